@@ -1,7 +1,12 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/spellbook/spellbook/internal/app"
 )
@@ -13,8 +18,20 @@ func main() {
 		log.Fatalf("Erro ao inicializar aplicação: %v", err)
 	}
 
+	// Encerrar de forma graciosa ao receber SIGTERM (orquestrador) ou Ctrl+C
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
 	// Iniciar servidor
-	if err := application.Run(); err != nil {
-		log.Fatalf("Erro ao iniciar servidor: %v", err)
+	runErr := application.Run(ctx)
+
+	closeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := application.Close(closeCtx); err != nil {
+		log.Printf("Erro ao encerrar aplicação: %v", err)
+	}
+
+	if runErr != nil {
+		log.Fatalf("Erro ao iniciar servidor: %v", runErr)
 	}
 }
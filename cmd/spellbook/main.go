@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+
+	"github.com/spellbook/spellbook/internal/cli"
+	"github.com/spellbook/spellbook/internal/config"
+	"github.com/spellbook/spellbook/internal/logging"
+	"github.com/spellbook/spellbook/internal/prompts"
+	"github.com/spellbook/spellbook/internal/services"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	os.Exit(cli.Run(ctx, os.Args[1:], os.Stdout, os.Stderr, newLocalService))
+}
+
+// newLocalService cria o GeminiService a partir da mesma configuração do servidor
+// (CONFIG_FILE, .env e variáveis de ambiente)
+func newLocalService() (services.GeminiServiceInterface, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar configurações: %w", err)
+	}
+	if len(cfg.APIKeys()) == 0 {
+		return nil, fmt.Errorf("GEMINI_API_KEY não configurada. Configure no arquivo .env, no arquivo de configuração ou use --server")
+	}
+
+	// Logs vão para stderr, para não misturar com o resultado em stdout
+	logLevel := os.Getenv("LOG_LEVEL")
+	if logLevel == "" {
+		logLevel = "warn"
+	}
+	slog.SetDefault(logging.New(os.Stderr, logLevel))
+
+	promptRegistry, err := prompts.NewRegistry(cfg.PromptsDir)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar templates de prompt: %w", err)
+	}
+
+	resourceCatalog, err := cfg.LoadCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	geminiService := services.NewGeminiService(cfg.APIKeys()...)
+	geminiService.Apply(cfg.GeminiSettings(promptRegistry, resourceCatalog))
+	return geminiService, nil
+}
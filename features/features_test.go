@@ -11,12 +11,13 @@ import (
 func TestFeatures(t *testing.T) {
 	suite := godog.TestSuite{
 		ScenarioInitializer: func(ctx *godog.ScenarioContext) {
+			step_definitions.InitializeGeminiScenario(ctx)
 			step_definitions.InitializeRoadmapScenario(ctx)
 			step_definitions.InitializeTopicsScenario(ctx)
 		},
 		Options: &godog.Options{
 			Format:   "pretty",
-			Paths:    []string{"features"},
+			Paths:    []string{"."},
 			TestingT: t,
 		},
 	}
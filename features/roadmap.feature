@@ -19,6 +19,6 @@ Feature: Gerar Roadmap de Estudo
   Scenario: Gerar roadmap sem API key
     Given que não tenho uma API key do Gemini configurada
     When eu envio uma requisição POST para /roadmap com topic "Python"
-    Then a resposta deve ter status 500
+    Then a resposta deve ter status 503
     And a resposta deve conter uma mensagem de erro sobre API key
 
@@ -0,0 +1,114 @@
+package step_definitions
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/cucumber/godog"
+	"github.com/spellbook/spellbook/internal/config"
+	"github.com/spellbook/spellbook/internal/fakegemini"
+	"github.com/spellbook/spellbook/internal/services"
+)
+
+// FixturesDir guarda as interações gravadas com a API real, uma cassete por cenário
+const FixturesDir = "testdata"
+
+// geminiEnv define, por cenário, qual API do Gemini os steps usam:
+//   - com GEMINI_RECORD=1, a API real, gravando as interações em FixturesDir
+//   - se existe uma cassete gravada para o cenário, a reprodução dela
+//   - caso contrário, o Gemini fake com respostas geradas a partir do prompt
+//
+// Só o modo de gravação precisa de GEMINI_API_KEY e de rede.
+type geminiEnv struct {
+	server *fakegemini.Server
+	apiKey string
+}
+
+var gemini = &geminiEnv{}
+
+func (g *geminiEnv) start(ctx context.Context, sc *godog.Scenario) (context.Context, error) {
+	cassette := filepath.Join(FixturesDir, slug(sc.Name)+".json")
+
+	switch {
+	case os.Getenv("GEMINI_RECORD") == "1":
+		g.server = fakegemini.NewRecorder(services.NewGeminiService("").BaseURL, cassette)
+
+	case fileExists(cassette):
+		server, err := fakegemini.NewReplay(cassette)
+		if err != nil {
+			return ctx, err
+		}
+		g.server = server
+
+	default:
+		g.server = fakegemini.New()
+	}
+
+	g.apiKey = ""
+	return ctx, nil
+}
+
+func (g *geminiEnv) stop(ctx context.Context, sc *godog.Scenario, err error) (context.Context, error) {
+	if g.server == nil {
+		return ctx, nil
+	}
+	closeErr := g.server.Close()
+	g.server = nil
+	return ctx, closeErr
+}
+
+func (g *geminiEnv) iHaveAValidGeminiAPIKey() error {
+	if os.Getenv("GEMINI_RECORD") != "1" {
+		g.apiKey = "fake-key"
+		return nil
+	}
+
+	cfg := config.LoadForTesting()
+	if cfg.GeminiAPIKey == "" {
+		return fmt.Errorf("GEMINI_API_KEY não configurada para gravação")
+	}
+	g.apiKey = cfg.GeminiAPIKey
+	return nil
+}
+
+func (g *geminiEnv) iDoNotHaveAGeminiAPIKeyConfigured() error {
+	g.apiKey = ""
+	return nil
+}
+
+// newService cria o serviço apontando para a API escolhida para o cenário
+func (g *geminiEnv) newService() *services.GeminiService {
+	service := services.NewGeminiService(g.apiKey)
+	service.BaseURL = g.server.URL
+	if os.Getenv("GEMINI_RECORD") != "1" {
+		service.QuotaRetryDelay = 10 * time.Millisecond
+	}
+	return service
+}
+
+func slug(name string) string {
+	return strings.Trim(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '-'
+	}, name), "-")
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func InitializeGeminiScenario(ctx *godog.ScenarioContext) {
+	ctx.Before(gemini.start)
+	ctx.After(gemini.stop)
+
+	ctx.Step(`^que tenho uma API key válida do Gemini$`, gemini.iHaveAValidGeminiAPIKey)
+	ctx.Step(`^que não tenho uma API key do Gemini configurada$`, gemini.iDoNotHaveAGeminiAPIKeyConfigured)
+}
@@ -6,47 +6,28 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"os"
 
 	"github.com/cucumber/godog"
 	"github.com/gin-gonic/gin"
-	"github.com/spellbook/spellbook/internal/config"
 	"github.com/spellbook/spellbook/internal/handlers"
-	"github.com/spellbook/spellbook/internal/services"
 )
 
 type apiFeature struct {
-	router     *gin.Engine
-	response   *httptest.ResponseRecorder
-	apiKey     string
-	originalKey string
+	router   *gin.Engine
+	response *httptest.ResponseRecorder
 }
 
+// api guarda o router e a resposta do cenário atual, usados pelos steps de todas as features
+var api = &apiFeature{}
+
 func (a *apiFeature) resetResponse(*godog.Scenario) {
 	gin.SetMode(gin.TestMode)
 	a.router = gin.New()
 	a.response = httptest.NewRecorder()
 }
 
-func (a *apiFeature) iHaveAValidGeminiAPIKey() error {
-	cfg := config.LoadForTesting()
-	if cfg.GeminiAPIKey == "" {
-		return fmt.Errorf("GEMINI_API_KEY não configurada para testes")
-	}
-	a.apiKey = cfg.GeminiAPIKey
-	a.originalKey = os.Getenv("GEMINI_API_KEY")
-	os.Setenv("GEMINI_API_KEY", a.apiKey)
-	return nil
-}
-
-func (a *apiFeature) iDoNotHaveAGeminiAPIKeyConfigured() error {
-	a.originalKey = os.Getenv("GEMINI_API_KEY")
-	os.Unsetenv("GEMINI_API_KEY")
-	return nil
-}
-
 func (a *apiFeature) iSendAPOSTRequestToRoadmapWithTopic(topic string) error {
-	geminiService := services.NewGeminiService(os.Getenv("GEMINI_API_KEY"))
+	geminiService := gemini.newService()
 	roadmapHandler := handlers.NewRoadmapHandler(geminiService)
 	
 	a.router.POST("/roadmap", roadmapHandler.GenerateRoadmap)
@@ -142,9 +123,9 @@ func (a *apiFeature) theResponseShouldContainAnErrorMessageAboutAPIKey() error {
 		return fmt.Errorf("erro ao fazer parse da resposta: %v", err)
 	}
 
-	errorMsg, ok := errorResp["error"].(string)
+	errorMsg, ok := errorResp["detail"].(string)
 	if !ok {
-		return fmt.Errorf("resposta não contém campo 'error'")
+		return fmt.Errorf("resposta não contém campo 'detail'")
 	}
 
 	if errorMsg == "" {
@@ -155,12 +136,9 @@ func (a *apiFeature) theResponseShouldContainAnErrorMessageAboutAPIKey() error {
 }
 
 func InitializeRoadmapScenario(ctx *godog.ScenarioContext) {
-	api := &apiFeature{}
 
 	ctx.BeforeScenario(api.resetResponse)
 
-	ctx.Step(`^que tenho uma API key válida do Gemini$`, api.iHaveAValidGeminiAPIKey)
-	ctx.Step(`^que não tenho uma API key do Gemini configurada$`, api.iDoNotHaveAGeminiAPIKeyConfigured)
 	ctx.Step(`^eu envio uma requisição POST para /roadmap com topic "([^"]*)"$`, api.iSendAPOSTRequestToRoadmapWithTopic)
 	ctx.Step(`^a resposta deve ter status (\d+)$`, api.theResponseShouldHaveStatus)
 	ctx.Step(`^a resposta deve conter um roadmap com pelo menos (\d+) categorias$`, api.theResponseShouldContainARoadmapWithAtLeastCategories)
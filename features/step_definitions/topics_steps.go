@@ -5,31 +5,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/http/httptest"
-	"os"
 
 	"github.com/cucumber/godog"
-	"github.com/gin-gonic/gin"
 	"github.com/spellbook/spellbook/internal/handlers"
-	"github.com/spellbook/spellbook/internal/services"
 )
 
-type topicsFeature struct {
-	router   *gin.Engine
-	response *httptest.ResponseRecorder
-}
-
-func (t *topicsFeature) resetResponse(*godog.Scenario) {
-	gin.SetMode(gin.TestMode)
-	t.router = gin.New()
-	t.response = httptest.NewRecorder()
-}
+// topicsFeature usa o router e a resposta do cenário (api), compartilhados com os
+// steps genéricos como "a resposta deve ter status"
+type topicsFeature struct{}
 
 func (t *topicsFeature) iSendAPOSTRequestToTopicsWithSubjectAndCount(subject string, count int) error {
-	geminiService := services.NewGeminiService(os.Getenv("GEMINI_API_KEY"))
+	geminiService := gemini.newService()
 	topicsHandler := handlers.NewTopicsHandler(geminiService)
 	
-	t.router.POST("/topics", topicsHandler.GenerateTopics)
+	api.router.POST("/topics", topicsHandler.GenerateTopics)
 
 	reqBody := map[string]interface{}{
 		"subject": subject,
@@ -40,15 +29,15 @@ func (t *topicsFeature) iSendAPOSTRequestToTopicsWithSubjectAndCount(subject str
 	req, _ := http.NewRequest("POST", "/topics", bytes.NewBuffer(jsonData))
 	req.Header.Set("Content-Type", "application/json")
 
-	t.router.ServeHTTP(t.response, req)
+	api.router.ServeHTTP(api.response, req)
 	return nil
 }
 
 func (t *topicsFeature) iSendAPOSTRequestToTopicsWithSubject(subject string) error {
-	geminiService := services.NewGeminiService(os.Getenv("GEMINI_API_KEY"))
+	geminiService := gemini.newService()
 	topicsHandler := handlers.NewTopicsHandler(geminiService)
 	
-	t.router.POST("/topics", topicsHandler.GenerateTopics)
+	api.router.POST("/topics", topicsHandler.GenerateTopics)
 
 	reqBody := map[string]string{"subject": subject}
 	jsonData, _ := json.Marshal(reqBody)
@@ -56,13 +45,13 @@ func (t *topicsFeature) iSendAPOSTRequestToTopicsWithSubject(subject string) err
 	req, _ := http.NewRequest("POST", "/topics", bytes.NewBuffer(jsonData))
 	req.Header.Set("Content-Type", "application/json")
 
-	t.router.ServeHTTP(t.response, req)
+	api.router.ServeHTTP(api.response, req)
 	return nil
 }
 
 func (t *topicsFeature) theResponseShouldContainAListOfTopics() error {
 	var topicsResp map[string]interface{}
-	if err := json.Unmarshal(t.response.Body.Bytes(), &topicsResp); err != nil {
+	if err := json.Unmarshal(api.response.Body.Bytes(), &topicsResp); err != nil {
 		return fmt.Errorf("erro ao fazer parse da resposta: %v", err)
 	}
 
@@ -80,7 +69,7 @@ func (t *topicsFeature) theResponseShouldContainAListOfTopics() error {
 
 func (t *topicsFeature) theListShouldHaveAtLeastTopics(minTopics int) error {
 	var topicsResp map[string]interface{}
-	if err := json.Unmarshal(t.response.Body.Bytes(), &topicsResp); err != nil {
+	if err := json.Unmarshal(api.response.Body.Bytes(), &topicsResp); err != nil {
 		return fmt.Errorf("erro ao fazer parse da resposta: %v", err)
 	}
 
@@ -98,7 +87,7 @@ func (t *topicsFeature) theListShouldHaveAtLeastTopics(minTopics int) error {
 
 func (t *topicsFeature) theSubjectShouldBe(subject string) error {
 	var topicsResp map[string]interface{}
-	if err := json.Unmarshal(t.response.Body.Bytes(), &topicsResp); err != nil {
+	if err := json.Unmarshal(api.response.Body.Bytes(), &topicsResp); err != nil {
 		return fmt.Errorf("erro ao fazer parse da resposta: %v", err)
 	}
 
@@ -113,8 +102,6 @@ func (t *topicsFeature) theSubjectShouldBe(subject string) error {
 func InitializeTopicsScenario(ctx *godog.ScenarioContext) {
 	topics := &topicsFeature{}
 
-	ctx.BeforeScenario(topics.resetResponse)
-
 	ctx.Step(`^eu envio uma requisição POST para /topics com subject "([^"]*)" e count (\d+)$`, topics.iSendAPOSTRequestToTopicsWithSubjectAndCount)
 	ctx.Step(`^eu envio uma requisição POST para /topics com subject "([^"]*)"$`, topics.iSendAPOSTRequestToTopicsWithSubject)
 	ctx.Step(`^a resposta deve conter uma lista de tópicos$`, topics.theResponseShouldContainAListOfTopics)
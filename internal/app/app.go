@@ -1,14 +1,27 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
 
 	"github.com/gin-gonic/gin"
 	"github.com/spellbook/spellbook/internal/config"
+	"github.com/spellbook/spellbook/internal/grpcapi"
 	"github.com/spellbook/spellbook/internal/handlers"
+	"github.com/spellbook/spellbook/internal/health"
+	"github.com/spellbook/spellbook/internal/logging"
+	"github.com/spellbook/spellbook/internal/middleware"
+	"github.com/spellbook/spellbook/internal/prompts"
 	"github.com/spellbook/spellbook/internal/routes"
 	"github.com/spellbook/spellbook/internal/services"
+	"github.com/spellbook/spellbook/internal/spells"
+	"github.com/spellbook/spellbook/internal/tracing"
+	"google.golang.org/grpc"
 )
 
 // App representa a aplicação e suas dependências
@@ -18,7 +31,21 @@ type App struct {
 	RoadmapHandler    *handlers.RoadmapHandler
 	TopicsHandler     *handlers.TopicsHandler
 	KeyResultsHandler *handlers.KeyResultsHandler
+	BatchHandler      *handlers.BatchHandler
+	HealthHandler     *handlers.HealthHandler
+	AdminHandler      *handlers.AdminHandler
+	SpellsHandler     *handlers.SpellsHandler
 	Router            *gin.Engine
+	Server            *http.Server
+	GRPCServer        *grpc.Server
+	CORS              *middleware.CORS
+	RateLimiter       *middleware.RateLimiter
+	AdminAuth         *middleware.AdminAuth
+	// ConfigWatcher recarrega a configuração ao receber SIGHUP ou quando o arquivo muda
+	ConfigWatcher *config.Watcher
+
+	logLevel        *slog.LevelVar
+	shutdownTracing func(context.Context) error
 }
 
 // NewApp cria e inicializa uma nova instância da aplicação
@@ -29,35 +56,203 @@ func NewApp() (*App, error) {
 		return nil, fmt.Errorf("erro ao carregar configurações: %w", err)
 	}
 
+	// Configurar logs estruturados em JSON (o nível pode mudar ao recarregar a configuração)
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(logging.ParseLevel(cfg.LogLevel))
+	slog.SetDefault(logging.NewWithLevel(os.Stdout, logLevel))
+
+	if len(cfg.APIKeys()) == 0 {
+		slog.Warn("GEMINI_API_KEY não configurada: as gerações responderão 503 até a chave ser configurada")
+	}
+
+	// Configurar tracing OpenTelemetry
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.OTLPEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao configurar tracing: %w", err)
+	}
+
+	// Carregar templates de prompt (embutidos + diretório opcional)
+	promptRegistry, err := prompts.NewRegistry(cfg.PromptsDir)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar templates de prompt: %w", err)
+	}
+
+	// Carregar spells (gerações definidas em arquivos); só mudam ao reiniciar
+	spellRegistry, err := spells.Load(cfg.SpellsDir)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar spells: %w", err)
+	}
+
+	// Carregar o catálogo curado de recursos educacionais (opcional)
+	resourceCatalog, err := cfg.LoadCatalog()
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("catálogo de recursos carregado", "file", cfg.CatalogFile, "entries", resourceCatalog.Len())
+
 	// Criar serviço Gemini
-	geminiService := services.NewGeminiService(cfg.GeminiAPIKey)
+	geminiService := services.NewGeminiService(cfg.APIKeys()...)
+	geminiService.Apply(cfg.GeminiSettings(promptRegistry, resourceCatalog))
 
 	// Criar handlers
 	roadmapHandler := handlers.NewRoadmapHandler(geminiService)
 	topicsHandler := handlers.NewTopicsHandler(geminiService)
 	keyResultsHandler := handlers.NewKeyResultsHandler(geminiService)
+	batchHandler := handlers.NewBatchHandler(geminiService)
+	batchHandler.Jobs.SetTTL(cfg.JobsTTL)
+	batchHandler.Runner.Keys = geminiService.Keys
+
+	// Health check detalhado: Gemini (alcance, API key, modelos, cache, quota) e jobs de lote
+	healthChecker := health.NewChecker()
+	health.RegisterGemini(healthChecker, geminiService, health.DefaultThresholds)
+	health.RegisterJobs(healthChecker, batchHandler.Jobs)
+	healthHandler := handlers.NewHealthHandler(geminiService, healthChecker)
+	adminHandler := handlers.NewAdminHandler(geminiService.Keys)
+	spellsHandler := handlers.NewSpellsHandler(spellRegistry, geminiService)
+
+	// Servidor gRPC compartilha o mesmo serviço dos handlers REST
+	grpcServer := grpcapi.NewGRPCServer(geminiService)
 
 	// Configurar Gin
 	gin.SetMode(gin.ReleaseMode)
-	router := gin.Default()
+	router := gin.New()
+	router.Use(gin.Recovery())
 
 	// Configurar rotas
-	routes.SetupRoutes(router, roadmapHandler, topicsHandler, keyResultsHandler)
+	cors := middleware.NewCORS(cfg.CORSOrigins)
+	rateLimiter := middleware.NewRateLimiter(cfg.RateLimitRPS, cfg.RateLimitBurst)
+	adminAuth := middleware.NewAdminAuth(cfg.AdminToken)
+	routes.SetupRoutes(router, roadmapHandler, topicsHandler, keyResultsHandler, batchHandler, healthHandler, adminHandler, spellsHandler, cors, rateLimiter, adminAuth)
+
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%s", cfg.Port),
+		Handler:      router,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
 
-	return &App{
+	app := &App{
 		Config:            cfg,
 		GeminiService:     geminiService,
 		RoadmapHandler:    roadmapHandler,
 		TopicsHandler:     topicsHandler,
 		KeyResultsHandler: keyResultsHandler,
+		BatchHandler:      batchHandler,
+		HealthHandler:     healthHandler,
+		AdminHandler:      adminHandler,
+		SpellsHandler:     spellsHandler,
 		Router:            router,
-	}, nil
+		Server:            server,
+		GRPCServer:        grpcServer,
+		CORS:              cors,
+		RateLimiter:       rateLimiter,
+		AdminAuth:         adminAuth,
+		logLevel:          logLevel,
+		shutdownTracing:   shutdownTracing,
+	}
+	app.ConfigWatcher = config.NewWatcher(cfg, app.applyConfig)
+
+	return app, nil
+}
+
+// applyConfig aplica uma configuração recarregada às dependências que aceitam troca em execução.
+// Portas, timeouts do servidor e tracing só mudam ao reiniciar.
+func (a *App) applyConfig(cfg *config.Config) {
+	a.logLevel.Set(logging.ParseLevel(cfg.LogLevel))
+
+	// Os templates são relidos a cada recarga; com erro, os anteriores continuam em uso
+	registry, err := prompts.NewRegistry(cfg.PromptsDir)
+	if err != nil {
+		slog.Error("erro ao recarregar templates de prompt, mantendo os anteriores", "error", err)
+		registry = nil
+	}
+
+	// O catálogo também é relido; com erro, o anterior continua em uso
+	resourceCatalog, err := cfg.LoadCatalog()
+	if err != nil {
+		slog.Error("erro ao recarregar catálogo de recursos, mantendo o anterior", "error", err)
+	}
+	a.GeminiService.Apply(cfg.GeminiSettings(registry, resourceCatalog))
+
+	a.CORS.SetOrigins(cfg.CORSOrigins)
+	a.RateLimiter.SetLimit(cfg.RateLimitRPS, cfg.RateLimitBurst)
+	a.AdminAuth.SetToken(cfg.AdminToken)
+	a.BatchHandler.Jobs.SetTTL(cfg.JobsTTL)
 }
 
-// Run inicia o servidor HTTP
-func (a *App) Run() error {
-	addr := fmt.Sprintf(":%s", a.Config.Port)
-	log.Printf("Servidor Spellbook iniciado na porta %s", a.Config.Port)
+// Run inicia os servidores HTTP e gRPC e bloqueia até o contexto ser cancelado (ex: SIGTERM)
+// ou um dos servidores falhar; em seguida encerra a aplicação com Shutdown
+func (a *App) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", a.Config.GRPCPort))
+	if err != nil {
+		return fmt.Errorf("erro ao abrir porta gRPC: %w", err)
+	}
+
+	go a.ConfigWatcher.Run(ctx)
+
+	errs := make(chan error, 2)
+	go func() {
+		slog.Info("servidor gRPC iniciado", "port", a.Config.GRPCPort)
+		if err := a.GRPCServer.Serve(listener); err != nil {
+			errs <- err
+		}
+	}()
+	go func() {
+		slog.Info("servidor Spellbook iniciado", "port", a.Config.Port)
+		if err := a.Server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			errs <- err
+		}
+	}()
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		slog.Info("sinal de encerramento recebido, aguardando requisições em andamento",
+			"timeout", a.Config.ShutdownTimeout.String())
+	case runErr = <-errs:
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.Config.ShutdownTimeout)
+	defer cancel()
 
-	return a.Router.Run(addr)
+	return errors.Join(runErr, a.Shutdown(shutdownCtx))
+}
+
+// Shutdown encerra a aplicação de forma graciosa até o prazo do contexto: /ready passa a
+// responder 503, os servidores param de aceitar conexões e aguardam as requisições em
+// andamento, e os jobs de lote em segundo plano são concluídos (ou cancelados no prazo)
+func (a *App) Shutdown(ctx context.Context) error {
+	a.HealthHandler.SetDraining()
+
+	var errs []error
+	if err := a.Server.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("erro ao encerrar servidor HTTP: %w", err))
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		a.GRPCServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		a.GRPCServer.Stop()
+	}
+
+	if err := a.BatchHandler.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("jobs de lote cancelados no encerramento: %w", err))
+	}
+
+	slog.Info("servidores encerrados")
+	return errors.Join(errs...)
+}
+
+// Close libera os recursos da aplicação, enviando os spans pendentes ao coletor
+func (a *App) Close(ctx context.Context) error {
+	if a.shutdownTracing == nil {
+		return nil
+	}
+	return a.shutdownTracing(ctx)
 }
@@ -0,0 +1,148 @@
+package apperror
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/i18n"
+)
+
+// Kind classifica o erro e define o status HTTP da resposta
+type Kind string
+
+const (
+	KindValidation          Kind = "validation"
+	KindUpstreamQuota       Kind = "upstream_quota"
+	KindUpstreamUnavailable Kind = "upstream_unavailable"
+	KindOutputInvalid       Kind = "output_invalid"
+	KindTimeout             Kind = "timeout"
+	KindUnauthorized        Kind = "unauthorized"
+	KindNotFound            Kind = "not_found"
+	KindRateLimited         Kind = "rate_limited"
+	KindUnauthenticated     Kind = "unauthenticated"
+	KindInternal            Kind = "internal"
+)
+
+// Códigos estáveis devolvidos no campo code das respostas de erro. São também as chaves
+// das mensagens traduzidas em internal/i18n
+const (
+	CodeTopicRequired       = i18n.MsgTopicRequired
+	CodeTopicEmpty          = i18n.MsgTopicEmpty
+	CodeSubjectRequired     = i18n.MsgSubjectRequired
+	CodeSubjectEmpty        = i18n.MsgSubjectEmpty
+	CodeObjectiveRequired   = i18n.MsgObjectiveRequired
+	CodeObjectiveEmpty      = i18n.MsgObjectiveEmpty
+	CodeRequestInvalid      = i18n.MsgRequestInvalid
+	CodeJobNotFound         = i18n.MsgJobNotFound
+	CodeSpellNotFound       = i18n.MsgSpellNotFound
+	CodeRateLimited         = i18n.MsgRateLimited
+	CodeAdminUnauthorized   = i18n.MsgAdminUnauthorized
+	CodeAPIKeyMissing       = i18n.MsgAPIKeyMissing
+	CodeUpstreamQuota       = i18n.MsgUpstreamQuota
+	CodeUpstreamUnavailable = i18n.MsgUpstreamUnavailable
+	CodeUpstreamRejected    = i18n.MsgUpstreamRejected
+	CodeModelForbidden      = i18n.MsgModelForbidden
+	CodeOutputInvalid       = i18n.MsgOutputInvalid
+	CodeTimeout             = i18n.MsgTimeout
+	CodeInternal            = i18n.MsgInternal
+)
+
+// Sentinelas para errors.Is: comparam apenas a categoria do erro
+var (
+	ErrValidation          = &Error{Kind: KindValidation}
+	ErrUpstreamQuota       = &Error{Kind: KindUpstreamQuota}
+	ErrUpstreamUnavailable = &Error{Kind: KindUpstreamUnavailable}
+	ErrOutputInvalid       = &Error{Kind: KindOutputInvalid}
+	ErrTimeout             = &Error{Kind: KindTimeout}
+	ErrUnauthorized        = &Error{Kind: KindUnauthorized}
+	ErrNotFound            = &Error{Kind: KindNotFound}
+	ErrRateLimited         = &Error{Kind: KindRateLimited}
+	ErrUnauthenticated     = &Error{Kind: KindUnauthenticated}
+)
+
+// Error é um erro da aplicação com categoria, código estável e mensagem segura para o cliente.
+// A causa (Err) pode conter detalhes do upstream e só deve aparecer em logs.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+	Err     error
+	// RetryAfter é a espera sugerida ao cliente antes de repetir a chamada (header Retry-After)
+	RetryAfter time.Duration
+}
+
+// FieldError descreve um campo inválido da requisição
+type FieldError struct {
+	Field   string `json:"field"` // Caminho do campo como JSON Pointer (ex: /count)
+	Message string `json:"message"`
+}
+
+// New cria um erro sem causa
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Wrap cria um erro que encapsula a causa original
+func Wrap(kind Kind, code, message string, err error) *Error {
+	return &Error{Kind: kind, Code: code, Message: message, Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is compara a categoria e, quando o alvo tem código, também o código
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.Kind == e.Kind && (t.Code == "" || t.Code == e.Code)
+}
+
+// StatusCode retorna o status HTTP correspondente à categoria
+func (k Kind) StatusCode() int {
+	switch k {
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindUnauthenticated:
+		// O cliente não apresentou credencial válida para a nossa API
+		return http.StatusUnauthorized
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindUpstreamQuota, KindRateLimited:
+		return http.StatusTooManyRequests
+	case KindOutputInvalid, KindUnauthorized:
+		// O modelo respondeu algo inutilizável ou recusou nossa credencial
+		return http.StatusBadGateway
+	case KindUpstreamUnavailable:
+		return http.StatusServiceUnavailable
+	case KindTimeout:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// From converte qualquer erro em *Error. Prazos estourados viram timeout e
+// erros desconhecidos viram erro interno, sem expor a mensagem original.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return Wrap(KindTimeout, CodeTimeout, "tempo limite excedido", err)
+	}
+	return Wrap(KindInternal, CodeInternal, "erro interno", err)
+}
@@ -0,0 +1,82 @@
+package apperror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError_IsAndAs(t *testing.T) {
+	cause := errors.New("erro da API: 429 - corpo")
+	err := fmt.Errorf("erro ao gerar tópicos: %w", Wrap(KindUpstreamQuota, CodeUpstreamQuota, "quota excedida (429)", cause))
+
+	assert.True(t, errors.Is(err, ErrUpstreamQuota))
+	assert.True(t, errors.Is(err, &Error{Kind: KindUpstreamQuota, Code: CodeUpstreamQuota}))
+	assert.False(t, errors.Is(err, &Error{Kind: KindUpstreamQuota, Code: CodeTimeout}))
+	assert.False(t, errors.Is(err, ErrTimeout))
+	assert.True(t, errors.Is(err, cause))
+
+	var appErr *Error
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, CodeUpstreamQuota, appErr.Code)
+}
+
+func TestKind_StatusCode(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, KindValidation.StatusCode())
+	assert.Equal(t, http.StatusUnauthorized, KindUnauthenticated.StatusCode())
+	assert.Equal(t, http.StatusTooManyRequests, KindUpstreamQuota.StatusCode())
+	assert.Equal(t, http.StatusBadGateway, KindOutputInvalid.StatusCode())
+	assert.Equal(t, http.StatusBadGateway, KindUnauthorized.StatusCode())
+	assert.Equal(t, http.StatusServiceUnavailable, KindUpstreamUnavailable.StatusCode())
+	assert.Equal(t, http.StatusGatewayTimeout, KindTimeout.StatusCode())
+	assert.Equal(t, http.StatusInternalServerError, KindInternal.StatusCode())
+}
+
+func TestFrom(t *testing.T) {
+	assert.Equal(t, KindTimeout, From(fmt.Errorf("falhou: %w", context.DeadlineExceeded)).Kind)
+	assert.Equal(t, KindInternal, From(errors.New("qualquer erro")).Kind)
+
+	original := New(KindValidation, CodeTopicEmpty, "tópico não pode ser vazio")
+	assert.Same(t, original, From(original))
+}
+
+func TestNewProblem_Localized(t *testing.T) {
+	err := New(KindValidation, CodeTopicEmpty, "tópico não pode ser vazio")
+
+	problem := NewProblem(err, "en")
+
+	assert.Equal(t, "urn:spellbook:error:topic_empty", problem.Type)
+	assert.Equal(t, "Invalid request", problem.Title)
+	assert.Equal(t, "topic cannot be empty", problem.Detail)
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Equal(t, CodeTopicEmpty, problem.Code)
+}
+
+func TestNewProblem_UnknownCodeUsesMessage(t *testing.T) {
+	problem := NewProblem(New(KindValidation, "campo_novo", "campo novo inválido"), "pt-BR")
+
+	assert.Equal(t, "Requisição inválida", problem.Title)
+	assert.Equal(t, "campo novo inválido", problem.Detail)
+}
+
+func TestRespond_RetryAfter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/topics", nil)
+
+	err := New(KindUpstreamQuota, CodeUpstreamQuota, "quota excedida")
+	err.RetryAfter = 1500 * time.Millisecond
+	Respond(c, "pt-BR", err)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+}
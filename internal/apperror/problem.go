@@ -0,0 +1,36 @@
+package apperror
+
+import "github.com/spellbook/spellbook/internal/i18n"
+
+// ContentType é o media type das respostas de erro (RFC 7807)
+const ContentType = "application/problem+json"
+
+// Problem é o corpo das respostas de erro no formato RFC 7807
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// NewProblem monta o corpo da resposta com título e detalhe no idioma informado.
+// O detalhe vem da tradução do código; sem tradução, usa a mensagem do erro.
+func NewProblem(err *Error, lang string) Problem {
+	detail, ok := i18n.Lookup(lang, err.Code)
+	if !ok {
+		detail = err.Message
+	}
+
+	return Problem{
+		Type:   "urn:spellbook:error:" + err.Code,
+		Title:  i18n.Message(lang, "title."+string(err.Kind)),
+		Status: err.Kind.StatusCode(),
+		Detail: detail,
+		Code:   err.Code,
+		Errors: err.Fields,
+	}
+}
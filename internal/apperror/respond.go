@@ -0,0 +1,30 @@
+package apperror
+
+import (
+	"log/slog"
+	"math"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Respond converte o erro em *Error e responde no formato problem+json, com status,
+// título e detalhe definidos pela categoria e pelo código do erro.
+// A causa original (ex: corpo da resposta do upstream) vai apenas para o log.
+func Respond(c *gin.Context, lang string, err error) {
+	appErr := From(err)
+	problem := NewProblem(appErr, lang)
+	problem.Instance = c.Request.URL.Path
+	problem.RequestID = c.GetString("request_id")
+
+	if problem.Status >= 500 {
+		slog.ErrorContext(c.Request.Context(), "erro ao processar requisição",
+			"code", appErr.Code, "status", problem.Status, "error", err.Error())
+	}
+
+	if appErr.RetryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(appErr.RetryAfter.Seconds()))))
+	}
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
@@ -0,0 +1,238 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/i18n"
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/spellbook/spellbook/internal/openapi"
+	"github.com/spellbook/spellbook/internal/services"
+)
+
+// MaxItems é o limite de itens por lote
+const MaxItems = 50
+
+// Tipos de item aceitos, com o path do endpoint REST equivalente (usado na validação)
+var itemPaths = map[string]string{
+	"roadmap":             "/api/v1/roadmap",
+	"topics":              "/api/v1/topics",
+	"key-results":         "/api/v1/key-results",
+	"educational-roadmap": "/api/v1/educational-roadmap",
+	"educational-trail":   "/api/v1/educational-trail",
+}
+
+// Item é uma geração do lote: o tipo e o mesmo corpo aceito pelo endpoint equivalente
+type Item struct {
+	Type    string          `json:"type"`
+	Request json.RawMessage `json:"request"`
+}
+
+// Request é o corpo de POST /api/v1/batch
+type Request struct {
+	Items    []Item `json:"items"`
+	Async    bool   `json:"async,omitempty"`    // Executa como job e responde 202 com o ID
+	Language string `json:"language,omitempty"` // Idioma padrão dos itens que não informam language
+}
+
+// Result é o resultado de um item, na mesma posição do item na requisição
+type Result struct {
+	Index  int               `json:"index"`
+	Type   string            `json:"type"`
+	Status int               `json:"status"`
+	Result interface{}       `json:"result,omitempty"`
+	Error  *apperror.Problem `json:"error,omitempty"`
+}
+
+// Response é o corpo da resposta síncrona
+type Response struct {
+	Results []Result `json:"results"`
+}
+
+// QuotaWaiter informa quanto falta para alguma API key sair da espera por quota (ver
+// services.KeyPool)
+type QuotaWaiter interface {
+	Wait() time.Duration
+}
+
+// Runner executa lotes com concorrência limitada. Antes de iniciar, cada item aguarda alguma
+// API key de Keys sair da espera por quota, para não esgotar a quota em rajada. Sem Keys,
+// um erro de quota em um item faz os demais aguardarem QuotaCooldown.
+type Runner struct {
+	Service       services.GeminiServiceInterface
+	Spec          *openapi.Spec
+	Keys          QuotaWaiter
+	Concurrency   int
+	QuotaCooldown time.Duration
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// NewRunner cria um runner com concorrência 4 e pausa de 30s após erro de quota
+func NewRunner(service services.GeminiServiceInterface) *Runner {
+	return &Runner{
+		Service:       service,
+		Spec:          openapi.Load(),
+		Concurrency:   4,
+		QuotaCooldown: 30 * time.Second,
+	}
+}
+
+// Run executa os itens e retorna os resultados na ordem dos itens
+func (r *Runner) Run(ctx context.Context, items []Item, lang string) []Result {
+	results := make([]Result, len(items))
+
+	concurrency := r.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			results[i] = r.runItem(ctx, i, item, lang)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (r *Runner) runItem(ctx context.Context, index int, item Item, defaultLang string) Result {
+	result := Result{Index: index, Type: item.Type}
+
+	var value interface{}
+	err := r.waitQuota(ctx)
+	if err == nil {
+		value, err = r.execute(ctx, item, defaultLang)
+	}
+	if err != nil {
+		if errors.Is(err, apperror.ErrUpstreamQuota) && r.Keys == nil {
+			r.pause()
+		}
+		problem := apperror.NewProblem(apperror.From(err), itemLanguage(item, defaultLang))
+		result.Status = problem.Status
+		result.Error = &problem
+		return result
+	}
+
+	result.Status = http.StatusOK
+	result.Result = value
+	return result
+}
+
+// waitQuota aguarda alguma API key sair da espera por quota ou, sem Keys, o fim da pausa
+// causada por erro de quota em outro item
+func (r *Runner) waitQuota(ctx context.Context) error {
+	var wait time.Duration
+	if r.Keys != nil {
+		wait = r.Keys.Wait()
+	} else {
+		r.mu.Lock()
+		wait = time.Until(r.pausedUntil)
+		r.mu.Unlock()
+	}
+
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Runner) pause() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if until := time.Now().Add(r.QuotaCooldown); until.After(r.pausedUntil) {
+		r.pausedUntil = until
+	}
+}
+
+// execute valida o corpo do item com o schema do endpoint equivalente e chama o serviço
+func (r *Runner) execute(ctx context.Context, item Item, defaultLang string) (interface{}, error) {
+	path, ok := itemPaths[item.Type]
+	if !ok {
+		return nil, apperror.New(apperror.KindValidation, apperror.CodeRequestInvalid, "tipo de item desconhecido: "+item.Type)
+	}
+
+	if fields := r.Spec.ValidateRequest(http.MethodPost, path, item.Request); len(fields) > 0 {
+		appErr := apperror.New(apperror.KindValidation, apperror.CodeRequestInvalid, "requisição não segue o schema")
+		appErr.Fields = fields
+		return nil, appErr
+	}
+
+	lang := itemLanguage(item, defaultLang)
+
+	switch item.Type {
+	case "roadmap":
+		var req models.RoadmapRequest
+		if err := json.Unmarshal(item.Request, &req); err != nil {
+			return nil, err
+		}
+		return r.Service.GenerateRoadmap(ctx, req.Topic, req.AvailableDays, req.ExactItemCount, lang)
+
+	case "topics":
+		var req models.TopicsRequest
+		if err := json.Unmarshal(item.Request, &req); err != nil {
+			return nil, err
+		}
+		if req.Count <= 0 {
+			req.Count = 10
+		}
+		return r.Service.GenerateTopics(ctx, req.Subject, req.Count, lang)
+
+	case "key-results":
+		var req models.KeyResultsRequest
+		if err := json.Unmarshal(item.Request, &req); err != nil {
+			return nil, err
+		}
+		if req.Count <= 0 {
+			req.Count = 5
+		}
+		return r.Service.GenerateKeyResults(ctx, req.Objective, req.Count, req.CompletionDate, lang)
+
+	case "educational-roadmap":
+		var req models.EducationalRoadmapRequest
+		if err := json.Unmarshal(item.Request, &req); err != nil {
+			return nil, err
+		}
+		return r.Service.GenerateEducationalRoadmap(ctx, req.Topic, lang)
+
+	default:
+		var req models.EducationalTrailRequest
+		if err := json.Unmarshal(item.Request, &req); err != nil {
+			return nil, err
+		}
+		return r.Service.GenerateEducationalTrail(ctx, req.Topic, req.AvailableDays, lang)
+	}
+}
+
+// itemLanguage usa o language do próprio item ou, na falta dele, o idioma do lote
+func itemLanguage(item Item, defaultLang string) string {
+	var req struct {
+		Language string `json:"language"`
+	}
+	_ = json.Unmarshal(item.Request, &req)
+	if req.Language == "" {
+		return defaultLang
+	}
+	return i18n.Resolve(req.Language, "")
+}
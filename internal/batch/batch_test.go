@@ -0,0 +1,220 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/spellbook/spellbook/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ services.GeminiServiceInterface = (*fakeService)(nil)
+
+// fakeService responde com o próprio tema e permite simular erros e medir a concorrência
+type fakeService struct {
+	topicsErr func(subject string) error
+	delay     time.Duration
+
+	mu        sync.Mutex
+	running   int
+	maxActive int
+	languages []string
+}
+
+func (f *fakeService) enter(language string) func() {
+	f.mu.Lock()
+	f.running++
+	if f.running > f.maxActive {
+		f.maxActive = f.running
+	}
+	f.languages = append(f.languages, language)
+	f.mu.Unlock()
+
+	time.Sleep(f.delay)
+
+	return func() {
+		f.mu.Lock()
+		f.running--
+		f.mu.Unlock()
+	}
+}
+
+func (f *fakeService) GenerateRoadmap(ctx context.Context, topic string, availableDays *int, exactItemCount *int, language string) (*models.Roadmap, error) {
+	defer f.enter(language)()
+	return &models.Roadmap{Topic: topic}, nil
+}
+
+func (f *fakeService) GenerateTopics(ctx context.Context, subject string, count int, language string) (*models.TopicsResponse, error) {
+	defer f.enter(language)()
+	if f.topicsErr != nil {
+		if err := f.topicsErr(subject); err != nil {
+			return nil, err
+		}
+	}
+	topics := make([]string, count)
+	return &models.TopicsResponse{Subject: subject, Topics: topics}, nil
+}
+
+func (f *fakeService) GenerateKeyResults(ctx context.Context, objective string, count int, completionDate *string, language string) (*models.KeyResultsResponse, error) {
+	defer f.enter(language)()
+	return &models.KeyResultsResponse{Objective: objective, KeyResults: make([]string, count)}, nil
+}
+
+func (f *fakeService) GenerateEducationalRoadmap(ctx context.Context, topic string, language string) (*models.EducationalRoadmap, error) {
+	defer f.enter(language)()
+	return &models.EducationalRoadmap{Topic: topic}, nil
+}
+
+func (f *fakeService) GenerateEducationalTrail(ctx context.Context, topic string, availableDays *int, language string) (*models.EducationalTrail, error) {
+	defer f.enter(language)()
+	return &models.EducationalTrail{Topic: topic}, nil
+}
+
+func item(typ, request string) Item {
+	return Item{Type: typ, Request: json.RawMessage(request)}
+}
+
+func TestRunner_Run_ResultsInOrder(t *testing.T) {
+	service := &fakeService{}
+	runner := NewRunner(service)
+
+	results := runner.Run(context.Background(), []Item{
+		item("topics", `{"subject": "Go"}`),
+		item("roadmap", `{"topic": "Rust", "available_days": 10}`),
+		item("key-results", `{"objective": "Crescer", "count": 2, "language": "en"}`),
+		item("topics", `{"subject": "Go", "count": -1}`),
+		item("poema", `{"topic": "Go"}`),
+		item("educational-roadmap", `{"topic": "SQL"}`),
+		item("educational-trail", `{"topic": "SQL"}`),
+	}, "es")
+
+	require.Len(t, results, 7)
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+	}
+
+	assert.Equal(t, http.StatusOK, results[0].Status)
+	assert.Len(t, results[0].Result.(*models.TopicsResponse).Topics, 10, "count padrão")
+	assert.Equal(t, "Rust", results[1].Result.(*models.Roadmap).Topic)
+	assert.Len(t, results[2].Result.(*models.KeyResultsResponse).KeyResults, 2)
+
+	require.NotNil(t, results[3].Error)
+	assert.Equal(t, http.StatusBadRequest, results[3].Status)
+	assert.Equal(t, apperror.CodeRequestInvalid, results[3].Error.Code)
+	assert.Equal(t, "/count", results[3].Error.Errors[0].Field)
+	assert.Nil(t, results[3].Result)
+
+	require.NotNil(t, results[4].Error)
+	assert.Equal(t, http.StatusBadRequest, results[4].Status)
+
+	assert.Equal(t, http.StatusOK, results[5].Status)
+	assert.Equal(t, http.StatusOK, results[6].Status)
+
+	// Itens sem language usam o idioma do lote
+	assert.ElementsMatch(t, []string{"es", "es", "en", "es", "es"}, service.languages)
+}
+
+func TestRunner_Run_BoundedConcurrency(t *testing.T) {
+	service := &fakeService{delay: 20 * time.Millisecond}
+	runner := NewRunner(service)
+	runner.Concurrency = 2
+
+	items := make([]Item, 8)
+	for i := range items {
+		items[i] = item("topics", `{"subject": "Go"}`)
+	}
+
+	results := runner.Run(context.Background(), items, "pt-BR")
+
+	assert.Len(t, results, 8)
+	assert.Equal(t, 2, service.maxActive)
+}
+
+func TestRunner_Run_QuotaPausesOtherItems(t *testing.T) {
+	var calls int32
+	service := &fakeService{topicsErr: func(subject string) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return apperror.New(apperror.KindUpstreamQuota, apperror.CodeUpstreamQuota, "quota excedida (429)")
+		}
+		return nil
+	}}
+	runner := NewRunner(service)
+	runner.Concurrency = 1
+	runner.QuotaCooldown = 50 * time.Millisecond
+
+	start := time.Now()
+	results := runner.Run(context.Background(), []Item{
+		item("topics", `{"subject": "A"}`),
+		item("topics", `{"subject": "B"}`),
+	}, "pt-BR")
+
+	// A ordem de início entre os itens não é garantida: o primeiro a executar recebe o 429
+	statuses := []int{results[0].Status, results[1].Status}
+	assert.ElementsMatch(t, []int{http.StatusTooManyRequests, http.StatusOK}, statuses)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+// fakeKeys simula o pool de API keys com todas as chaves em espera até until
+type fakeKeys struct {
+	until time.Time
+}
+
+func (k fakeKeys) Wait() time.Duration {
+	return max(time.Until(k.until), 0)
+}
+
+func TestRunner_Run_WaitsForKeyPool(t *testing.T) {
+	service := &fakeService{topicsErr: func(string) error {
+		return apperror.New(apperror.KindUpstreamQuota, apperror.CodeUpstreamQuota, "quota excedida (429)")
+	}}
+	runner := NewRunner(service)
+	runner.Keys = fakeKeys{until: time.Now().Add(50 * time.Millisecond)}
+	// Com o pool, o erro de quota não gera pausa própria do lote
+	runner.QuotaCooldown = time.Hour
+
+	start := time.Now()
+	results := runner.Run(context.Background(), []Item{
+		item("topics", `{"subject": "A"}`),
+		item("topics", `{"subject": "B"}`),
+	}, "pt-BR")
+
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Less(t, time.Since(start), time.Minute)
+	for _, result := range results {
+		assert.Equal(t, http.StatusTooManyRequests, result.Status)
+	}
+}
+
+func TestJobStore(t *testing.T) {
+	store := NewJobStore()
+
+	job := store.Create(2)
+	assert.Equal(t, JobPending, job.Status)
+	assert.Equal(t, 2, job.Total)
+
+	store.Start(job.ID)
+	running, ok := store.Get(job.ID)
+	require.True(t, ok)
+	assert.Equal(t, JobRunning, running.Status)
+
+	store.Complete(job.ID, []Result{{Index: 0, Status: http.StatusOK}})
+	completed, ok := store.Get(job.ID)
+	require.True(t, ok)
+	assert.Equal(t, JobCompleted, completed.Status)
+	assert.NotNil(t, completed.CompletedAt)
+	assert.Len(t, completed.Results, 1)
+
+	// Jobs concluídos expiram após o TTL
+	store.TTL = 0
+	time.Sleep(time.Millisecond)
+	_, ok = store.Get(job.ID)
+	assert.False(t, ok)
+}
@@ -0,0 +1,131 @@
+package batch
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// Estados de um job
+const (
+	JobPending   = "pending"
+	JobRunning   = "running"
+	JobCompleted = "completed"
+)
+
+// Job é um lote executado de forma assíncrona
+type Job struct {
+	ID          string     `json:"id"`
+	Status      string     `json:"status"`
+	Total       int        `json:"total"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Results     []Result   `json:"results,omitempty"`
+}
+
+// JobStore guarda os jobs em memória. Jobs concluídos são removidos após TTL.
+type JobStore struct {
+	TTL time.Duration
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// NewJobStore cria um store que mantém jobs concluídos por 1 hora
+func NewJobStore() *JobStore {
+	return &JobStore{
+		TTL:  time.Hour,
+		jobs: make(map[string]*Job),
+	}
+}
+
+// Create registra um novo job pendente com o total de itens
+func (s *JobStore) Create(total int) Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evictExpired()
+
+	job := &Job{
+		ID:        newJobID(),
+		Status:    JobPending,
+		Total:     total,
+		CreatedAt: time.Now().UTC(),
+	}
+	s.jobs[job.ID] = job
+	return *job
+}
+
+// Start marca o job como em execução
+func (s *JobStore) Start(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if job, ok := s.jobs[id]; ok {
+		job.Status = JobRunning
+	}
+}
+
+// Complete guarda os resultados e marca o job como concluído
+func (s *JobStore) Complete(id string, results []Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if job, ok := s.jobs[id]; ok {
+		now := time.Now().UTC()
+		job.Status = JobCompleted
+		job.CompletedAt = &now
+		job.Results = results
+	}
+}
+
+// Get retorna uma cópia do job
+func (s *JobStore) Get(id string) (Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evictExpired()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// SetTTL troca por quanto tempo os jobs concluídos são mantidos
+func (s *JobStore) SetTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.TTL = ttl
+}
+
+// Counts retorna a quantidade de jobs guardados por estado
+func (s *JobStore) Counts() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evictExpired()
+
+	counts := map[string]int{JobPending: 0, JobRunning: 0, JobCompleted: 0}
+	for _, job := range s.jobs {
+		counts[job.Status]++
+	}
+	return counts
+}
+
+// evictExpired remove os jobs concluídos há mais de TTL (chamado com o lock adquirido)
+func (s *JobStore) evictExpired() {
+	for id, job := range s.jobs {
+		if job.CompletedAt != nil && time.Since(*job.CompletedAt) > s.TTL {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+func newJobID() string {
+	buf := make([]byte, 12)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,358 @@
+// Package catalog guarda um catálogo curado de recursos educacionais (livros, cursos, vídeos,
+// artigos e projetos) usado para fundamentar os roadmaps e trilhas: os recursos do catálogo que
+// combinam com o tema entram no prompt e substituem ou completam os sugeridos pelo modelo.
+// O catálogo é lido de um arquivo JSON ou CSV (ver Load); não há outro armazenamento.
+package catalog
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Tipos de recurso, na ordem das listas do roadmap educacional
+const (
+	TypeBook    = "book"
+	TypeCourse  = "course"
+	TypeVideo   = "video"
+	TypeArticle = "article"
+	TypeProject = "project"
+)
+
+// Types lista os tipos aceitos no catálogo
+var Types = []string{TypeBook, TypeCourse, TypeVideo, TypeArticle, TypeProject}
+
+// Modos de uso do catálogo na resposta do modelo
+const (
+	// ModePrefer completa os recursos sugeridos que estão no catálogo com os dados curados e
+	// os coloca no início de cada lista
+	ModePrefer = "prefer"
+	// ModeReplace também troca os recursos fora do catálogo por entradas do catálogo que
+	// combinam com o tema e ainda não aparecem na resposta
+	ModeReplace = "replace"
+)
+
+// Entry é um recurso curado
+type Entry struct {
+	Type        string   `json:"type"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Author      string   `json:"author,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Duration    string   `json:"duration,omitempty"`
+	Chapters    []string `json:"chapters,omitempty"`
+	// Tags são os temas do recurso, usados na busca (ex: "go", "concorrência")
+	Tags []string `json:"tags"`
+}
+
+// Catalog guarda as entradas em memória. É imutável depois de criado; recarregar a
+// configuração cria um novo catálogo.
+type Catalog struct {
+	entries []Entry
+	byTitle map[string]int
+}
+
+// New cria um catálogo com as entradas informadas. Retorna erro com todas as entradas
+// inválidas ou repetidas (mesmo tipo e título).
+func New(entries ...Entry) (*Catalog, error) {
+	c := &Catalog{byTitle: make(map[string]int, len(entries))}
+
+	var errs []error
+	for i, entry := range entries {
+		entry.Type = strings.ToLower(strings.TrimSpace(entry.Type))
+		entry.Title = strings.TrimSpace(entry.Title)
+		if problems := validate(entry); len(problems) > 0 {
+			errs = append(errs, fmt.Errorf("entrada %d (%q): %s", i+1, entry.Title, strings.Join(problems, "; ")))
+			continue
+		}
+
+		key := entry.Type + "\x00" + TitleKey(entry.Title)
+		if _, ok := c.byTitle[key]; ok {
+			errs = append(errs, fmt.Errorf("entrada %d (%q): repetida", i+1, entry.Title))
+			continue
+		}
+		c.byTitle[key] = len(c.entries)
+		c.entries = append(c.entries, entry)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return c, nil
+}
+
+func validate(entry Entry) []string {
+	var problems []string
+	if !validType(entry.Type) {
+		problems = append(problems, fmt.Sprintf("tipo %q inválido (use %s)", entry.Type, strings.Join(Types, ", ")))
+	}
+	if entry.Title == "" {
+		problems = append(problems, "título não pode ser vazio")
+	}
+	if entry.URL != "" {
+		if u, err := url.Parse(entry.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("URL %q inválida", entry.URL))
+		}
+	}
+	if len(entry.Tags) == 0 {
+		problems = append(problems, "informe ao menos uma tag")
+	}
+	return problems
+}
+
+func validType(kind string) bool {
+	for _, t := range Types {
+		if kind == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Load importa o catálogo de um arquivo JSON (lista de entradas) ou CSV (com cabeçalho), pela
+// extensão. Caminho vazio retorna um catálogo vazio.
+func Load(path string) (*Catalog, error) {
+	if path == "" {
+		return New()
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler catálogo: %w", err)
+	}
+
+	var entries []Entry
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		entries, err = parseJSON(data)
+	case ".csv":
+		entries, err = parseCSV(data)
+	default:
+		return nil, fmt.Errorf("formato de catálogo não suportado: %q (use .json ou .csv)", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("catálogo %s inválido: %w", path, err)
+	}
+
+	catalog, err := New(entries...)
+	if err != nil {
+		return nil, fmt.Errorf("catálogo %s inválido: %w", path, err)
+	}
+	return catalog, nil
+}
+
+func parseJSON(data []byte) ([]Entry, error) {
+	var entries []Entry
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// csvColumns são as colunas aceitas no CSV; tags e chapters são separados por ";"
+var csvColumns = []string{"type", "title", "description", "author", "url", "duration", "chapters", "tags"}
+
+func parseCSV(data []byte) ([]Entry, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("cabeçalho: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !contains(csvColumns, name) {
+			return nil, fmt.Errorf("coluna %q desconhecida (use %s)", name, strings.Join(csvColumns, ", "))
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"type", "title", "tags"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("coluna %q obrigatória", required)
+		}
+	}
+
+	var entries []Entry
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		entries = append(entries, Entry{
+			Type:        field("type"),
+			Title:       field("title"),
+			Description: field("description"),
+			Author:      field("author"),
+			URL:         field("url"),
+			Duration:    field("duration"),
+			Chapters:    splitList(field("chapters")),
+			Tags:        splitList(field("tags")),
+		})
+	}
+	return entries, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ";") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Len retorna o número de entradas
+func (c *Catalog) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.entries)
+}
+
+// Search retorna as entradas que combinam com o tema, no máximo perType de cada tipo, das
+// mais relevantes para as menos. Uma tag igual ao tema vale mais que palavras em comum com as
+// tags ou com o título; maiúsculas e acentos são ignorados.
+func (c *Catalog) Search(topic string, perType int) []Entry {
+	if c.Len() == 0 {
+		return nil
+	}
+
+	normalizedTopic := normalize(topic)
+	words := significantWords(normalizedTopic)
+	if len(words) == 0 {
+		return nil
+	}
+
+	type scored struct {
+		index int
+		score int
+	}
+	var matches []scored
+	for i, entry := range c.entries {
+		score := 0
+		for _, tag := range entry.Tags {
+			tag = normalize(tag)
+			if tag == normalizedTopic {
+				score += 10
+			}
+			score += 2 * commonWords(words, tag)
+		}
+		score += commonWords(words, normalize(entry.Title))
+		if score > 0 {
+			matches = append(matches, scored{index: i, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	perTypeCount := make(map[string]int)
+	var results []Entry
+	for _, match := range matches {
+		entry := c.entries[match.index]
+		if perTypeCount[entry.Type] >= perType {
+			continue
+		}
+		perTypeCount[entry.Type]++
+		results = append(results, entry)
+	}
+	return results
+}
+
+// Lookup procura a entrada do tipo com o mesmo título, ignorando maiúsculas, acentos,
+// pontuação e subtítulo (o que vem depois de ":")
+func (c *Catalog) Lookup(kind, title string) (Entry, bool) {
+	if c.Len() == 0 {
+		return Entry{}, false
+	}
+	i, ok := c.byTitle[kind+"\x00"+TitleKey(title)]
+	if !ok {
+		return Entry{}, false
+	}
+	return c.entries[i], true
+}
+
+// TitleKey normaliza um título para comparação: minúsculas, sem acentos, pontuação nem
+// subtítulo (o que vem depois de ":")
+func TitleKey(title string) string {
+	if i := strings.Index(title, ":"); i > 0 {
+		title = title[:i]
+	}
+	return strings.Join(strings.Fields(normalize(title)), " ")
+}
+
+// stripMarks remove os acentos depois da decomposição Unicode
+var stripMarks = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// normalize deixa só letras minúsculas sem acento, números e espaços
+func normalize(text string) string {
+	text, _, err := transform.String(stripMarks, strings.ToLower(text))
+	if err != nil {
+		text = strings.ToLower(text)
+	}
+	return strings.Join(strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	}), " ")
+}
+
+// significantWords descarta palavras curtas demais para a busca (artigos, preposições), exceto
+// nomes curtos de tecnologias quando são o tema inteiro (ex: "go", "c")
+func significantWords(normalizedTopic string) []string {
+	fields := strings.Fields(normalizedTopic)
+	if len(fields) == 1 {
+		return fields
+	}
+	var words []string
+	for _, word := range fields {
+		if len([]rune(word)) >= 3 {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// commonWords conta as palavras do tema presentes no texto normalizado
+func commonWords(words []string, normalizedText string) int {
+	count := 0
+	for _, field := range strings.Fields(normalizedText) {
+		for _, word := range words {
+			if field == word {
+				count++
+			}
+		}
+	}
+	return count
+}
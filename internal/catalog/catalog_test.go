@@ -0,0 +1,149 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func newTestCatalog(t *testing.T) *Catalog {
+	catalog, err := New(
+		Entry{Type: TypeBook, Title: "The Go Programming Language", URL: "https://www.gopl.io/", Tags: []string{"go", "golang"}},
+		Entry{Type: TypeBook, Title: "Concurrency in Go", Tags: []string{"go", "concorrência"}},
+		Entry{Type: TypeBook, Title: "Designing Data-Intensive Applications", Tags: []string{"sistemas distribuídos", "bancos de dados"}},
+		Entry{Type: TypeCourse, Title: "A Tour of Go", URL: "https://go.dev/tour/", Tags: []string{"golang"}},
+		Entry{Type: TypeVideo, Title: "Go Concurrency Patterns", Tags: []string{"concorrência"}},
+	)
+	require.NoError(t, err)
+	return catalog
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		catalog, err := Load(writeFile(t, "catalogo.json", `[
+			{"type": "book", "title": "Clean Code", "author": "Robert C. Martin", "tags": ["boas práticas"]},
+			{"type": "Video", "title": " Go Concurrency Patterns ", "tags": ["go"]}
+		]`))
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, catalog.Len())
+		entry, ok := catalog.Lookup(TypeVideo, "go concurrency patterns")
+		require.True(t, ok)
+		assert.Equal(t, "Go Concurrency Patterns", entry.Title)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		catalog, err := Load(writeFile(t, "catalogo.csv", "type,title,author,url,chapters,tags\n"+
+			"book,Clean Code,Robert C. Martin,https://example.com/clean-code,Nomes;Funções,boas práticas;refatoração\n"+
+			"course,A Tour of Go,,https://go.dev/tour/,,go\n"))
+
+		require.NoError(t, err)
+		entry, ok := catalog.Lookup(TypeBook, "Clean Code")
+		require.True(t, ok)
+		assert.Equal(t, "Robert C. Martin", entry.Author)
+		assert.Equal(t, []string{"Nomes", "Funções"}, entry.Chapters)
+		assert.Equal(t, []string{"boas práticas", "refatoração"}, entry.Tags)
+	})
+
+	t.Run("vazio", func(t *testing.T) {
+		catalog, err := Load("")
+
+		require.NoError(t, err)
+		assert.Zero(t, catalog.Len())
+	})
+
+	errorTests := []struct {
+		name     string
+		file     string
+		content  string
+		contains []string
+	}{
+		{"extensão", "catalogo.yaml", "[]", []string{"não suportado"}},
+		{"campo desconhecido", "catalogo.json", `[{"type": "book", "title": "X", "tags": ["a"], "isbn": "1"}]`, []string{"isbn"}},
+		{"coluna desconhecida", "catalogo.csv", "type,title,isbn,tags\nbook,X,1,a\n", []string{"isbn"}},
+		{"coluna obrigatória", "catalogo.csv", "type,title\nbook,X\n", []string{"tags"}},
+		{
+			"entradas inválidas",
+			"catalogo.json",
+			`[{"type": "podcast", "title": "X", "tags": ["a"]}, {"type": "book", "title": "", "url": "ftp://x", "tags": []},
+			  {"type": "book", "title": "Clean Code", "tags": ["a"]}, {"type": "book", "title": "clean code", "tags": ["b"]}]`,
+			[]string{"entrada 1", "podcast", "entrada 2", "título", "URL", "tag", "entrada 4", "repetida"},
+		},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeFile(t, tt.file, tt.content))
+
+			require.Error(t, err)
+			for _, text := range tt.contains {
+				assert.Contains(t, err.Error(), text)
+			}
+		})
+	}
+}
+
+func TestCatalog_Search(t *testing.T) {
+	catalog := newTestCatalog(t)
+
+	titles := func(entries []Entry) []string {
+		var titles []string
+		for _, entry := range entries {
+			titles = append(titles, entry.Title)
+		}
+		return titles
+	}
+
+	t.Run("tag igual ao tema antes de palavras no título", func(t *testing.T) {
+		entries := catalog.Search("Go", 5)
+
+		assert.Equal(t, []string{"The Go Programming Language", "Concurrency in Go", "A Tour of Go", "Go Concurrency Patterns"}, titles(entries))
+	})
+
+	t.Run("palavras em comum, sem acentos", func(t *testing.T) {
+		entries := catalog.Search("Concorrencia em Go", 5)
+
+		assert.Equal(t, []string{"Concurrency in Go", "Go Concurrency Patterns"}, titles(entries))
+	})
+
+	t.Run("limite por tipo", func(t *testing.T) {
+		entries := catalog.Search("golang", 1)
+
+		assert.Equal(t, []string{"The Go Programming Language", "A Tour of Go"}, titles(entries))
+	})
+
+	t.Run("sem resultados", func(t *testing.T) {
+		assert.Empty(t, catalog.Search("culinária", 5))
+		assert.Empty(t, (*Catalog)(nil).Search("Go", 5))
+	})
+}
+
+func TestCatalog_Lookup(t *testing.T) {
+	catalog := newTestCatalog(t)
+
+	tests := []struct {
+		kind  string
+		title string
+		found bool
+	}{
+		{TypeBook, "The Go Programming Language", true},
+		{TypeBook, "the go programming language: 1st edition", true},
+		{TypeBook, "The Go Programming Language!", true},
+		{TypeCourse, "The Go Programming Language", false},
+		{TypeBook, "Go in Action", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			_, found := catalog.Lookup(tt.kind, tt.title)
+			assert.Equal(t, tt.found, found)
+		})
+	}
+}
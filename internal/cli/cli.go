@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spellbook/spellbook/internal/i18n"
+	"github.com/spellbook/spellbook/internal/services"
+	"github.com/spellbook/spellbook/pkg/client"
+)
+
+const usage = `Uso: spellbook <comando> <tema> [flags]
+
+Comandos:
+  roadmap      Gera um roadmap de estudo (--days, --exact-items)
+  topics       Gera uma lista de tópicos (--count)
+  key-results  Gera Key Results para um objetivo OKR (--count, --completion-date)
+  edu-roadmap  Gera um roadmap educacional com livros, cursos, vídeos e projetos
+  trail        Gera uma trilha educacional dia a dia (--days)
+
+Flags comuns:
+  --format     json, markdown ou tree (padrão tree)
+  --language   idioma da resposta em BCP 47 (padrão pt-BR)
+  --server     URL de um servidor Spellbook; sem ela, chama o Gemini diretamente
+`
+
+// ServiceFactory cria o serviço usado quando --server não é informado
+type ServiceFactory func() (services.GeminiServiceInterface, error)
+
+// options são as flags de linha de comando, espelhando os modelos de requisição
+type options struct {
+	days           int
+	exactItems     int
+	count          int
+	completionDate string
+	language       string
+	format         string
+	server         string
+}
+
+// Run executa a CLI com os argumentos (sem o nome do programa) e retorna o código de saída
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer, newService ServiceFactory) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprint(stderr, usage)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	command := args[0]
+	if !isCommand(command) {
+		fmt.Fprintf(stderr, "comando desconhecido: %s\n\n%s", command, usage)
+		return 2
+	}
+
+	opts, topic, err := parseFlags(command, args[1:], stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintf(stderr, "%v\n", err)
+		return 2
+	}
+
+	renderer, ok := renderers[opts.format]
+	if !ok {
+		fmt.Fprintf(stderr, "formato inválido: %s (use %s)\n", opts.format, strings.Join(formats(), ", "))
+		return 2
+	}
+
+	var service services.GeminiServiceInterface
+	if opts.server != "" {
+		service = &remoteService{client: client.NewClient(opts.server)}
+	} else {
+		service, err = newService()
+		if err != nil {
+			fmt.Fprintf(stderr, "%v\n", err)
+			return 1
+		}
+	}
+
+	result, err := generate(ctx, service, command, topic, opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "erro: %v\n", err)
+		return 1
+	}
+
+	if err := renderer(stdout, result); err != nil {
+		fmt.Fprintf(stderr, "erro ao exibir resultado: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// parseFlags aceita flags antes ou depois do tema (ex: roadmap "Go" --days 10)
+func parseFlags(command string, args []string, stderr io.Writer) (*options, string, error) {
+	opts := &options{}
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.format, "format", "tree", "formato da saída: json, markdown ou tree")
+	fs.StringVar(&opts.language, "language", i18n.DefaultLanguage, "idioma da resposta (BCP 47)")
+	fs.StringVar(&opts.server, "server", "", "URL de um servidor Spellbook (ex: http://localhost:8080)")
+
+	switch command {
+	case "roadmap":
+		fs.IntVar(&opts.days, "days", 0, "dias disponíveis para o estudo")
+		fs.IntVar(&opts.exactItems, "exact-items", 0, "número exato de itens do roadmap")
+	case "topics":
+		fs.IntVar(&opts.count, "count", 10, "quantidade de tópicos")
+	case "key-results":
+		fs.IntVar(&opts.count, "count", 5, "quantidade de Key Results")
+		fs.StringVar(&opts.completionDate, "completion-date", "", "data de conclusão (AAAA-MM-DD)")
+	case "trail":
+		fs.IntVar(&opts.days, "days", 0, "dias disponíveis para a trilha")
+	}
+
+	positional := make([]string, 0, 1)
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, "", err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(positional) == 0 {
+		return nil, "", fmt.Errorf("informe o tema: spellbook %s <tema>", command)
+	}
+	return opts, strings.Join(positional, " "), nil
+}
+
+// generate chama a operação do comando no serviço
+func generate(ctx context.Context, service services.GeminiServiceInterface, command, topic string, opts *options) (interface{}, error) {
+	switch command {
+	case "roadmap":
+		return service.GenerateRoadmap(ctx, topic, positive(opts.days), positive(opts.exactItems), opts.language)
+	case "topics":
+		return service.GenerateTopics(ctx, topic, opts.count, opts.language)
+	case "key-results":
+		var completionDate *string
+		if opts.completionDate != "" {
+			completionDate = &opts.completionDate
+		}
+		return service.GenerateKeyResults(ctx, topic, opts.count, completionDate, opts.language)
+	case "edu-roadmap":
+		return service.GenerateEducationalRoadmap(ctx, topic, opts.language)
+	default:
+		return service.GenerateEducationalTrail(ctx, topic, positive(opts.days), opts.language)
+	}
+}
+
+func isCommand(command string) bool {
+	switch command {
+	case "roadmap", "topics", "key-results", "edu-roadmap", "trail":
+		return true
+	}
+	return false
+}
+
+// positive converte flags numéricas opcionais (0 = não informado) em ponteiro
+func positive(value int) *int {
+	if value <= 0 {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/spellbook/spellbook/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockService struct {
+	mock.Mock
+}
+
+func (m *mockService) GenerateRoadmap(ctx context.Context, topic string, availableDays *int, exactItemCount *int, language string) (*models.Roadmap, error) {
+	args := m.Called(ctx, topic, availableDays, exactItemCount, language)
+	return args.Get(0).(*models.Roadmap), args.Error(1)
+}
+
+func (m *mockService) GenerateTopics(ctx context.Context, subject string, count int, language string) (*models.TopicsResponse, error) {
+	args := m.Called(ctx, subject, count, language)
+	return args.Get(0).(*models.TopicsResponse), args.Error(1)
+}
+
+func (m *mockService) GenerateKeyResults(ctx context.Context, objective string, count int, completionDate *string, language string) (*models.KeyResultsResponse, error) {
+	args := m.Called(ctx, objective, count, completionDate, language)
+	return args.Get(0).(*models.KeyResultsResponse), args.Error(1)
+}
+
+func (m *mockService) GenerateEducationalRoadmap(ctx context.Context, topic string, language string) (*models.EducationalRoadmap, error) {
+	args := m.Called(ctx, topic, language)
+	return args.Get(0).(*models.EducationalRoadmap), args.Error(1)
+}
+
+func (m *mockService) GenerateEducationalTrail(ctx context.Context, topic string, availableDays *int, language string) (*models.EducationalTrail, error) {
+	args := m.Called(ctx, topic, availableDays, language)
+	return args.Get(0).(*models.EducationalTrail), args.Error(1)
+}
+
+func factory(service services.GeminiServiceInterface) ServiceFactory {
+	return func() (services.GeminiServiceInterface, error) { return service, nil }
+}
+
+func TestRun_RoadmapTree(t *testing.T) {
+	service := new(mockService)
+	days := 10
+	service.On("GenerateRoadmap", mock.Anything, "Machine Learning", &days, (*int)(nil), "en").Return(&models.Roadmap{
+		Topic: "Machine Learning",
+		Roadmap: []models.RoadmapCategory{
+			{Category: "Fundamentos", Items: []models.RoadmapItem{{ID: "1", Title: "Álgebra"}, {ID: "2", Title: "Estatística"}}},
+			{Category: "Modelos", Items: []models.RoadmapItem{{ID: "3", Title: "Regressão", Completed: true}}},
+		},
+	}, nil)
+
+	var stdout, stderr bytes.Buffer
+	// Flags antes e depois do tema
+	code := Run(context.Background(), []string{"roadmap", "--language", "en", "Machine", "Learning", "--days", "10"}, &stdout, &stderr, factory(service))
+
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Equal(t, `Machine Learning
+├── Fundamentos
+│   ├── [ ] Álgebra
+│   └── [ ] Estatística
+└── Modelos
+    └── [x] Regressão
+`, stdout.String())
+	service.AssertExpectations(t)
+}
+
+func TestRun_TopicsMarkdown(t *testing.T) {
+	service := new(mockService)
+	service.On("GenerateTopics", mock.Anything, "Go", 2, "pt-BR").Return(&models.TopicsResponse{
+		Subject: "Go",
+		Topics:  []string{"Goroutines", "Channels | select"},
+	}, nil)
+
+	var stdout, stderr bytes.Buffer
+	code := Run(context.Background(), []string{"topics", "Go", "--count", "2", "--format", "markdown"}, &stdout, &stderr, factory(service))
+
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Equal(t, "# Go\n\n| # | Tópico |\n|---|---|\n| 1 | Goroutines |\n| 2 | Channels \\| select |\n", stdout.String())
+}
+
+func TestRun_KeyResultsJSON(t *testing.T) {
+	service := new(mockService)
+	date := "2026-12-31"
+	service.On("GenerateKeyResults", mock.Anything, "Crescer", 5, &date, "pt-BR").Return(&models.KeyResultsResponse{
+		Objective:  "Crescer",
+		KeyResults: []string{"Dobrar a receita"},
+	}, nil)
+
+	var stdout, stderr bytes.Buffer
+	code := Run(context.Background(), []string{"key-results", "--completion-date", "2026-12-31", "--format", "json", "Crescer"}, &stdout, &stderr, factory(service))
+
+	assert.Equal(t, 0, code, stderr.String())
+	assert.JSONEq(t, `{"objective": "Crescer", "key_results": ["Dobrar a receita"]}`, stdout.String())
+}
+
+func TestRun_Server(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/topics", r.URL.Path)
+		w.Write([]byte(`{"subject": "Rust", "topics": ["Ownership"]}`))
+	}))
+	defer server.Close()
+
+	noLocal := func() (services.GeminiServiceInterface, error) {
+		t.Fatal("não deveria criar o serviço local")
+		return nil, nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := Run(context.Background(), []string{"topics", "Rust", "--server", server.URL}, &stdout, &stderr, noLocal)
+
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Equal(t, "Rust\n└── Ownership\n", stdout.String())
+}
+
+func TestRun_InvalidUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "sem argumentos", args: nil},
+		{name: "comando desconhecido", args: []string{"poema", "Go"}},
+		{name: "sem tema", args: []string{"topics", "--count", "3"}},
+		{name: "formato inválido", args: []string{"topics", "Go", "--format", "xml"}},
+		{name: "flag de outro comando", args: []string{"topics", "Go", "--days", "3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := Run(context.Background(), tt.args, &stdout, &stderr, factory(new(mockService)))
+
+			assert.Equal(t, 2, code)
+			assert.Empty(t, stdout.String())
+			assert.NotEmpty(t, stderr.String())
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/spellbook/spellbook/internal/services"
+	"github.com/spellbook/spellbook/pkg/client"
+)
+
+var _ services.GeminiServiceInterface = (*remoteService)(nil)
+
+// remoteService implementa a interface do serviço chamando um servidor em execução
+type remoteService struct {
+	client *client.Client
+}
+
+func (r *remoteService) GenerateRoadmap(ctx context.Context, topic string, availableDays *int, exactItemCount *int, language string) (*models.Roadmap, error) {
+	return r.client.GenerateRoadmap(ctx, client.RoadmapRequest{
+		Topic:          topic,
+		AvailableDays:  availableDays,
+		ExactItemCount: exactItemCount,
+		Language:       language,
+	})
+}
+
+func (r *remoteService) GenerateTopics(ctx context.Context, subject string, count int, language string) (*models.TopicsResponse, error) {
+	return r.client.GenerateTopics(ctx, client.TopicsRequest{Subject: subject, Count: count, Language: language})
+}
+
+func (r *remoteService) GenerateKeyResults(ctx context.Context, objective string, count int, completionDate *string, language string) (*models.KeyResultsResponse, error) {
+	return r.client.GenerateKeyResults(ctx, client.KeyResultsRequest{
+		Objective:      objective,
+		Count:          count,
+		CompletionDate: completionDate,
+		Language:       language,
+	})
+}
+
+func (r *remoteService) GenerateEducationalRoadmap(ctx context.Context, topic string, language string) (*models.EducationalRoadmap, error) {
+	return r.client.GenerateEducationalRoadmap(ctx, client.EducationalRoadmapRequest{Topic: topic, Language: language})
+}
+
+func (r *remoteService) GenerateEducationalTrail(ctx context.Context, topic string, availableDays *int, language string) (*models.EducationalTrail, error) {
+	return r.client.GenerateEducationalTrail(ctx, client.EducationalTrailRequest{
+		Topic:         topic,
+		AvailableDays: availableDays,
+		Language:      language,
+	})
+}
@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spellbook/spellbook/internal/models"
+)
+
+// renderers formata o resultado de uma geração para a saída escolhida em --format
+var renderers = map[string]func(io.Writer, interface{}) error{
+	"json":     renderJSON,
+	"markdown": renderMarkdown,
+	"tree":     renderTree,
+}
+
+func renderJSON(w io.Writer, result interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// table é a representação tabular de um resultado, usada pelo formato markdown
+type table struct {
+	title   string
+	headers []string
+	rows    [][]string
+}
+
+func renderMarkdown(w io.Writer, result interface{}) error {
+	t := toTable(result)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", t.title)
+	fmt.Fprintf(&b, "| %s |\n", strings.Join(t.headers, " | "))
+	fmt.Fprintf(&b, "|%s\n", strings.Repeat("---|", len(t.headers)))
+	for _, row := range t.rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = escapeCell(cell)
+		}
+		fmt.Fprintf(&b, "| %s |\n", strings.Join(cells, " | "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func toTable(result interface{}) table {
+	switch r := result.(type) {
+	case *models.Roadmap:
+		t := table{title: r.Topic, headers: []string{"Categoria", "#", "Item"}}
+		for _, category := range r.Roadmap {
+			for _, item := range category.Items {
+				t.rows = append(t.rows, []string{category.Category, item.ID, item.Title})
+			}
+		}
+		return t
+
+	case *models.TopicsResponse:
+		t := table{title: r.Subject, headers: []string{"#", "Tópico"}}
+		for i, topic := range r.Topics {
+			t.rows = append(t.rows, []string{strconv.Itoa(i + 1), topic})
+		}
+		return t
+
+	case *models.KeyResultsResponse:
+		t := table{title: r.Objective, headers: []string{"#", "Key Result"}}
+		for i, keyResult := range r.KeyResults {
+			t.rows = append(t.rows, []string{strconv.Itoa(i + 1), keyResult})
+		}
+		return t
+
+	case *models.EducationalRoadmap:
+		t := table{title: r.Topic, headers: []string{"Tipo", "Título", "Detalhes", "URL"}}
+		for _, group := range resourceGroups(r) {
+			for _, resource := range group.resources {
+				t.rows = append(t.rows, []string{group.name, resource.Title, resourceDetails(resource), resource.URL})
+			}
+		}
+		return t
+
+	case *models.EducationalTrail:
+		t := table{title: fmt.Sprintf("%s (%d dias)", r.Topic, r.TotalDays), headers: []string{"Dia", "Atividade", "Tipo", "Recurso"}}
+		for _, step := range r.Steps {
+			for _, activity := range step.Activities {
+				t.rows = append(t.rows, []string{strconv.Itoa(step.Day), activity.Title, activity.Type, resourceTitle(r, activity.ResourceID)})
+			}
+		}
+		return t
+	}
+
+	return table{title: "resultado", headers: []string{"valor"}, rows: [][]string{{fmt.Sprint(result)}}}
+}
+
+// node é um nó da árvore exibida no terminal pelo formato tree
+type node struct {
+	label    string
+	children []*node
+}
+
+func (n *node) add(label string) *node {
+	child := &node{label: label}
+	n.children = append(n.children, child)
+	return child
+}
+
+func renderTree(w io.Writer, result interface{}) error {
+	root := toTree(result)
+
+	var b strings.Builder
+	b.WriteString(root.label + "\n")
+	writeChildren(&b, root.children, "")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeChildren(b *strings.Builder, children []*node, prefix string) {
+	for i, child := range children {
+		branch, indent := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		b.WriteString(prefix + branch + child.label + "\n")
+		writeChildren(b, child.children, prefix+indent)
+	}
+}
+
+func toTree(result interface{}) *node {
+	switch r := result.(type) {
+	case *models.Roadmap:
+		root := &node{label: r.Topic}
+		for _, category := range r.Roadmap {
+			categoryNode := root.add(category.Category)
+			for _, item := range category.Items {
+				check := "[ ]"
+				if item.Completed {
+					check = "[x]"
+				}
+				categoryNode.add(check + " " + item.Title)
+			}
+		}
+		return root
+
+	case *models.TopicsResponse:
+		root := &node{label: r.Subject}
+		for _, topic := range r.Topics {
+			root.add(topic)
+		}
+		return root
+
+	case *models.KeyResultsResponse:
+		root := &node{label: r.Objective}
+		for _, keyResult := range r.KeyResults {
+			root.add(keyResult)
+		}
+		return root
+
+	case *models.EducationalRoadmap:
+		root := &node{label: r.Topic}
+		for _, group := range resourceGroups(r) {
+			if len(group.resources) == 0 {
+				continue
+			}
+			groupNode := root.add(group.name)
+			for _, resource := range group.resources {
+				label := resource.Title
+				if details := resourceDetails(resource); details != "" {
+					label += " (" + details + ")"
+				}
+				groupNode.add(label)
+			}
+		}
+		return root
+
+	case *models.EducationalTrail:
+		root := &node{label: fmt.Sprintf("%s (%d dias)", r.Topic, r.TotalDays)}
+		for _, step := range r.Steps {
+			stepNode := root.add(step.Title)
+			for _, activity := range step.Activities {
+				stepNode.add(fmt.Sprintf("[%s] %s", activity.Type, activity.Title))
+			}
+		}
+		return root
+	}
+
+	return &node{label: fmt.Sprint(result)}
+}
+
+type resourceGroup struct {
+	name      string
+	resources []models.EducationalResource
+}
+
+func resourceGroups(r *models.EducationalRoadmap) []resourceGroup {
+	return []resourceGroup{
+		{name: "Livros", resources: r.Books},
+		{name: "Cursos", resources: r.Courses},
+		{name: "Vídeos", resources: r.Videos},
+		{name: "Artigos", resources: r.Articles},
+		{name: "Projetos", resources: r.Projects},
+	}
+}
+
+// resourceDetails resume autor e duração do recurso
+func resourceDetails(resource models.EducationalResource) string {
+	details := make([]string, 0, 2)
+	if resource.Author != "" {
+		details = append(details, resource.Author)
+	}
+	if resource.Duration != "" {
+		details = append(details, resource.Duration)
+	}
+	return strings.Join(details, ", ")
+}
+
+// resourceTitle retorna o título do recurso referenciado pela atividade, ou o próprio ID
+func resourceTitle(trail *models.EducationalTrail, resourceID string) string {
+	if resource, ok := trail.Resources[resourceID]; ok && resource.Title != "" {
+		return resource.Title
+	}
+	return resourceID
+}
+
+func escapeCell(cell string) string {
+	cell = strings.ReplaceAll(cell, "|", `\|`)
+	return strings.ReplaceAll(cell, "\n", " ")
+}
+
+// formats retorna os formatos suportados, em ordem alfabética
+func formats() []string {
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
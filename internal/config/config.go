@@ -3,49 +3,360 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/spellbook/spellbook/internal/catalog"
+	"github.com/spellbook/spellbook/internal/linkcheck"
+	"github.com/spellbook/spellbook/internal/prompts"
+	"github.com/spellbook/spellbook/internal/services"
 )
 
-// Config armazena as configurações da aplicação
+// Config armazena as configurações da aplicação. Os valores vêm, em ordem de precedência,
+// das variáveis de ambiente, do arquivo de configuração (CONFIG_FILE) e dos padrões.
 type Config struct {
+	// File é o arquivo de configuração usado (vazio quando só o ambiente é usado)
+	File string
+
 	GeminiAPIKey string
-	Port         string
+	// GeminiAPIKeys são chaves adicionais, usadas em rodízio junto com GeminiAPIKey
+	GeminiAPIKeys []string
+	Port          string
+	// GRPCPort é a porta da API gRPC, servida ao lado da API REST
+	GRPCPort string
+	LogLevel string
+	// OTLPEndpoint é a URL base do coletor OpenTelemetry (vazio desativa a exportação)
+	OTLPEndpoint string
+	// PromptsDir é um diretório opcional com templates que substituem ou complementam os embutidos
+	PromptsDir string
+	// SpellsDir é um diretório opcional com as gerações definidas em arquivos (spells)
+	SpellsDir string
+
+	// Timeouts do servidor HTTP. WriteTimeout precisa cobrir as gerações mais longas (até 3 minutos).
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// ShutdownTimeout é o prazo para concluir as requisições e jobs em andamento ao receber SIGTERM
+	ShutdownTimeout time.Duration
+
+	// CORSOrigins são as origens aceitas pelo CORS ("*" aceita qualquer origem)
+	CORSOrigins []string
+	// RateLimitRPS limita as requisições por segundo de cada cliente na API (0 desativa);
+	// RateLimitBurst é a rajada permitida acima desse ritmo
+	RateLimitRPS   float64
+	RateLimitBurst int
+
+	// PreferredModels são tentados antes dos modelos listados pela API do Gemini;
+	// FallbackModels, depois deles (vazio usa a lista padrão do serviço)
+	PreferredModels []string
+	FallbackModels  []string
+	// RequestTimeout limita cada chamada à API do Gemini
+	RequestTimeout time.Duration
+	// QuotaRetryDelay é a espera antes de tentar novamente um modelo que respondeu 429
+	QuotaRetryDelay time.Duration
+	// ModelsCacheTTL é a validade do cache da lista de modelos
+	ModelsCacheTTL time.Duration
+	// JobsTTL é por quanto tempo os jobs de lote concluídos ficam disponíveis
+	JobsTTL time.Duration
+
+	// CircuitFailureThreshold é o número de falhas seguidas que abre o circuito de um modelo
+	// (0 desativa); o modelo é pulado por CircuitOpenDuration e o circuito fecha depois de
+	// CircuitHalfOpenSuccesses tentativas de teste bem-sucedidas
+	CircuitFailureThreshold  int
+	CircuitOpenDuration      time.Duration
+	CircuitHalfOpenSuccesses int
+
+	// HedgeDelay é a espera pelo primeiro modelo antes de consultar o próximo em paralelo na
+	// geração de tópicos (0 desativa o hedging); HedgeMaxRatio limita essas consultas extras
+	// a uma fração das gerações
+	HedgeDelay    time.Duration
+	HedgeMaxRatio float64
+
+	// LinkCheckMode define o tratamento das URLs dos recursos educacionais: off, flag (informa
+	// o resultado em link_status) ou strip (também remove as quebradas). Cada URL é verificada
+	// em até LinkCheckTimeout, com LinkCheckConcurrency em paralelo, e o resultado fica em
+	// cache por LinkCheckCacheTTL.
+	LinkCheckMode        string
+	LinkCheckTimeout     time.Duration
+	LinkCheckConcurrency int
+	LinkCheckCacheTTL    time.Duration
+
+	// CatalogFile é o catálogo curado de recursos educacionais (JSON ou CSV; vazio desativa);
+	// CatalogMode define se os recursos do catálogo só completam (prefer) ou também substituem
+	// (replace) os sugeridos pelo modelo
+	CatalogFile string
+	CatalogMode string
+
+	// AdminToken protege os endpoints de administração (vazio os desativa)
+	AdminToken string
+}
+
+// Valores padrão
+const (
+	DefaultPort            = "8080"
+	DefaultGRPCPort        = "9090"
+	DefaultLogLevel        = "info"
+	DefaultReadTimeout     = 30 * time.Second
+	DefaultWriteTimeout    = 4 * time.Minute
+	DefaultIdleTimeout     = 2 * time.Minute
+	DefaultShutdownTimeout = 4 * time.Minute
+	DefaultRequestTimeout  = services.DefaultRequestTimeout
+	DefaultQuotaRetryDelay = 30 * time.Second
+	DefaultModelsCacheTTL  = 5 * time.Minute
+	DefaultJobsTTL         = time.Hour
+)
+
+// Defaults retorna a configuração padrão, sem arquivo nem ambiente
+func Defaults() *Config {
+	return &Config{
+		Port:            DefaultPort,
+		GRPCPort:        DefaultGRPCPort,
+		LogLevel:        DefaultLogLevel,
+		ReadTimeout:     DefaultReadTimeout,
+		WriteTimeout:    DefaultWriteTimeout,
+		IdleTimeout:     DefaultIdleTimeout,
+		ShutdownTimeout: DefaultShutdownTimeout,
+		CORSOrigins:     []string{"*"},
+		RequestTimeout:  DefaultRequestTimeout,
+		QuotaRetryDelay: DefaultQuotaRetryDelay,
+		ModelsCacheTTL:  DefaultModelsCacheTTL,
+		JobsTTL:         DefaultJobsTTL,
+
+		CircuitFailureThreshold:  services.DefaultBreakerSettings.FailureThreshold,
+		CircuitOpenDuration:      services.DefaultBreakerSettings.OpenDuration,
+		CircuitHalfOpenSuccesses: services.DefaultBreakerSettings.HalfOpenSuccesses,
+
+		HedgeDelay:    services.DefaultHedgeSettings.Delay,
+		HedgeMaxRatio: services.DefaultHedgeSettings.MaxRatio,
+
+		LinkCheckMode:        linkcheck.DefaultSettings.Mode,
+		LinkCheckTimeout:     linkcheck.DefaultSettings.Timeout,
+		LinkCheckConcurrency: linkcheck.DefaultSettings.Concurrency,
+		LinkCheckCacheTTL:    linkcheck.DefaultSettings.CacheTTL,
+
+		CatalogMode: catalog.ModePrefer,
+	}
 }
 
-// Load carrega as configurações do ambiente
+// Load carrega as configurações do arquivo indicado em CONFIG_FILE (opcional) e do ambiente.
+// Retorna erro com todos os problemas encontrados quando a configuração é inválida.
 func Load() (*Config, error) {
 	// Tentar carregar .env (não é erro se não existir)
 	_ = godotenv.Load()
 
-	apiKey := os.Getenv("GEMINI_API_KEY")
-	if apiKey == "" {
-		return nil, fmt.Errorf("GEMINI_API_KEY não configurada. Configure no arquivo .env ou variável de ambiente")
+	return LoadFile(os.Getenv("CONFIG_FILE"))
+}
+
+// LoadFile carrega as configurações do arquivo informado (vazio para usar só o ambiente),
+// aplica as variáveis de ambiente por cima e valida o resultado
+func LoadFile(path string) (*Config, error) {
+	cfg := Defaults()
+	var problems []string
+
+	if path != "" {
+		if err := applyFile(cfg, path); err != nil {
+			return nil, err
+		}
+		cfg.File = path
 	}
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	problems = append(problems, applyEnv(cfg)...)
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
 	}
 
-	return &Config{
-		GeminiAPIKey: apiKey,
-		Port:         port,
-	}, nil
+	return cfg, nil
 }
 
-// LoadForTesting carrega configurações para testes (permite API key vazia)
-func LoadForTesting() *Config {
-	_ = godotenv.Load()
+// applyEnv sobrepõe as variáveis de ambiente definidas, retornando os valores inválidos
+func applyEnv(cfg *Config) []string {
+	var problems []string
 
-	apiKey := os.Getenv("GEMINI_API_KEY")
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	texts := []struct {
+		env    string
+		target *string
+	}{
+		{"GEMINI_API_KEY", &cfg.GeminiAPIKey},
+		{"PORT", &cfg.Port},
+		{"GRPC_PORT", &cfg.GRPCPort},
+		{"LOG_LEVEL", &cfg.LogLevel},
+		{"OTEL_EXPORTER_OTLP_ENDPOINT", &cfg.OTLPEndpoint},
+		{"PROMPTS_DIR", &cfg.PromptsDir},
+		{"SPELLS_DIR", &cfg.SpellsDir},
+		{"ADMIN_TOKEN", &cfg.AdminToken},
+		{"LINK_CHECK_MODE", &cfg.LinkCheckMode},
+		{"CATALOG_FILE", &cfg.CatalogFile},
+		{"CATALOG_MODE", &cfg.CatalogMode},
+	}
+	for _, t := range texts {
+		if value := os.Getenv(t.env); value != "" {
+			*t.target = value
+		}
 	}
 
-	return &Config{
-		GeminiAPIKey: apiKey,
-		Port:         port,
+	lists := []struct {
+		env    string
+		target *[]string
+	}{
+		{"GEMINI_API_KEYS", &cfg.GeminiAPIKeys},
+		{"CORS_ALLOWED_ORIGINS", &cfg.CORSOrigins},
+		{"GEMINI_PREFERRED_MODELS", &cfg.PreferredModels},
+		{"GEMINI_FALLBACK_MODELS", &cfg.FallbackModels},
+	}
+	for _, l := range lists {
+		if value := os.Getenv(l.env); value != "" {
+			*l.target = splitList(value)
+		}
 	}
+
+	durations := []struct {
+		env    string
+		target *time.Duration
+	}{
+		{"HTTP_READ_TIMEOUT", &cfg.ReadTimeout},
+		{"HTTP_WRITE_TIMEOUT", &cfg.WriteTimeout},
+		{"HTTP_IDLE_TIMEOUT", &cfg.IdleTimeout},
+		{"SHUTDOWN_TIMEOUT", &cfg.ShutdownTimeout},
+		{"GEMINI_REQUEST_TIMEOUT", &cfg.RequestTimeout},
+		{"QUOTA_RETRY_DELAY", &cfg.QuotaRetryDelay},
+		{"MODELS_CACHE_TTL", &cfg.ModelsCacheTTL},
+		{"JOBS_TTL", &cfg.JobsTTL},
+		{"CIRCUIT_OPEN_DURATION", &cfg.CircuitOpenDuration},
+		{"GEMINI_HEDGE_DELAY", &cfg.HedgeDelay},
+		{"LINK_CHECK_TIMEOUT", &cfg.LinkCheckTimeout},
+		{"LINK_CHECK_CACHE_TTL", &cfg.LinkCheckCacheTTL},
+	}
+	for _, d := range durations {
+		value := os.Getenv(d.env)
+		if value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %q não é uma duração (use 30s, 4m, 1h)", d.env, value))
+			continue
+		}
+		*d.target = duration
+	}
+
+	floats := []struct {
+		env    string
+		target *float64
+	}{
+		{"RATE_LIMIT_RPS", &cfg.RateLimitRPS},
+		{"GEMINI_HEDGE_MAX_RATIO", &cfg.HedgeMaxRatio},
+	}
+	for _, f := range floats {
+		value := os.Getenv(f.env)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %q não é um número", f.env, value))
+			continue
+		}
+		*f.target = n
+	}
+
+	ints := []struct {
+		env    string
+		target *int
+	}{
+		{"RATE_LIMIT_BURST", &cfg.RateLimitBurst},
+		{"CIRCUIT_FAILURE_THRESHOLD", &cfg.CircuitFailureThreshold},
+		{"CIRCUIT_HALF_OPEN_SUCCESSES", &cfg.CircuitHalfOpenSuccesses},
+		{"LINK_CHECK_CONCURRENCY", &cfg.LinkCheckConcurrency},
+	}
+	for _, i := range ints {
+		value := os.Getenv(i.env)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %q não é um número inteiro", i.env, value))
+			continue
+		}
+		*i.target = n
+	}
+
+	return problems
+}
+
+// splitList separa uma lista por vírgulas, ignorando itens vazios
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// APIKeys retorna as API keys do Gemini configuradas (GeminiAPIKey primeiro), sem repetições
+func (c *Config) APIKeys() []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, key := range append([]string{c.GeminiAPIKey}, c.GeminiAPIKeys...) {
+		if key != "" && !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+	return keys
+}
+
+// GeminiSettings retorna as opções do serviço Gemini definidas pela configuração, com os
+// templates de prompt e o catálogo de recursos já carregados (ver LoadCatalog). Registry ou
+// catálogo nil mantêm os que o serviço já usa.
+func (c *Config) GeminiSettings(registry *prompts.Registry, resources *catalog.Catalog) services.Settings {
+	return services.Settings{
+		APIKeys:         c.APIKeys(),
+		PreferredModels: c.PreferredModels,
+		FallbackModels:  c.FallbackModels,
+		RequestTimeout:  c.RequestTimeout,
+		QuotaRetryDelay: c.QuotaRetryDelay,
+		ModelsCacheTTL:  c.ModelsCacheTTL,
+		Prompts:         registry,
+		Catalog:         resources,
+		CatalogMode:     strings.ToLower(c.CatalogMode),
+		Breaker: &services.BreakerSettings{
+			FailureThreshold:  c.CircuitFailureThreshold,
+			OpenDuration:      c.CircuitOpenDuration,
+			HalfOpenSuccesses: c.CircuitHalfOpenSuccesses,
+		},
+		Hedge: &services.HedgeSettings{
+			Delay:    c.HedgeDelay,
+			MaxRatio: c.HedgeMaxRatio,
+		},
+		Links: &linkcheck.Settings{
+			Mode:        strings.ToLower(c.LinkCheckMode),
+			Timeout:     c.LinkCheckTimeout,
+			Concurrency: c.LinkCheckConcurrency,
+			CacheTTL:    c.LinkCheckCacheTTL,
+		},
+	}
+}
+
+// LoadCatalog lê o catálogo de recursos de catalog.file (vazio sem arquivo)
+func (c *Config) LoadCatalog() (*catalog.Catalog, error) {
+	resources, err := catalog.Load(c.CatalogFile)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar catálogo de recursos: %w", err)
+	}
+	return resources, nil
+}
+
+// LoadForTesting carrega configurações para testes: ignora CONFIG_FILE e valores inválidos
+func LoadForTesting() *Config {
+	_ = godotenv.Load()
+
+	cfg := Defaults()
+	_ = applyEnv(cfg)
+	return cfg
 }
@@ -0,0 +1,245 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/linkcheck"
+	"github.com/spellbook/spellbook/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clearEnv garante que variáveis do ambiente de quem roda os testes não interfiram
+func clearEnv(t *testing.T) {
+	for _, name := range []string{
+		"GEMINI_API_KEY", "PORT", "GRPC_PORT", "LOG_LEVEL", "OTEL_EXPORTER_OTLP_ENDPOINT", "PROMPTS_DIR", "SPELLS_DIR",
+		"CORS_ALLOWED_ORIGINS", "GEMINI_PREFERRED_MODELS", "GEMINI_FALLBACK_MODELS",
+		"HTTP_READ_TIMEOUT", "HTTP_WRITE_TIMEOUT", "HTTP_IDLE_TIMEOUT", "SHUTDOWN_TIMEOUT",
+		"GEMINI_REQUEST_TIMEOUT", "QUOTA_RETRY_DELAY", "MODELS_CACHE_TTL", "JOBS_TTL",
+		"RATE_LIMIT_RPS", "RATE_LIMIT_BURST", "GEMINI_API_KEYS", "ADMIN_TOKEN",
+		"CIRCUIT_FAILURE_THRESHOLD", "CIRCUIT_OPEN_DURATION", "CIRCUIT_HALF_OPEN_SUCCESSES",
+		"GEMINI_HEDGE_DELAY", "GEMINI_HEDGE_MAX_RATIO",
+		"LINK_CHECK_MODE", "LINK_CHECK_TIMEOUT", "LINK_CHECK_CONCURRENCY", "LINK_CHECK_CACHE_TTL",
+		"CATALOG_FILE", "CATALOG_MODE",
+	} {
+		t.Setenv(name, "")
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadFile_Defaults(t *testing.T) {
+	clearEnv(t)
+
+	cfg, err := LoadFile("")
+
+	require.NoError(t, err)
+	assert.Equal(t, Defaults(), cfg)
+	assert.Empty(t, cfg.GeminiAPIKey)
+}
+
+func TestLoadFile_YAMLWithEnvOverrides(t *testing.T) {
+	clearEnv(t)
+	path := writeFile(t, "spellbook.yaml", `
+log_level: debug
+server:
+  port: 8082
+  write_timeout: 5m
+  cors_origins: ["https://app.example.com"]
+  rate_limit:
+    requests_per_second: 2.5
+    burst: 5
+gemini:
+  api_key: chave-do-arquivo
+  models:
+    preferred: [gemini-2.5-flash]
+  retry:
+    quota_delay: 10s
+batch:
+  jobs_ttl: 30m
+`)
+	t.Setenv("GEMINI_API_KEY", "chave-do-ambiente")
+	t.Setenv("HTTP_WRITE_TIMEOUT", "6m")
+
+	cfg, err := LoadFile(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, path, cfg.File)
+	assert.Equal(t, "chave-do-ambiente", cfg.GeminiAPIKey)
+	assert.Equal(t, "8082", cfg.Port)
+	assert.Equal(t, "debug", cfg.LogLevel)
+	assert.Equal(t, 6*time.Minute, cfg.WriteTimeout)
+	assert.Equal(t, DefaultReadTimeout, cfg.ReadTimeout)
+	assert.Equal(t, []string{"https://app.example.com"}, cfg.CORSOrigins)
+	assert.Equal(t, 2.5, cfg.RateLimitRPS)
+	assert.Equal(t, 5, cfg.RateLimitBurst)
+	assert.Equal(t, []string{"gemini-2.5-flash"}, cfg.PreferredModels)
+	assert.Equal(t, 10*time.Second, cfg.QuotaRetryDelay)
+	assert.Equal(t, 30*time.Minute, cfg.JobsTTL)
+}
+
+func TestLoadFile_TOML(t *testing.T) {
+	clearEnv(t)
+	path := writeFile(t, "spellbook.toml", `
+log_level = "warn"
+
+[server]
+grpc_port = 9191
+read_timeout = "15s"
+
+[gemini.models]
+fallback = ["gemini-2.5-pro"]
+
+[gemini.circuit_breaker]
+failure_threshold = 0
+open_duration = "1m"
+
+[gemini.hedging]
+delay = "800ms"
+max_ratio = 0.05
+
+[link_check]
+mode = "strip"
+concurrency = 4
+
+[catalog]
+mode = "replace"
+`)
+	t.Setenv("CIRCUIT_HALF_OPEN_SUCCESSES", "2")
+
+	cfg, err := LoadFile(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, "warn", cfg.LogLevel)
+	assert.Equal(t, "9191", cfg.GRPCPort)
+	assert.Equal(t, 15*time.Second, cfg.ReadTimeout)
+	assert.Equal(t, []string{"gemini-2.5-pro"}, cfg.FallbackModels)
+
+	// failure_threshold = 0 desativa o breaker em vez de manter o padrão
+	assert.Equal(t, services.BreakerSettings{OpenDuration: time.Minute, HalfOpenSuccesses: 2}, *cfg.GeminiSettings(nil, nil).Breaker)
+	assert.Equal(t, services.HedgeSettings{Delay: 800 * time.Millisecond, MaxRatio: 0.05}, *cfg.GeminiSettings(nil, nil).Hedge)
+	assert.Equal(t, linkcheck.Settings{Mode: "strip", Timeout: 5 * time.Second, Concurrency: 4, CacheTTL: 24 * time.Hour},
+		*cfg.GeminiSettings(nil, nil).Links)
+	assert.Equal(t, "replace", cfg.GeminiSettings(nil, nil).CatalogMode)
+}
+
+func TestLoadFile_APIKeys(t *testing.T) {
+	clearEnv(t)
+	path := writeFile(t, "spellbook.yaml", `
+gemini:
+  api_key: chave-1
+  api_keys: [chave-2, chave-1]
+admin:
+  token: segredo
+`)
+	t.Setenv("GEMINI_API_KEYS", "chave-3, chave-1")
+
+	cfg, err := LoadFile(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"chave-1", "chave-3"}, cfg.APIKeys())
+	assert.Equal(t, "segredo", cfg.AdminToken)
+}
+
+func TestLoadFile_FileErrors(t *testing.T) {
+	clearEnv(t)
+
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		contains string
+	}{
+		{"chave desconhecida", "c.yaml", "server:\n  prot: 8080\n", "prot"},
+		{"duração inválida", "c.yaml", "server:\n  read_timeout: rapido\n", "não é uma duração"},
+		{"TOML inválido", "c.toml", "server = [", "inválido"},
+		{"extensão não suportada", "c.json", "{}", "não suportado"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFile(writeFile(t, tt.file, tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.contains)
+		})
+	}
+}
+
+func TestLoadFile_ValidationListsAllProblems(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("PORT", "http")
+	t.Setenv("LOG_LEVEL", "verbose")
+	t.Setenv("HTTP_READ_TIMEOUT", "30")
+	t.Setenv("RATE_LIMIT_RPS", "-1")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://app.example.com/path")
+	t.Setenv("PROMPTS_DIR", filepath.Join(t.TempDir(), "inexistente"))
+	t.Setenv("GEMINI_HEDGE_MAX_RATIO", "1.5")
+	t.Setenv("LINK_CHECK_MODE", "delete")
+	t.Setenv("CATALOG_FILE", filepath.Join(t.TempDir(), "catalogo.json"))
+
+	_, err := LoadFile("")
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Len(t, validationErr.Problems, 9)
+	for _, key := range []string{"PORT", "LOG_LEVEL", "HTTP_READ_TIMEOUT", "RATE_LIMIT_RPS", "CORS_ALLOWED_ORIGINS", "PROMPTS_DIR", "GEMINI_HEDGE_MAX_RATIO", "LINK_CHECK_MODE", "CATALOG_FILE"} {
+		assert.Contains(t, err.Error(), key)
+	}
+}
+
+func TestWatcher_Reload(t *testing.T) {
+	clearEnv(t)
+	path := writeFile(t, "spellbook.yaml", "log_level: info\n")
+	initial, err := LoadFile(path)
+	require.NoError(t, err)
+
+	var applied []*Config
+	watcher := NewWatcher(initial, func(cfg *Config) { applied = append(applied, cfg) })
+
+	require.NoError(t, os.WriteFile(path, []byte("log_level: debug\n"), 0o644))
+	assert.True(t, watcher.fileChanged())
+	require.NoError(t, watcher.Reload())
+	assert.False(t, watcher.fileChanged())
+
+	require.Len(t, applied, 1)
+	assert.Equal(t, "debug", watcher.Current().LogLevel)
+
+	// Configuração inválida é rejeitada e a anterior continua em uso
+	require.NoError(t, os.WriteFile(path, []byte("log_level: verbose\n"), 0o644))
+	assert.Error(t, watcher.Reload())
+	assert.Len(t, applied, 1)
+	assert.Equal(t, "debug", watcher.Current().LogLevel)
+}
+
+func TestRestartRequired(t *testing.T) {
+	previous := Defaults()
+	next := Defaults()
+	next.Port = "8081"
+	next.LogLevel = "debug"
+	next.CORSOrigins = []string{"https://app.example.com"}
+	next.SpellsDir = "/etc/spellbook/spells"
+
+	assert.Equal(t, []string{"server.port", "spells_dir"}, RestartRequired(previous, next))
+}
+
+func TestConfig_LoadCatalog(t *testing.T) {
+	cfg := Defaults()
+	cfg.CatalogFile = filepath.Join("..", "..", "examples", "catalog", "resources.json")
+
+	resources, err := cfg.LoadCatalog()
+
+	require.NoError(t, err)
+	assert.Positive(t, resources.Len())
+	assert.Same(t, resources, cfg.GeminiSettings(nil, resources).Catalog)
+
+	cfg.CatalogFile = filepath.Join(t.TempDir(), "inexistente.json")
+	_, err = cfg.LoadCatalog()
+	assert.ErrorContains(t, err, "erro ao carregar catálogo de recursos")
+}
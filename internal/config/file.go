@@ -0,0 +1,200 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig é o formato do arquivo de configuração (YAML ou TOML, pela extensão).
+// Campos ausentes mantêm o valor padrão.
+type fileConfig struct {
+	LogLevel     string `yaml:"log_level" toml:"log_level"`
+	OTLPEndpoint string `yaml:"otlp_endpoint" toml:"otlp_endpoint"`
+	PromptsDir   string `yaml:"prompts_dir" toml:"prompts_dir"`
+	SpellsDir    string `yaml:"spells_dir" toml:"spells_dir"`
+
+	Server struct {
+		Port            int      `yaml:"port" toml:"port"`
+		GRPCPort        int      `yaml:"grpc_port" toml:"grpc_port"`
+		ReadTimeout     duration `yaml:"read_timeout" toml:"read_timeout"`
+		WriteTimeout    duration `yaml:"write_timeout" toml:"write_timeout"`
+		IdleTimeout     duration `yaml:"idle_timeout" toml:"idle_timeout"`
+		ShutdownTimeout duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+		CORSOrigins     []string `yaml:"cors_origins" toml:"cors_origins"`
+		RateLimit       struct {
+			RequestsPerSecond float64 `yaml:"requests_per_second" toml:"requests_per_second"`
+			Burst             int     `yaml:"burst" toml:"burst"`
+		} `yaml:"rate_limit" toml:"rate_limit"`
+	} `yaml:"server" toml:"server"`
+
+	Gemini struct {
+		APIKey         string   `yaml:"api_key" toml:"api_key"`
+		APIKeys        []string `yaml:"api_keys" toml:"api_keys"`
+		RequestTimeout duration `yaml:"request_timeout" toml:"request_timeout"`
+		ModelsCacheTTL duration `yaml:"models_cache_ttl" toml:"models_cache_ttl"`
+		Models         struct {
+			Preferred []string `yaml:"preferred" toml:"preferred"`
+			Fallback  []string `yaml:"fallback" toml:"fallback"`
+		} `yaml:"models" toml:"models"`
+		Retry struct {
+			QuotaDelay duration `yaml:"quota_delay" toml:"quota_delay"`
+		} `yaml:"retry" toml:"retry"`
+		CircuitBreaker struct {
+			// Ponteiro para distinguir 0 (desativa) de ausente
+			FailureThreshold  *int     `yaml:"failure_threshold" toml:"failure_threshold"`
+			OpenDuration      duration `yaml:"open_duration" toml:"open_duration"`
+			HalfOpenSuccesses int      `yaml:"half_open_successes" toml:"half_open_successes"`
+		} `yaml:"circuit_breaker" toml:"circuit_breaker"`
+		Hedging struct {
+			Delay    duration `yaml:"delay" toml:"delay"`
+			MaxRatio *float64 `yaml:"max_ratio" toml:"max_ratio"`
+		} `yaml:"hedging" toml:"hedging"`
+	} `yaml:"gemini" toml:"gemini"`
+
+	Batch struct {
+		JobsTTL duration `yaml:"jobs_ttl" toml:"jobs_ttl"`
+	} `yaml:"batch" toml:"batch"`
+
+	Catalog struct {
+		File string `yaml:"file" toml:"file"`
+		Mode string `yaml:"mode" toml:"mode"`
+	} `yaml:"catalog" toml:"catalog"`
+
+	LinkCheck struct {
+		Mode        string   `yaml:"mode" toml:"mode"`
+		Timeout     duration `yaml:"timeout" toml:"timeout"`
+		Concurrency int      `yaml:"concurrency" toml:"concurrency"`
+		CacheTTL    duration `yaml:"cache_ttl" toml:"cache_ttl"`
+	} `yaml:"link_check" toml:"link_check"`
+
+	Admin struct {
+		Token string `yaml:"token" toml:"token"`
+	} `yaml:"admin" toml:"admin"`
+}
+
+// duration aceita durações no formato de time.ParseDuration (ex: 30s, 4m) em YAML e TOML
+type duration time.Duration
+
+func (d *duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return fmt.Errorf("%q não é uma duração (use 30s, 4m, 1h)", text)
+	}
+	*d = duration(parsed)
+	return nil
+}
+
+// applyFile lê o arquivo de configuração e sobrepõe os campos definidos nele.
+// Chaves desconhecidas são erro, para que erros de digitação não passem despercebidos.
+func applyFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("erro ao ler arquivo de configuração: %w", err)
+	}
+
+	var file fileConfig
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		// Um arquivo vazio (io.EOF) mantém todos os padrões
+		if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("arquivo de configuração %s inválido: %w", path, err)
+		}
+	case ".toml":
+		decoder := toml.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&file); err != nil {
+			return fmt.Errorf("arquivo de configuração %s inválido: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("formato de arquivo de configuração não suportado: %q (use .yaml, .yml ou .toml)", ext)
+	}
+
+	file.applyTo(cfg)
+	return nil
+}
+
+// applyTo copia para cfg os campos definidos no arquivo
+func (f *fileConfig) applyTo(cfg *Config) {
+	setString(&cfg.LogLevel, f.LogLevel)
+	setString(&cfg.OTLPEndpoint, f.OTLPEndpoint)
+	setString(&cfg.PromptsDir, f.PromptsDir)
+	setString(&cfg.SpellsDir, f.SpellsDir)
+	setString(&cfg.GeminiAPIKey, f.Gemini.APIKey)
+	setString(&cfg.AdminToken, f.Admin.Token)
+	setString(&cfg.LinkCheckMode, f.LinkCheck.Mode)
+	setString(&cfg.CatalogFile, f.Catalog.File)
+	setString(&cfg.CatalogMode, f.Catalog.Mode)
+
+	if f.Server.Port != 0 {
+		cfg.Port = strconv.Itoa(f.Server.Port)
+	}
+	if f.Server.GRPCPort != 0 {
+		cfg.GRPCPort = strconv.Itoa(f.Server.GRPCPort)
+	}
+
+	setDuration(&cfg.ReadTimeout, f.Server.ReadTimeout)
+	setDuration(&cfg.WriteTimeout, f.Server.WriteTimeout)
+	setDuration(&cfg.IdleTimeout, f.Server.IdleTimeout)
+	setDuration(&cfg.ShutdownTimeout, f.Server.ShutdownTimeout)
+	setDuration(&cfg.RequestTimeout, f.Gemini.RequestTimeout)
+	setDuration(&cfg.ModelsCacheTTL, f.Gemini.ModelsCacheTTL)
+	setDuration(&cfg.QuotaRetryDelay, f.Gemini.Retry.QuotaDelay)
+	setDuration(&cfg.JobsTTL, f.Batch.JobsTTL)
+	setDuration(&cfg.CircuitOpenDuration, f.Gemini.CircuitBreaker.OpenDuration)
+	setDuration(&cfg.HedgeDelay, f.Gemini.Hedging.Delay)
+	setDuration(&cfg.LinkCheckTimeout, f.LinkCheck.Timeout)
+	setDuration(&cfg.LinkCheckCacheTTL, f.LinkCheck.CacheTTL)
+
+	if f.Gemini.CircuitBreaker.FailureThreshold != nil {
+		cfg.CircuitFailureThreshold = *f.Gemini.CircuitBreaker.FailureThreshold
+	}
+	if f.Gemini.CircuitBreaker.HalfOpenSuccesses != 0 {
+		cfg.CircuitHalfOpenSuccesses = f.Gemini.CircuitBreaker.HalfOpenSuccesses
+	}
+	if f.Gemini.Hedging.MaxRatio != nil {
+		cfg.HedgeMaxRatio = *f.Gemini.Hedging.MaxRatio
+	}
+	if f.LinkCheck.Concurrency != 0 {
+		cfg.LinkCheckConcurrency = f.LinkCheck.Concurrency
+	}
+
+	if f.Server.CORSOrigins != nil {
+		cfg.CORSOrigins = f.Server.CORSOrigins
+	}
+	if f.Gemini.APIKeys != nil {
+		cfg.GeminiAPIKeys = f.Gemini.APIKeys
+	}
+	if f.Gemini.Models.Preferred != nil {
+		cfg.PreferredModels = f.Gemini.Models.Preferred
+	}
+	if f.Gemini.Models.Fallback != nil {
+		cfg.FallbackModels = f.Gemini.Models.Fallback
+	}
+
+	cfg.RateLimitRPS = f.Server.RateLimit.RequestsPerSecond
+	cfg.RateLimitBurst = f.Server.RateLimit.Burst
+}
+
+func setString(target *string, value string) {
+	if value != "" {
+		*target = value
+	}
+}
+
+func setDuration(target *time.Duration, value duration) {
+	if value != 0 {
+		*target = time.Duration(value)
+	}
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidationError lista todos os problemas encontrados na configuração
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "configuração inválida:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// validate verifica a configuração final e retorna os problemas encontrados, identificados
+// pela chave do arquivo e pela variável de ambiente correspondente
+func (c *Config) validate() []string {
+	var problems []string
+	add := func(key, format string, args ...interface{}) {
+		problems = append(problems, key+": "+fmt.Sprintf(format, args...))
+	}
+
+	for _, port := range []struct{ key, value string }{
+		{"server.port (PORT)", c.Port},
+		{"server.grpc_port (GRPC_PORT)", c.GRPCPort},
+	} {
+		if n, err := strconv.Atoi(port.value); err != nil || n < 1 || n > 65535 {
+			add(port.key, "%q não é uma porta válida (1-65535)", port.value)
+		}
+	}
+	if c.Port == c.GRPCPort {
+		add("server.grpc_port (GRPC_PORT)", "deve ser diferente de server.port")
+	}
+
+	switch strings.ToLower(c.LogLevel) {
+	case "debug", "info", "warn", "warning", "error":
+	default:
+		add("log_level (LOG_LEVEL)", "%q não é um nível válido (debug, info, warn, error)", c.LogLevel)
+	}
+
+	for _, d := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"server.read_timeout (HTTP_READ_TIMEOUT)", c.ReadTimeout},
+		{"server.write_timeout (HTTP_WRITE_TIMEOUT)", c.WriteTimeout},
+		{"server.idle_timeout (HTTP_IDLE_TIMEOUT)", c.IdleTimeout},
+		{"server.shutdown_timeout (SHUTDOWN_TIMEOUT)", c.ShutdownTimeout},
+		{"gemini.request_timeout (GEMINI_REQUEST_TIMEOUT)", c.RequestTimeout},
+		{"gemini.retry.quota_delay (QUOTA_RETRY_DELAY)", c.QuotaRetryDelay},
+		{"gemini.models_cache_ttl (MODELS_CACHE_TTL)", c.ModelsCacheTTL},
+		{"batch.jobs_ttl (JOBS_TTL)", c.JobsTTL},
+		{"gemini.circuit_breaker.open_duration (CIRCUIT_OPEN_DURATION)", c.CircuitOpenDuration},
+		{"link_check.timeout (LINK_CHECK_TIMEOUT)", c.LinkCheckTimeout},
+		{"link_check.cache_ttl (LINK_CHECK_CACHE_TTL)", c.LinkCheckCacheTTL},
+	} {
+		if d.value <= 0 {
+			add(d.key, "deve ser maior que zero")
+		}
+	}
+
+	if len(c.CORSOrigins) == 0 {
+		add("server.cors_origins (CORS_ALLOWED_ORIGINS)", "informe ao menos uma origem ou \"*\"")
+	}
+	for _, origin := range c.CORSOrigins {
+		if !validOrigin(origin) {
+			add("server.cors_origins (CORS_ALLOWED_ORIGINS)", "%q não é uma origem válida (use \"*\" ou esquema://host[:porta])", origin)
+		}
+	}
+
+	if c.RateLimitRPS < 0 {
+		add("server.rate_limit.requests_per_second (RATE_LIMIT_RPS)", "não pode ser negativo")
+	}
+	if c.RateLimitBurst < 0 {
+		add("server.rate_limit.burst (RATE_LIMIT_BURST)", "não pode ser negativo")
+	}
+
+	if c.CircuitFailureThreshold < 0 {
+		add("gemini.circuit_breaker.failure_threshold (CIRCUIT_FAILURE_THRESHOLD)", "não pode ser negativo (0 desativa)")
+	}
+	if c.CircuitHalfOpenSuccesses < 1 {
+		add("gemini.circuit_breaker.half_open_successes (CIRCUIT_HALF_OPEN_SUCCESSES)", "deve ser ao menos 1")
+	}
+
+	if c.HedgeDelay < 0 {
+		add("gemini.hedging.delay (GEMINI_HEDGE_DELAY)", "não pode ser negativo (0 desativa)")
+	}
+	if c.HedgeMaxRatio < 0 || c.HedgeMaxRatio > 1 {
+		add("gemini.hedging.max_ratio (GEMINI_HEDGE_MAX_RATIO)", "deve estar entre 0 e 1")
+	}
+
+	switch strings.ToLower(c.LinkCheckMode) {
+	case "off", "flag", "strip":
+	default:
+		add("link_check.mode (LINK_CHECK_MODE)", "%q não é um modo válido (off, flag, strip)", c.LinkCheckMode)
+	}
+	switch strings.ToLower(c.CatalogMode) {
+	case "prefer", "replace":
+	default:
+		add("catalog.mode (CATALOG_MODE)", "%q não é um modo válido (prefer, replace)", c.CatalogMode)
+	}
+	if c.CatalogFile != "" {
+		if info, err := os.Stat(c.CatalogFile); err != nil || info.IsDir() {
+			add("catalog.file (CATALOG_FILE)", "%q não é um arquivo", c.CatalogFile)
+		}
+	}
+
+	if c.LinkCheckConcurrency < 1 {
+		add("link_check.concurrency (LINK_CHECK_CONCURRENCY)", "deve ser ao menos 1")
+	}
+
+	for _, models := range []struct {
+		key   string
+		names []string
+	}{
+		{"gemini.models.preferred (GEMINI_PREFERRED_MODELS)", c.PreferredModels},
+		{"gemini.models.fallback (GEMINI_FALLBACK_MODELS)", c.FallbackModels},
+	} {
+		for _, name := range models.names {
+			if name == "" || strings.ContainsAny(name, " /?#") {
+				add(models.key, "%q não é um nome de modelo válido", name)
+			}
+		}
+	}
+
+	for _, dir := range []struct {
+		key  string
+		path string
+	}{
+		{"prompts_dir (PROMPTS_DIR)", c.PromptsDir},
+		{"spells_dir (SPELLS_DIR)", c.SpellsDir},
+	} {
+		if dir.path == "" {
+			continue
+		}
+		if info, err := os.Stat(dir.path); err != nil || !info.IsDir() {
+			add(dir.key, "%q não é um diretório", dir.path)
+		}
+	}
+
+	return problems
+}
+
+// validOrigin aceita "*" ou uma origem no formato esquema://host[:porta], sem caminho
+func validOrigin(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && u.Path == "" && u.RawQuery == ""
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Watcher recarrega a configuração ao receber SIGHUP ou quando o conteúdo do arquivo muda.
+// Uma configuração inválida é rejeitada e a anterior continua em uso.
+type Watcher struct {
+	// Interval é o intervalo de verificação do arquivo
+	Interval time.Duration
+	// OnChange recebe cada nova configuração válida
+	OnChange func(*Config)
+
+	mu      sync.Mutex
+	current *Config
+	hash    [sha256.Size]byte
+}
+
+// NewWatcher cria um watcher a partir da configuração carregada na inicialização,
+// verificando o arquivo a cada 5s
+func NewWatcher(initial *Config, onChange func(*Config)) *Watcher {
+	w := &Watcher{
+		Interval: 5 * time.Second,
+		OnChange: onChange,
+		current:  initial,
+	}
+	w.hash, _ = fileHash(initial.File)
+	return w
+}
+
+// Current retorna a configuração em uso
+func (w *Watcher) Current() *Config {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// Reload lê o arquivo e o ambiente novamente. Em caso de erro, mantém a configuração atual.
+func (w *Watcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	previous := w.current
+	w.hash, _ = fileHash(previous.File)
+
+	cfg, err := LoadFile(previous.File)
+	if err != nil {
+		slog.Error("configuração recarregada é inválida, mantendo a anterior", "file", previous.File, "error", err)
+		return err
+	}
+
+	if changed := RestartRequired(previous, cfg); len(changed) > 0 {
+		slog.Warn("configurações alteradas só têm efeito após reiniciar o servidor", "fields", changed)
+	}
+
+	w.current = cfg
+	if w.OnChange != nil {
+		w.OnChange(cfg)
+	}
+	slog.Info("configuração recarregada", "file", cfg.File)
+	return nil
+}
+
+// Run recarrega a configuração a cada SIGHUP e, quando há arquivo, sempre que o conteúdo
+// dele muda. Retorna quando o contexto é cancelado.
+func (w *Watcher) Run(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+	if w.Current().File != "" {
+		ticker := time.NewTicker(w.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			_ = w.Reload()
+		case <-tick:
+			if w.fileChanged() {
+				_ = w.Reload()
+			}
+		}
+	}
+}
+
+// fileChanged compara o conteúdo atual do arquivo com o da última leitura
+func (w *Watcher) fileChanged() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	hash, err := fileHash(w.current.File)
+	if err != nil {
+		// Arquivo removido ou sendo substituído: mantém a configuração até a próxima leitura
+		return false
+	}
+	return hash != w.hash
+}
+
+func fileHash(path string) ([sha256.Size]byte, error) {
+	if path == "" {
+		return [sha256.Size]byte{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(data), nil
+}
+
+// RestartRequired lista as configurações alteradas que só têm efeito ao reiniciar o servidor
+func RestartRequired(previous, next *Config) []string {
+	var changed []string
+	check := func(name string, differs bool) {
+		if differs {
+			changed = append(changed, name)
+		}
+	}
+
+	check("server.port", previous.Port != next.Port)
+	check("server.grpc_port", previous.GRPCPort != next.GRPCPort)
+	check("server.read_timeout", previous.ReadTimeout != next.ReadTimeout)
+	check("server.write_timeout", previous.WriteTimeout != next.WriteTimeout)
+	check("server.idle_timeout", previous.IdleTimeout != next.IdleTimeout)
+	check("server.shutdown_timeout", previous.ShutdownTimeout != next.ShutdownTimeout)
+	check("otlp_endpoint", previous.OTLPEndpoint != next.OTLPEndpoint)
+	// Os spells são carregados na inicialização, junto com as rotas
+	check("spells_dir", previous.SpellsDir != next.SpellsDir)
+	return changed
+}
@@ -0,0 +1,81 @@
+package fakegemini
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Os templates de todos os idiomas trazem um JSON de exemplo com as chaves em inglês
+	// e os valores informados na requisição; é dele que AutoGenerate tira tipo e parâmetros
+	stringField = func(name string) *regexp.Regexp {
+		return regexp.MustCompile(`"` + name + `":\s*"([^"]*)"`)
+	}
+	topicField     = stringField("topic")
+	subjectField   = stringField("subject")
+	objectiveField = stringField("objective")
+	totalDaysField = regexp.MustCompile(`"total_days":\s*(\d+)`)
+
+	// Quantidades pedidas no texto do prompt (pt-BR, en, es)
+	exactCount = regexp.MustCompile(`(?:EXATAMENTE|EXACTLY|EXACTAMENTE) (\d+)`)
+	listCount  = regexp.MustCompile(`(?:lista de|list of) (\d+)`)
+	// Mínimo da faixa de atividades por dia da trilha (ex: "2-3 atividades")
+	activitiesCount = regexp.MustCompile(`(\d+)-\d+ (?:atividades|activities|actividades)`)
+)
+
+// AutoGenerate responde com um JSON válido para o tipo de geração pedido no prompt,
+// respeitando o tema e as quantidades pedidas
+func AutoGenerate(req Request) Response {
+	prompt := req.Prompt
+
+	switch {
+	case strings.Contains(prompt, `"key_results"`):
+		return KeyResults(match(objectiveField, prompt), matchInt(listCount, prompt, 5))
+
+	case strings.Contains(prompt, `"steps"`):
+		return EducationalTrailWithActivities(match(topicField, prompt), matchInt(totalDaysField, prompt, 12), matchInt(activitiesCount, prompt, 2))
+
+	case strings.Contains(prompt, `"books"`):
+		return EducationalRoadmap(match(topicField, prompt))
+
+	case strings.Contains(prompt, `"roadmap"`):
+		return roadmapWithItems(match(topicField, prompt), matchInt(exactCount, prompt, 30))
+
+	case strings.Contains(prompt, `"topics"`):
+		return Topics(match(subjectField, prompt), matchInt(listCount, prompt, 10))
+
+	default:
+		return Status(http.StatusBadRequest, `{"error":{"code":400,"message":"fakegemini: prompt não reconhecido","status":"INVALID_ARGUMENT"}}`)
+	}
+}
+
+// roadmapWithItems distribui total itens em categorias de até 6 itens
+func roadmapWithItems(topic string, total int) Response {
+	categories := (total + 5) / 6
+	sizes := make([]int, categories)
+	for i := range sizes {
+		sizes[i] = total / categories
+		if i < total%categories {
+			sizes[i]++
+		}
+	}
+	return JSON(buildRoadmap(topic, sizes))
+}
+
+func match(re *regexp.Regexp, text string) string {
+	if m := re.FindStringSubmatch(text); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+func matchInt(re *regexp.Regexp, text string, fallback int) int {
+	if m := re.FindStringSubmatch(text); m != nil {
+		if n, err := strconv.Atoi(m[1]); err == nil && n > 0 {
+			return n
+		}
+	}
+	return fallback
+}
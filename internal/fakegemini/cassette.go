@@ -0,0 +1,201 @@
+package fakegemini
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Interaction é uma requisição à API e a resposta recebida. A query string (com a API key)
+// não é gravada.
+type Interaction struct {
+	Method   string          `json:"method"`
+	Path     string          `json:"path"`
+	Request  json.RawMessage `json:"request,omitempty"`
+	Status   int             `json:"status"`
+	Response json.RawMessage `json:"response"`
+}
+
+// Cassette é um conjunto de interações gravadas, salvo como JSON
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+
+	mu   sync.Mutex
+	used []bool
+}
+
+// LoadCassette lê uma cassete gravada com NewRecorder
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler cassete: %w", err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar cassete %s: %w", path, err)
+	}
+	cassette.used = make([]bool, len(cassette.Interactions))
+	return &cassette, nil
+}
+
+// Save grava a cassete em path, criando o diretório se necessário
+func (c *Cassette) Save(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+func (c *Cassette) add(interaction Interaction) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Interactions = append(c.Interactions, interaction)
+	c.used = append(c.used, false)
+}
+
+// find retorna a primeira interação ainda não usada com o mesmo método, path e corpo.
+// Requisições idênticas recebem as respostas na ordem em que foram gravadas; depois da
+// última, a última resposta se repete.
+func (c *Cassette) find(method, path string, body []byte) (Interaction, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	request := compact(body)
+	last := -1
+	for i, interaction := range c.Interactions {
+		if interaction.Method != method || interaction.Path != path || !bytes.Equal(compact(interaction.Request), request) {
+			continue
+		}
+		if !c.used[i] {
+			c.used[i] = true
+			return interaction, true
+		}
+		last = i
+	}
+
+	if last >= 0 {
+		return c.Interactions[last], true
+	}
+	return Interaction{}, false
+}
+
+// NewReplay cria um servidor que responde com as interações gravadas na cassete
+func NewReplay(path string) (*Server, error) {
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{cassette: cassette, byModel: make(map[string]Response)}
+	s.start()
+	return s, nil
+}
+
+// NewRecorder cria um servidor que repassa as requisições para a API em target
+// (ex: https://generativelanguage.googleapis.com/v1beta) e grava as interações em path
+// ao ser fechado
+func NewRecorder(target, path string) *Server {
+	s := &Server{
+		cassette: &Cassette{},
+		target:   strings.TrimRight(target, "/"),
+		path:     path,
+		byModel:  make(map[string]Response),
+	}
+	s.start()
+	return s
+}
+
+func (s *Server) replay(w http.ResponseWriter, r *http.Request, body []byte) {
+	s.logRequest(r, body)
+
+	interaction, ok := s.cassette.find(r.Method, r.URL.Path, body)
+	if !ok {
+		message := fmt.Sprintf("fakegemini: nenhuma interação gravada para %s %s", r.Method, r.URL.Path)
+		Status(http.StatusNotImplemented, fmt.Sprintf(`{"error":{"code":501,"message":%q}}`, message)).write(w)
+		return
+	}
+
+	Response{Status: interaction.Status, Body: interaction.Response}.write(w)
+}
+
+func (s *Server) record(w http.ResponseWriter, r *http.Request, body []byte) {
+	s.logRequest(r, body)
+
+	url := s.target + r.URL.Path
+	if r.URL.RawQuery != "" {
+		url += "?" + r.URL.RawQuery
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, url, bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	req.Header.Set("Content-Type", r.Header.Get("Content-Type"))
+
+	client := &http.Client{Timeout: 3 * time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		http.Error(w, "fakegemini: erro ao chamar a API", http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, "fakegemini: erro ao ler resposta da API", http.StatusBadGateway)
+		return
+	}
+
+	interaction := Interaction{Method: r.Method, Path: r.URL.Path, Status: resp.StatusCode, Response: asJSON(respBody)}
+	if len(body) > 0 {
+		interaction.Request = asJSON(body)
+	}
+	s.cassette.add(interaction)
+
+	Response{Status: resp.StatusCode, Body: respBody}.write(w)
+}
+
+// logRequest registra as chamadas a generateContent para Requests e CalledModels
+func (s *Server) logRequest(r *http.Request, body []byte) {
+	if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, ":generateContent") {
+		return
+	}
+	model := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/models/"), ":generateContent")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, Request{Model: model, Prompt: promptText(body)})
+}
+
+// asJSON mantém corpos JSON como estão e grava os demais como string JSON
+func asJSON(data []byte) json.RawMessage {
+	if json.Valid(data) {
+		return json.RawMessage(compact(data))
+	}
+	encoded, _ := json.Marshal(string(data))
+	return encoded
+}
+
+func compact(data []byte) []byte {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return data
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,241 @@
+// Package fakegemini é um servidor HTTP que imita a API do Gemini para testes determinísticos.
+//
+// O servidor atende GET /models e POST /models/{modelo}:generateContent e é usado através de
+// GeminiService.BaseURL:
+//
+//	gemini := fakegemini.New()
+//	defer gemini.Close()
+//	service := services.NewGeminiService("fake-key")
+//	service.BaseURL = gemini.URL
+//
+// Sem configuração, as respostas vêm de AutoGenerate, que monta um JSON válido a partir do
+// prompt. Enqueue, OnModel e OnKey injetam respostas específicas (429, 500, JSON malformado,
+// quantidade errada de itens) para exercitar o fallback entre modelos. NewRecorder e
+// NewReplay gravam interações com a API real em um arquivo e as reproduzem depois.
+package fakegemini
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultModels são os modelos listados em GET /models pelo servidor criado com New
+var DefaultModels = []string{"gemini-2.5-flash", "gemini-2.5-pro"}
+
+// Request é uma chamada a generateContent recebida pelo servidor
+type Request struct {
+	Model  string
+	APIKey string
+	Prompt string
+}
+
+// Handler produz a resposta de uma chamada a generateContent
+type Handler func(Request) Response
+
+// Server é o servidor fake. Os campos podem ser alterados antes das chamadas.
+type Server struct {
+	URL string
+
+	// Models são os modelos listados em GET /models. Chamadas a modelos fora da lista
+	// recebem 404, como na API real.
+	Models []string
+	// Handler responde às chamadas que não têm resposta em fila nem resposta fixa do modelo
+	Handler Handler
+
+	mu       sync.Mutex
+	queue    []Response
+	byModel  map[string]Response
+	byKey    map[string]Response
+	requests []Request
+
+	server   *httptest.Server
+	cassette *Cassette // Usado por NewReplay e NewRecorder
+	target   string    // URL da API real (NewRecorder)
+	path     string    // Arquivo da cassete (NewRecorder)
+}
+
+// New cria e inicia um servidor fake que gera respostas com AutoGenerate
+func New() *Server {
+	s := &Server{
+		Models:  slices.Clone(DefaultModels),
+		Handler: AutoGenerate,
+		byModel: make(map[string]Response),
+		byKey:   make(map[string]Response),
+	}
+	s.start()
+	return s
+}
+
+func (s *Server) start() {
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+}
+
+// Close encerra o servidor. No modo de gravação, salva a cassete.
+func (s *Server) Close() error {
+	s.server.Close()
+	if s.target != "" {
+		return s.cassette.Save(s.path)
+	}
+	return nil
+}
+
+// Enqueue define as respostas das próximas chamadas a generateContent, na ordem,
+// independentemente do modelo
+func (s *Server) Enqueue(responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queue = append(s.queue, responses...)
+}
+
+// OnModel fixa a resposta de todas as chamadas ao modelo (ex: um modelo sempre com 500)
+func (s *Server) OnModel(model string, response Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byModel[model] = response
+}
+
+// OnKey fixa a resposta de todas as chamadas feitas com a API key, inclusive a lista de
+// modelos (ex: uma chave revogada sempre com InvalidKey)
+func (s *Server) OnKey(apiKey string, response Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byKey[apiKey] = response
+}
+
+// Requests retorna as chamadas a generateContent recebidas até agora, na ordem
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// CalledModels retorna os modelos chamados, na ordem (útil para verificar o fallback)
+func (s *Server) CalledModels() []string {
+	requests := s.Requests()
+	models := make([]string, len(requests))
+	for i, req := range requests {
+		models[i] = req.Model
+	}
+	return models
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if s.target != "" {
+		s.record(w, r, body)
+		return
+	}
+
+	if s.cassette != nil {
+		s.replay(w, r, body)
+		return
+	}
+
+	apiKey := r.URL.Query().Get("key")
+	s.mu.Lock()
+	byKey, fixed := s.byKey[apiKey]
+	s.mu.Unlock()
+
+	switch {
+	case fixed && r.Method == http.MethodGet:
+		byKey.write(w)
+
+	case r.Method == http.MethodGet && r.URL.Path == "/models":
+		s.listModels().write(w)
+
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":generateContent"):
+		model := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/models/"), ":generateContent")
+		response := s.generate(model, apiKey, body)
+		if response.Delay > 0 {
+			select {
+			case <-time.After(response.Delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		response.write(w)
+
+	default:
+		Status(http.StatusNotFound, `{"error":{"code":404,"status":"NOT_FOUND"}}`).write(w)
+	}
+}
+
+func (s *Server) listModels() Response {
+	type model struct {
+		Name string `json:"name"`
+	}
+	list := struct {
+		Models []model `json:"models"`
+	}{Models: make([]model, 0, len(s.Models))}
+	for _, name := range s.Models {
+		list.Models = append(list.Models, model{Name: "models/" + name})
+	}
+
+	data, _ := json.Marshal(list)
+	return Response{Status: http.StatusOK, Body: data}
+}
+
+func (s *Server) generate(model, apiKey string, body []byte) Response {
+	req := Request{Model: model, APIKey: apiKey, Prompt: promptText(body)}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+
+	if response, fixed := s.byKey[apiKey]; fixed {
+		s.mu.Unlock()
+		return response
+	}
+
+	if len(s.queue) > 0 {
+		response := s.queue[0]
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+		return response
+	}
+
+	response, fixed := s.byModel[model]
+	s.mu.Unlock()
+
+	if fixed {
+		return response
+	}
+	if !slices.Contains(s.Models, model) {
+		return Status(http.StatusNotFound, fmt.Sprintf(`{"error":{"code":404,"message":"models/%s is not found","status":"NOT_FOUND"}}`, model))
+	}
+	return s.Handler(req)
+}
+
+// promptText extrai o texto do prompt do corpo de generateContent
+func promptText(body []byte) string {
+	var payload struct {
+		Contents []struct {
+			Parts []struct {
+				Text string `json:"text"`
+			} `json:"parts"`
+		} `json:"contents"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+
+	var text strings.Builder
+	for _, content := range payload.Contents {
+		for _, part := range content.Parts {
+			text.WriteString(part.Text)
+		}
+	}
+	return text.String()
+}
@@ -0,0 +1,164 @@
+package fakegemini
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/spellbook/spellbook/internal/prompts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// modelText extrai o texto gerado de uma resposta de generateContent
+func modelText(t *testing.T, response Response) string {
+	var envelope struct {
+		Candidates []struct {
+			Content struct {
+				Parts []struct {
+					Text string `json:"text"`
+				} `json:"parts"`
+			} `json:"content"`
+		} `json:"candidates"`
+	}
+	require.NoError(t, json.Unmarshal(response.Body, &envelope))
+	require.NotEmpty(t, envelope.Candidates)
+	return envelope.Candidates[0].Content.Parts[0].Text
+}
+
+func render(t *testing.T, language, name string, vars map[string]interface{}) Request {
+	prompt, err := prompts.Default().Render(language, name, vars)
+	require.NoError(t, err)
+	return Request{Model: "gemini-2.5-flash", Prompt: prompt.Text}
+}
+
+func TestAutoGenerate(t *testing.T) {
+	for _, language := range []string{"pt-BR", "en", "es"} {
+		t.Run(language, func(t *testing.T) {
+			var topics models.TopicsResponse
+			text := modelText(t, AutoGenerate(render(t, language, "topics", map[string]interface{}{"Subject": "Go", "Count": 7})))
+			require.NoError(t, json.Unmarshal([]byte(text), &topics))
+			assert.Equal(t, "Go", topics.Subject)
+			assert.Len(t, topics.Topics, 7)
+
+			var keyResults models.KeyResultsResponse
+			text = modelText(t, AutoGenerate(render(t, language, "key_results", map[string]interface{}{"Objective": "Crescer", "Count": 4, "Deadline": "none"})))
+			require.NoError(t, json.Unmarshal([]byte(text), &keyResults))
+			assert.Equal(t, "Crescer", keyResults.Objective)
+			assert.Len(t, keyResults.KeyResults, 4)
+
+			var roadmap models.Roadmap
+			text = modelText(t, AutoGenerate(render(t, language, "roadmap", map[string]interface{}{
+				"Topic": "Rust", "TargetItemCount": 13, "DaysAvailable": 13, "NumCategories": "3-4", "ItemsPerCategory": "3-5", "Pace": "short",
+			})))
+			require.NoError(t, json.Unmarshal([]byte(text), &roadmap))
+			assert.Equal(t, "Rust", roadmap.Topic)
+			total := 0
+			for _, category := range roadmap.Roadmap {
+				total += len(category.Items)
+			}
+			assert.Equal(t, 13, total)
+
+			var trail models.EducationalTrail
+			text = modelText(t, AutoGenerate(render(t, language, "educational_trail", map[string]interface{}{"Topic": "SQL", "TotalDays": 5, "ActivitiesPerDay": "1-2", "ProjectsFromDay": 4, "Pace": "short"})))
+			require.NoError(t, json.Unmarshal([]byte(text), &trail))
+			assert.Equal(t, "SQL", trail.Topic)
+			assert.Len(t, trail.Steps, 5)
+
+			var educational models.EducationalRoadmap
+			text = modelText(t, AutoGenerate(render(t, language, "educational_roadmap", map[string]interface{}{"Topic": "SQL"})))
+			require.NoError(t, json.Unmarshal([]byte(text), &educational))
+			assert.Equal(t, "SQL", educational.Topic)
+			assert.NotEmpty(t, educational.Books)
+		})
+	}
+}
+
+func generateContent(t *testing.T, baseURL, model, prompt string) *http.Response {
+	body, _ := json.Marshal(map[string]interface{}{
+		"contents": []map[string]interface{}{{"parts": []map[string]string{{"text": prompt}}}},
+	})
+	resp, err := http.Post(baseURL+"/models/"+model+":generateContent?key=segredo", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestServer_FaultInjection(t *testing.T) {
+	server := New()
+	defer server.Close()
+
+	server.Enqueue(Quota(), Malformed())
+	server.OnModel("gemini-2.5-pro", ServerError())
+
+	assert.Equal(t, http.StatusTooManyRequests, generateContent(t, server.URL, "gemini-2.5-flash", "").StatusCode)
+	assert.Equal(t, http.StatusOK, generateContent(t, server.URL, "gemini-2.5-flash", "").StatusCode)
+	assert.Equal(t, http.StatusInternalServerError, generateContent(t, server.URL, "gemini-2.5-pro", "").StatusCode)
+	assert.Equal(t, http.StatusNotFound, generateContent(t, server.URL, "gemini-inexistente", "").StatusCode)
+
+	assert.Equal(t, []string{"gemini-2.5-flash", "gemini-2.5-flash", "gemini-2.5-pro", "gemini-inexistente"}, server.CalledModels())
+}
+
+func TestServer_ListModels(t *testing.T) {
+	server := New()
+	defer server.Close()
+	server.Models = []string{"gemini-test"}
+
+	resp, err := http.Get(server.URL + "/models?key=segredo")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var list struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.Len(t, list.Models, 1)
+	assert.Equal(t, "models/gemini-test", list.Models[0].Name)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	// "API real" que responde 429 na primeira chamada e sucesso na segunda
+	calls := 0
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "segredo", r.URL.Query().Get("key"))
+		calls++
+		if calls == 1 {
+			Quota().write(w)
+			return
+		}
+		Topics("Go", 3).write(w)
+	}))
+	defer upstream.Close()
+
+	path := filepath.Join(t.TempDir(), "cassete.json")
+
+	recorder := NewRecorder(upstream.URL, path)
+	assert.Equal(t, http.StatusTooManyRequests, generateContent(t, recorder.URL, "gemini-2.5-flash", "tópicos sobre Go").StatusCode)
+	assert.Equal(t, http.StatusOK, generateContent(t, recorder.URL, "gemini-2.5-flash", "tópicos sobre Go").StatusCode)
+	require.NoError(t, recorder.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.False(t, strings.Contains(string(data), "segredo"), "a API key não pode ser gravada")
+
+	replay, err := NewReplay(path)
+	require.NoError(t, err)
+	defer replay.Close()
+
+	// As respostas de requisições idênticas são reproduzidas na ordem da gravação
+	assert.Equal(t, http.StatusTooManyRequests, generateContent(t, replay.URL, "gemini-2.5-flash", "tópicos sobre Go").StatusCode)
+	resp := generateContent(t, replay.URL, "gemini-2.5-flash", "tópicos sobre Go")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Requisição não gravada
+	assert.Equal(t, http.StatusNotImplemented, generateContent(t, replay.URL, "gemini-2.5-flash", "outro prompt").StatusCode)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,207 @@
+package fakegemini
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/models"
+)
+
+// Response é a resposta HTTP devolvida pelo servidor
+type Response struct {
+	Status int
+	Body   []byte
+	// Delay atrasa a resposta a generateContent; o cliente pode desistir antes (ver Slow)
+	Delay time.Duration
+}
+
+// Slow atrasa a resposta informada, simulando um modelo lento
+func Slow(delay time.Duration, response Response) Response {
+	response.Delay = delay
+	return response
+}
+
+func (r Response) write(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(r.Status)
+	w.Write(r.Body)
+}
+
+// Status cria uma resposta com o status e o corpo informados
+func Status(status int, body string) Response {
+	return Response{Status: status, Body: []byte(body)}
+}
+
+// Text cria uma resposta de sucesso em que o modelo gerou o texto informado
+func Text(text string) Response {
+	envelope := map[string]interface{}{
+		"candidates": []map[string]interface{}{
+			{"content": map[string]interface{}{
+				"parts": []map[string]string{{"text": text}},
+				"role":  "model",
+			}},
+		},
+		"usageMetadata": map[string]int{
+			"promptTokenCount":     100,
+			"candidatesTokenCount": len(text) / 4,
+		},
+	}
+	data, _ := json.Marshal(envelope)
+	return Response{Status: http.StatusOK, Body: data}
+}
+
+// JSON cria uma resposta de sucesso em que o modelo gerou v serializado em JSON
+func JSON(v interface{}) Response {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("fakegemini: valor não serializável: %v", err))
+	}
+	return Text(string(data))
+}
+
+// Quota simula quota excedida (429)
+func Quota() Response {
+	return Status(http.StatusTooManyRequests, `{"error":{"code":429,"message":"Resource has been exhausted (e.g. check quota).","status":"RESOURCE_EXHAUSTED"}}`)
+}
+
+// QuotaRetry simula um erro de quota em que a API informa a espera até a quota ser
+// liberada (RetryInfo), como nos limites por minuto do plano gratuito
+func QuotaRetry(delay time.Duration) Response {
+	return Status(http.StatusTooManyRequests, fmt.Sprintf(`{"error":{"code":429,"message":"You exceeded your current quota.","status":"RESOURCE_EXHAUSTED","details":[{"@type":"type.googleapis.com/google.rpc.RetryInfo","retryDelay":"%ds"}]}}`, int(delay.Seconds())))
+}
+
+// InvalidKey simula uma API key inválida ou revogada (a API responde 400 com API_KEY_INVALID)
+func InvalidKey() Response {
+	return Status(http.StatusBadRequest, `{"error":{"code":400,"message":"API key not valid. Please pass a valid API key.","status":"INVALID_ARGUMENT","details":[{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"API_KEY_INVALID","domain":"googleapis.com"}]}}`)
+}
+
+// Forbidden simula uma permissão negada (403), como um modelo que a chave não pode usar
+func Forbidden() Response {
+	return Status(http.StatusForbidden, `{"error":{"code":403,"message":"Permission denied on resource (or it may not exist).","status":"PERMISSION_DENIED"}}`)
+}
+
+// ServerError simula um erro interno da API (500)
+func ServerError() Response {
+	return Status(http.StatusInternalServerError, `{"error":{"code":500,"message":"An internal error has occurred.","status":"INTERNAL"}}`)
+}
+
+// Malformed simula um modelo que respondeu com texto que não é JSON
+func Malformed() Response {
+	return Text(`Aqui está o roadmap: {"topic": "incompleto", "roadmap": [`)
+}
+
+// Empty simula uma resposta sem candidatos (ex: bloqueada por filtro de segurança)
+func Empty() Response {
+	return Status(http.StatusOK, `{"candidates":[],"promptFeedback":{"blockReason":"SAFETY"}}`)
+}
+
+// Roadmap gera um roadmap com categories categorias de itemsPerCategory itens.
+// Use uma quantidade diferente da pedida no prompt para simular respostas rejeitadas.
+func Roadmap(topic string, categories, itemsPerCategory int) Response {
+	sizes := make([]int, categories)
+	for i := range sizes {
+		sizes[i] = itemsPerCategory
+	}
+	return JSON(buildRoadmap(topic, sizes))
+}
+
+// buildRoadmap monta um roadmap com uma categoria para cada tamanho em sizes
+func buildRoadmap(topic string, sizes []int) models.Roadmap {
+	roadmap := models.Roadmap{Topic: topic}
+	id := 1
+	for c, size := range sizes {
+		category := models.RoadmapCategory{Category: fmt.Sprintf("Categoria %d", c+1)}
+		for i := 0; i < size; i++ {
+			category.Items = append(category.Items, models.RoadmapItem{
+				ID:    fmt.Sprint(id),
+				Title: fmt.Sprintf("%s: item %d", topic, id),
+			})
+			id++
+		}
+		roadmap.Roadmap = append(roadmap.Roadmap, category)
+	}
+	return roadmap
+}
+
+// Topics gera count tópicos sobre o assunto
+func Topics(subject string, count int) Response {
+	topics := models.TopicsResponse{Subject: subject, Topics: make([]string, count)}
+	for i := range topics.Topics {
+		topics.Topics[i] = fmt.Sprintf("%s: tópico %d", subject, i+1)
+	}
+	return JSON(topics)
+}
+
+// KeyResults gera count Key Results para o objetivo
+func KeyResults(objective string, count int) Response {
+	keyResults := models.KeyResultsResponse{Objective: objective, KeyResults: make([]string, count)}
+	for i := range keyResults.KeyResults {
+		keyResults.KeyResults[i] = fmt.Sprintf("Atingir a meta %d de %s", i+1, objective)
+	}
+	return JSON(keyResults)
+}
+
+// EducationalRoadmap gera um roadmap educacional com três recursos de cada tipo
+func EducationalRoadmap(topic string) Response {
+	resources := func(kind string) []models.EducationalResource {
+		list := make([]models.EducationalResource, 3)
+		for i := range list {
+			list[i] = models.EducationalResource{
+				Title:       fmt.Sprintf("%s sobre %s %d", kind, topic, i+1),
+				Description: fmt.Sprintf("%s introdutório", kind),
+			}
+		}
+		return list
+	}
+
+	return JSON(models.EducationalRoadmap{
+		Topic:    topic,
+		Books:    resources("Livro"),
+		Courses:  resources("Curso"),
+		Videos:   resources("Vídeo"),
+		Articles: resources("Artigo"),
+		Projects: resources("Projeto"),
+	})
+}
+
+// EducationalTrail gera uma trilha de days dias com duas atividades por dia
+func EducationalTrail(topic string, days int) Response {
+	return EducationalTrailWithActivities(topic, days, 2)
+}
+
+// EducationalTrailWithActivities é como EducationalTrail, com perDay atividades em cada dia
+func EducationalTrailWithActivities(topic string, days, perDay int) Response {
+	trail := models.EducationalTrail{
+		Topic:       topic,
+		TotalDays:   days,
+		Description: "Trilha de aprendizado progressiva",
+		Resources: map[string]models.EducationalResource{
+			"recurso_1": {Title: "Livro sobre " + topic, Description: "Referência principal"},
+			"recurso_2": {Title: "Vídeo sobre " + topic, Duration: "30 min"},
+			"recurso_3": {Title: "Artigo sobre " + topic},
+		},
+	}
+	activities := []models.Activity{
+		{Type: "read_chapters", ResourceID: "recurso_1", Description: "Leitura"},
+		{Type: "watch_video", ResourceID: "recurso_2", Title: "Assistir ao vídeo", Description: "Revisão"},
+		{Type: "read_article", ResourceID: "recurso_3", Title: "Ler o artigo", Description: "Aprofundamento"},
+	}
+	for day := 1; day <= days; day++ {
+		step := models.EducationalTrailStep{
+			Day:         day,
+			Title:       fmt.Sprintf("Dia %d", day),
+			Description: fmt.Sprintf("Estudo de %s", topic),
+		}
+		for i := 0; i < perDay; i++ {
+			activity := activities[i%len(activities)]
+			if activity.Type == "read_chapters" {
+				activity.Title = fmt.Sprintf("Ler capítulo %d", day)
+			}
+			step.Activities = append(step.Activities, activity)
+		}
+		trail.Steps = append(trail.Steps, step)
+	}
+	return JSON(trail)
+}
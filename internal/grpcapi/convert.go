@@ -0,0 +1,111 @@
+package grpcapi
+
+import (
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/spellbook/spellbook/pkg/pb/spellbookv1"
+)
+
+// Conversões dos modelos do serviço para as mensagens protobuf
+
+func toRoadmap(roadmap *models.Roadmap) *spellbookv1.Roadmap {
+	categories := make([]*spellbookv1.RoadmapCategory, 0, len(roadmap.Roadmap))
+	for _, category := range roadmap.Roadmap {
+		items := make([]*spellbookv1.RoadmapItem, 0, len(category.Items))
+		for _, item := range category.Items {
+			items = append(items, &spellbookv1.RoadmapItem{Id: item.ID, Title: item.Title, Completed: item.Completed})
+		}
+		categories = append(categories, &spellbookv1.RoadmapCategory{Category: category.Category, Items: items})
+	}
+
+	return &spellbookv1.Roadmap{
+		Topic:         roadmap.Topic,
+		Roadmap:       categories,
+		PromptVersion: roadmap.PromptVersion,
+	}
+}
+
+func toTopics(topics *models.TopicsResponse) *spellbookv1.TopicsResponse {
+	return &spellbookv1.TopicsResponse{
+		Subject:       topics.Subject,
+		Topics:        topics.Topics,
+		PromptVersion: topics.PromptVersion,
+	}
+}
+
+func toKeyResults(keyResults *models.KeyResultsResponse) *spellbookv1.KeyResultsResponse {
+	return &spellbookv1.KeyResultsResponse{
+		Objective:     keyResults.Objective,
+		KeyResults:    keyResults.KeyResults,
+		PromptVersion: keyResults.PromptVersion,
+	}
+}
+
+func toEducationalRoadmap(roadmap *models.EducationalRoadmap) *spellbookv1.EducationalRoadmap {
+	return &spellbookv1.EducationalRoadmap{
+		Topic:         roadmap.Topic,
+		Books:         toResources(roadmap.Books),
+		Courses:       toResources(roadmap.Courses),
+		Videos:        toResources(roadmap.Videos),
+		Articles:      toResources(roadmap.Articles),
+		Projects:      toResources(roadmap.Projects),
+		PromptVersion: roadmap.PromptVersion,
+	}
+}
+
+func toEducationalTrail(trail *models.EducationalTrail) *spellbookv1.EducationalTrail {
+	steps := make([]*spellbookv1.EducationalTrailStep, 0, len(trail.Steps))
+	for _, step := range trail.Steps {
+		activities := make([]*spellbookv1.Activity, 0, len(step.Activities))
+		for _, activity := range step.Activities {
+			activities = append(activities, &spellbookv1.Activity{
+				Type:        activity.Type,
+				ResourceId:  activity.ResourceID,
+				Title:       activity.Title,
+				Description: activity.Description,
+				Chapters:    activity.Chapters,
+				Duration:    activity.Duration,
+				Url:         activity.URL,
+				Progress:    activity.Progress,
+			})
+		}
+		steps = append(steps, &spellbookv1.EducationalTrailStep{
+			Day:         int32(step.Day),
+			Title:       step.Title,
+			Description: step.Description,
+			Activities:  activities,
+		})
+	}
+
+	resources := make(map[string]*spellbookv1.EducationalResource, len(trail.Resources))
+	for id, resource := range trail.Resources {
+		resources[id] = toResource(resource)
+	}
+
+	return &spellbookv1.EducationalTrail{
+		Topic:         trail.Topic,
+		TotalDays:     int32(trail.TotalDays),
+		Description:   trail.Description,
+		Steps:         steps,
+		Resources:     resources,
+		PromptVersion: trail.PromptVersion,
+	}
+}
+
+func toResources(resources []models.EducationalResource) []*spellbookv1.EducationalResource {
+	converted := make([]*spellbookv1.EducationalResource, 0, len(resources))
+	for _, resource := range resources {
+		converted = append(converted, toResource(resource))
+	}
+	return converted
+}
+
+func toResource(resource models.EducationalResource) *spellbookv1.EducationalResource {
+	return &spellbookv1.EducationalResource{
+		Title:       resource.Title,
+		Description: resource.Description,
+		Url:         resource.URL,
+		Chapters:    resource.Chapters,
+		Duration:    resource.Duration,
+		Author:      resource.Author,
+	}
+}
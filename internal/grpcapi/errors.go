@@ -0,0 +1,57 @@
+package grpcapi
+
+import (
+	"github.com/spellbook/spellbook/internal/apperror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain identifica os erros do Spellbook no google.rpc.ErrorInfo
+const ErrorDomain = "spellbook"
+
+// grpcCodes mapeia cada tipo de erro ao código gRPC equivalente ao status HTTP
+var grpcCodes = map[apperror.Kind]codes.Code{
+	apperror.KindValidation:          codes.InvalidArgument,
+	apperror.KindNotFound:            codes.NotFound,
+	apperror.KindRateLimited:         codes.ResourceExhausted,
+	apperror.KindUnauthenticated:     codes.Unauthenticated,
+	apperror.KindUpstreamQuota:       codes.ResourceExhausted,
+	apperror.KindUpstreamUnavailable: codes.Unavailable,
+	apperror.KindOutputInvalid:       codes.Internal,
+	apperror.KindUnauthorized:        codes.Internal,
+	apperror.KindTimeout:             codes.DeadlineExceeded,
+	apperror.KindInternal:            codes.Internal,
+}
+
+// statusError converte o erro em status gRPC com a mensagem no idioma da requisição,
+// o código estável em ErrorInfo e, em erros de validação, os campos inválidos em BadRequest
+func statusError(err error, lang string) error {
+	appErr := apperror.From(err)
+	problem := apperror.NewProblem(appErr, lang)
+
+	code, ok := grpcCodes[appErr.Kind]
+	if !ok {
+		code = codes.Internal
+	}
+
+	st := status.New(code, problem.Detail)
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: appErr.Code, Domain: ErrorDomain}}
+	if len(appErr.Fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range appErr.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
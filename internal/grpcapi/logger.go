@@ -0,0 +1,50 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryLoggerInterceptor registra cada chamada gRPC em log estruturado
+func UnaryLoggerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamLoggerInterceptor registra cada chamada gRPC com streaming em log estruturado
+func StreamLoggerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		logCall(stream.Context(), info.FullMethod, start, err)
+		return err
+	}
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK:
+	case codes.Internal, codes.Unavailable, codes.DeadlineExceeded, codes.Unknown:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+
+	slog.Log(ctx, level, "chamada gRPC",
+		"method", method,
+		"code", code.String(),
+		"latency_ms", time.Since(start).Milliseconds(),
+	)
+}
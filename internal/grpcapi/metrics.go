@@ -0,0 +1,61 @@
+package grpcapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcMethod é o valor do label method das chamadas gRPC, que chegam como POST em HTTP/2
+const grpcMethod = http.MethodPost
+
+// httpStatus mapeia o código gRPC ao status HTTP equivalente (o inverso de grpcCodes), para
+// as chamadas gRPC entrarem nas mesmas métricas das requisições HTTP
+var httpStatus = map[codes.Code]int{
+	codes.OK:                http.StatusOK,
+	codes.InvalidArgument:   http.StatusBadRequest,
+	codes.NotFound:          http.StatusNotFound,
+	codes.ResourceExhausted: http.StatusTooManyRequests,
+	codes.Unauthenticated:   http.StatusUnauthorized,
+	codes.Unavailable:       http.StatusServiceUnavailable,
+	codes.DeadlineExceeded:  http.StatusGatewayTimeout,
+	codes.Canceled:          499,
+}
+
+// UnaryMetricsInterceptor registra contagem e latência das chamadas gRPC nas métricas HTTP,
+// com o método gRPC completo como rota
+func UnaryMetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		recordCall(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamMetricsInterceptor registra contagem e latência das chamadas gRPC com streaming
+func StreamMetricsInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		recordCall(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func recordCall(method string, start time.Time, err error) {
+	code, ok := httpStatus[status.Code(err)]
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+	statusLabel := strconv.Itoa(code)
+
+	metrics.HTTPRequestsTotal.WithLabelValues(method, grpcMethod, statusLabel).Inc()
+	metrics.HTTPRequestDuration.WithLabelValues(method, grpcMethod, statusLabel).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,35 @@
+package grpcapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/metrics"
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/spellbook/spellbook/pkg/pb/spellbookv1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_RecordsHTTPMetrics(t *testing.T) {
+	const route = "/spellbook.v1.SpellbookService/GenerateTopics"
+	mockService := new(MockGeminiService)
+	mockService.On("GenerateTopics", mock.Anything, "Go", 10, "pt-BR").Return(&models.TopicsResponse{Subject: "Go"}, nil)
+	mockService.On("GenerateTopics", mock.Anything, "Rust", 10, "pt-BR").
+		Return(nil, apperror.New(apperror.KindUpstreamUnavailable, apperror.CodeUpstreamUnavailable, "down"))
+	client := startServer(t, NewServer(mockService))
+
+	ok := testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(route, "POST", "200"))
+	unavailable := testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(route, "POST", "503"))
+
+	_, err := client.GenerateTopics(context.Background(), &spellbookv1.TopicsRequest{Subject: "Go"})
+	require.NoError(t, err)
+	_, err = client.GenerateTopics(context.Background(), &spellbookv1.TopicsRequest{Subject: "Rust"})
+	require.Error(t, err)
+
+	assert.Equal(t, ok+1, testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(route, "POST", "200")))
+	assert.Equal(t, unavailable+1, testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(route, "POST", "503")))
+}
@@ -0,0 +1,178 @@
+// Package grpcapi implementa a API gRPC (spellbook.v1.SpellbookService) sobre o mesmo
+// GeminiServiceInterface usado pelos handlers REST.
+package grpcapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/i18n"
+	"github.com/spellbook/spellbook/internal/openapi"
+	"github.com/spellbook/spellbook/internal/services"
+	"github.com/spellbook/spellbook/pkg/pb/spellbookv1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Server implementa spellbookv1.SpellbookServiceServer
+type Server struct {
+	spellbookv1.UnimplementedSpellbookServiceServer
+
+	Service services.GeminiServiceInterface
+	Spec    *openapi.Spec
+	// ProgressInterval é o intervalo entre os eventos de progresso dos RPCs com streaming
+	ProgressInterval time.Duration
+}
+
+// NewServer cria o servidor gRPC com eventos de progresso a cada 5s
+func NewServer(service services.GeminiServiceInterface) *Server {
+	return &Server{
+		Service:          service,
+		Spec:             openapi.Load(),
+		ProgressInterval: 5 * time.Second,
+	}
+}
+
+// NewGRPCServer cria um grpc.Server com o serviço registrado e os interceptors de tracing,
+// métricas e log
+func NewGRPCServer(service services.GeminiServiceInterface) *grpc.Server {
+	server := grpc.NewServer(serverOptions()...)
+	spellbookv1.RegisterSpellbookServiceServer(server, NewServer(service))
+	return server
+}
+
+// serverOptions encadeia os interceptors na mesma ordem dos middlewares HTTP: o span vem
+// primeiro, para o log da chamada já ter o trace
+func serverOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(UnaryTracingInterceptor(), UnaryMetricsInterceptor(), UnaryLoggerInterceptor()),
+		grpc.ChainStreamInterceptor(StreamTracingInterceptor(), StreamMetricsInterceptor(), StreamLoggerInterceptor()),
+	}
+}
+
+// GenerateRoadmap gera um roadmap de estudo
+func (s *Server) GenerateRoadmap(ctx context.Context, req *spellbookv1.RoadmapRequest) (*spellbookv1.Roadmap, error) {
+	lang, err := s.prepare(ctx, "/api/v1/roadmap", req, req.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+
+	roadmap, err := s.Service.GenerateRoadmap(ctx, req.GetTopic(), intPtr(req.AvailableDays), intPtr(req.ExactItemCount), lang)
+	if err != nil {
+		return nil, statusError(err, lang)
+	}
+	return toRoadmap(roadmap), nil
+}
+
+// GenerateTopics gera uma lista de tópicos sobre um assunto
+func (s *Server) GenerateTopics(ctx context.Context, req *spellbookv1.TopicsRequest) (*spellbookv1.TopicsResponse, error) {
+	lang, err := s.prepare(ctx, "/api/v1/topics", req, req.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+
+	// Usar count padrão de 10 se não especificado (mesmo padrão do REST)
+	count := int(req.GetCount())
+	if count <= 0 {
+		count = 10
+	}
+
+	topics, err := s.Service.GenerateTopics(ctx, req.GetSubject(), count, lang)
+	if err != nil {
+		return nil, statusError(err, lang)
+	}
+	return toTopics(topics), nil
+}
+
+// GenerateKeyResults gera Key Results para um objetivo
+func (s *Server) GenerateKeyResults(ctx context.Context, req *spellbookv1.KeyResultsRequest) (*spellbookv1.KeyResultsResponse, error) {
+	lang, err := s.prepare(ctx, "/api/v1/key-results", req, req.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+
+	// Usar count padrão de 5 se não especificado (mesmo padrão do REST)
+	count := int(req.GetCount())
+	if count <= 0 {
+		count = 5
+	}
+
+	keyResults, err := s.Service.GenerateKeyResults(ctx, req.GetObjective(), count, req.CompletionDate, lang)
+	if err != nil {
+		return nil, statusError(err, lang)
+	}
+	return toKeyResults(keyResults), nil
+}
+
+// GenerateEducationalRoadmap gera um roadmap educacional
+func (s *Server) GenerateEducationalRoadmap(ctx context.Context, req *spellbookv1.EducationalRoadmapRequest) (*spellbookv1.EducationalRoadmap, error) {
+	lang, err := s.prepare(ctx, "/api/v1/educational-roadmap", req, req.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+
+	roadmap, err := s.Service.GenerateEducationalRoadmap(ctx, req.GetTopic(), lang)
+	if err != nil {
+		return nil, statusError(err, lang)
+	}
+	return toEducationalRoadmap(roadmap), nil
+}
+
+// GenerateEducationalTrail gera uma trilha educacional
+func (s *Server) GenerateEducationalTrail(ctx context.Context, req *spellbookv1.EducationalTrailRequest) (*spellbookv1.EducationalTrail, error) {
+	lang, err := s.prepare(ctx, "/api/v1/educational-trail", req, req.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+
+	trail, err := s.Service.GenerateEducationalTrail(ctx, req.GetTopic(), intPtr(req.AvailableDays), lang)
+	if err != nil {
+		return nil, statusError(err, lang)
+	}
+	return toEducationalTrail(trail), nil
+}
+
+// prepare resolve o idioma da resposta e valida a requisição com o schema do endpoint REST
+// equivalente, para que as duas APIs aceitem exatamente os mesmos valores
+func (s *Server) prepare(ctx context.Context, path string, req proto.Message, requested string) (string, error) {
+	lang := requestLanguage(ctx, requested)
+
+	body, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(req)
+	if err != nil {
+		return lang, statusError(err, lang)
+	}
+
+	if fields := s.Spec.ValidateRequest(http.MethodPost, path, body); len(fields) > 0 {
+		appErr := apperror.New(apperror.KindValidation, apperror.CodeRequestInvalid, "requisição não segue o schema")
+		appErr.Fields = fields
+		return lang, statusError(appErr, lang)
+	}
+	return lang, nil
+}
+
+// requestLanguage resolve o idioma a partir do campo language e do metadata accept-language,
+// e o informa ao cliente no header content-language
+func requestLanguage(ctx context.Context, requested string) string {
+	var acceptLanguage string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("accept-language"); len(values) > 0 {
+			acceptLanguage = values[0]
+		}
+	}
+
+	lang := i18n.Resolve(requested, acceptLanguage)
+	_ = grpc.SetHeader(ctx, metadata.Pairs("content-language", lang))
+	return lang
+}
+
+func intPtr(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+	v := int(*value)
+	return &v
+}
@@ -0,0 +1,250 @@
+package grpcapi
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/spellbook/spellbook/internal/services"
+	"github.com/spellbook/spellbook/pkg/pb/spellbookv1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ services.GeminiServiceInterface = (*MockGeminiService)(nil)
+
+type MockGeminiService struct {
+	mock.Mock
+}
+
+func (m *MockGeminiService) GenerateRoadmap(ctx context.Context, topic string, availableDays *int, exactItemCount *int, language string) (*models.Roadmap, error) {
+	args := m.Called(ctx, topic, availableDays, exactItemCount, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Roadmap), args.Error(1)
+}
+
+func (m *MockGeminiService) GenerateTopics(ctx context.Context, subject string, count int, language string) (*models.TopicsResponse, error) {
+	args := m.Called(ctx, subject, count, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TopicsResponse), args.Error(1)
+}
+
+func (m *MockGeminiService) GenerateKeyResults(ctx context.Context, objective string, count int, completionDate *string, language string) (*models.KeyResultsResponse, error) {
+	args := m.Called(ctx, objective, count, completionDate, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.KeyResultsResponse), args.Error(1)
+}
+
+func (m *MockGeminiService) GenerateEducationalRoadmap(ctx context.Context, topic string, language string) (*models.EducationalRoadmap, error) {
+	args := m.Called(ctx, topic, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EducationalRoadmap), args.Error(1)
+}
+
+func (m *MockGeminiService) GenerateEducationalTrail(ctx context.Context, topic string, availableDays *int, language string) (*models.EducationalTrail, error) {
+	args := m.Called(ctx, topic, availableDays, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EducationalTrail), args.Error(1)
+}
+
+// startServer sobe o serviço em memória (bufconn) e retorna um cliente conectado a ele
+func startServer(t *testing.T, server *Server) spellbookv1.SpellbookServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer(serverOptions()...)
+	spellbookv1.RegisterSpellbookServiceServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return spellbookv1.NewSpellbookServiceClient(conn)
+}
+
+func errorInfo(t *testing.T, err error) (*status.Status, *errdetails.ErrorInfo) {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return st, info
+		}
+	}
+	t.Fatalf("status sem ErrorInfo: %v", err)
+	return nil, nil
+}
+
+func TestServer_GenerateTopics_DefaultCountAndLanguage(t *testing.T) {
+	mockService := new(MockGeminiService)
+	mockService.On("GenerateTopics", mock.Anything, "Go", 10, "en").
+		Return(&models.TopicsResponse{Subject: "Go", Topics: []string{"Goroutines"}, PromptVersion: "v1"}, nil)
+
+	client := startServer(t, NewServer(mockService))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "accept-language", "en-US")
+	var header metadata.MD
+	resp, err := client.GenerateTopics(ctx, &spellbookv1.TopicsRequest{Subject: "Go"}, grpc.Header(&header))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Goroutines"}, resp.GetTopics())
+	assert.Equal(t, "v1", resp.GetPromptVersion())
+	assert.Equal(t, []string{"en"}, header.Get("content-language"))
+	mockService.AssertExpectations(t)
+}
+
+func TestServer_GenerateRoadmap_OptionalFields(t *testing.T) {
+	mockService := new(MockGeminiService)
+	mockService.On("GenerateRoadmap", mock.Anything, "Rust", mock.MatchedBy(func(days *int) bool {
+		return days != nil && *days == 30
+	}), (*int)(nil), "pt-BR").Return(&models.Roadmap{
+		Topic: "Rust",
+		Roadmap: []models.RoadmapCategory{
+			{Category: "Básico", Items: []models.RoadmapItem{{ID: "1", Title: "Ownership"}}},
+		},
+	}, nil)
+
+	client := startServer(t, NewServer(mockService))
+
+	resp, err := client.GenerateRoadmap(context.Background(), &spellbookv1.RoadmapRequest{Topic: "Rust", AvailableDays: proto.Int32(30)})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetRoadmap(), 1)
+	assert.Equal(t, "Ownership", resp.GetRoadmap()[0].GetItems()[0].GetTitle())
+	mockService.AssertExpectations(t)
+}
+
+func TestServer_ValidationError(t *testing.T) {
+	mockService := new(MockGeminiService)
+	client := startServer(t, NewServer(mockService))
+
+	_, err := client.GenerateRoadmap(context.Background(), &spellbookv1.RoadmapRequest{Topic: "Go", AvailableDays: proto.Int32(0)})
+
+	st, info := errorInfo(t, err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, apperror.CodeRequestInvalid, info.GetReason())
+	assert.Equal(t, ErrorDomain, info.GetDomain())
+
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			badRequest = br
+		}
+	}
+	require.NotNil(t, badRequest)
+	assert.Equal(t, "/available_days", badRequest.GetFieldViolations()[0].GetField())
+
+	mockService.AssertNotCalled(t, "GenerateRoadmap")
+}
+
+func TestServer_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected codes.Code
+		reason   string
+	}{
+		{name: "quota", err: apperror.New(apperror.KindUpstreamQuota, apperror.CodeUpstreamQuota, "quota"), expected: codes.ResourceExhausted, reason: apperror.CodeUpstreamQuota},
+		{name: "indisponível", err: apperror.New(apperror.KindUpstreamUnavailable, apperror.CodeUpstreamUnavailable, "down"), expected: codes.Unavailable, reason: apperror.CodeUpstreamUnavailable},
+		{name: "timeout", err: context.DeadlineExceeded, expected: codes.DeadlineExceeded, reason: apperror.CodeTimeout},
+		{name: "saída inválida", err: apperror.New(apperror.KindOutputInvalid, apperror.CodeOutputInvalid, "json"), expected: codes.Internal, reason: apperror.CodeOutputInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockGeminiService)
+			mockService.On("GenerateKeyResults", mock.Anything, "Crescer", 5, (*string)(nil), "es").Return(nil, tt.err)
+
+			client := startServer(t, NewServer(mockService))
+
+			_, err := client.GenerateKeyResults(context.Background(), &spellbookv1.KeyResultsRequest{Objective: "Crescer", Language: "es"})
+
+			st, info := errorInfo(t, err)
+			assert.Equal(t, tt.expected, st.Code())
+			assert.Equal(t, tt.reason, info.GetReason())
+			assert.NotEmpty(t, st.Message())
+		})
+	}
+}
+
+func TestServer_StreamEducationalTrail(t *testing.T) {
+	mockService := new(MockGeminiService)
+	mockService.On("GenerateEducationalTrail", mock.Anything, "SQL", (*int)(nil), "pt-BR").
+		After(60*time.Millisecond).
+		Return(&models.EducationalTrail{
+			Topic:     "SQL",
+			TotalDays: 1,
+			Steps:     []models.EducationalTrailStep{{Day: 1, Title: "Dia 1"}},
+			Resources: map[string]models.EducationalResource{"resource_1": {Title: "SQL Antipatterns"}},
+		}, nil)
+
+	server := NewServer(mockService)
+	server.ProgressInterval = 10 * time.Millisecond
+	client := startServer(t, server)
+
+	stream, err := client.StreamEducationalTrail(context.Background(), &spellbookv1.EducationalTrailRequest{Topic: "SQL"})
+	require.NoError(t, err)
+
+	var progress int
+	var result *spellbookv1.EducationalTrail
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		if event.GetProgress() != nil {
+			progress++
+		}
+		if event.GetResult() != nil {
+			result = event.GetResult()
+		}
+	}
+
+	assert.Greater(t, progress, 0)
+	require.NotNil(t, result)
+	assert.Equal(t, "SQL Antipatterns", result.GetResources()["resource_1"].GetTitle())
+	assert.Equal(t, int32(1), result.GetSteps()[0].GetDay())
+}
+
+func TestServer_StreamRoadmap_Error(t *testing.T) {
+	mockService := new(MockGeminiService)
+	mockService.On("GenerateRoadmap", mock.Anything, "Go", (*int)(nil), (*int)(nil), "pt-BR").
+		Return(nil, apperror.New(apperror.KindUpstreamQuota, apperror.CodeUpstreamQuota, "quota"))
+
+	client := startServer(t, NewServer(mockService))
+
+	stream, err := client.StreamRoadmap(context.Background(), &spellbookv1.RoadmapRequest{Topic: "Go"})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -0,0 +1,124 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/spellbook/spellbook/pkg/pb/spellbookv1"
+)
+
+// StreamRoadmap gera um roadmap enviando eventos de progresso até o resultado
+func (s *Server) StreamRoadmap(req *spellbookv1.RoadmapRequest, stream spellbookv1.SpellbookService_StreamRoadmapServer) error {
+	ctx := stream.Context()
+	lang, err := s.prepare(ctx, "/api/v1/roadmap", req, req.GetLanguage())
+	if err != nil {
+		return err
+	}
+
+	return streamGeneration(ctx, s.ProgressInterval, lang,
+		func(ctx context.Context) (*spellbookv1.Roadmap, error) {
+			roadmap, err := s.Service.GenerateRoadmap(ctx, req.GetTopic(), intPtr(req.AvailableDays), intPtr(req.ExactItemCount), lang)
+			if err != nil {
+				return nil, err
+			}
+			return toRoadmap(roadmap), nil
+		},
+		func(progress *spellbookv1.Progress) error {
+			return stream.Send(&spellbookv1.RoadmapEvent{Event: &spellbookv1.RoadmapEvent_Progress{Progress: progress}})
+		},
+		func(result *spellbookv1.Roadmap) error {
+			return stream.Send(&spellbookv1.RoadmapEvent{Event: &spellbookv1.RoadmapEvent_Result{Result: result}})
+		},
+	)
+}
+
+// StreamEducationalRoadmap gera um roadmap educacional enviando eventos de progresso até o resultado
+func (s *Server) StreamEducationalRoadmap(req *spellbookv1.EducationalRoadmapRequest, stream spellbookv1.SpellbookService_StreamEducationalRoadmapServer) error {
+	ctx := stream.Context()
+	lang, err := s.prepare(ctx, "/api/v1/educational-roadmap", req, req.GetLanguage())
+	if err != nil {
+		return err
+	}
+
+	return streamGeneration(ctx, s.ProgressInterval, lang,
+		func(ctx context.Context) (*spellbookv1.EducationalRoadmap, error) {
+			roadmap, err := s.Service.GenerateEducationalRoadmap(ctx, req.GetTopic(), lang)
+			if err != nil {
+				return nil, err
+			}
+			return toEducationalRoadmap(roadmap), nil
+		},
+		func(progress *spellbookv1.Progress) error {
+			return stream.Send(&spellbookv1.EducationalRoadmapEvent{Event: &spellbookv1.EducationalRoadmapEvent_Progress{Progress: progress}})
+		},
+		func(result *spellbookv1.EducationalRoadmap) error {
+			return stream.Send(&spellbookv1.EducationalRoadmapEvent{Event: &spellbookv1.EducationalRoadmapEvent_Result{Result: result}})
+		},
+	)
+}
+
+// StreamEducationalTrail gera uma trilha educacional enviando eventos de progresso até o resultado
+func (s *Server) StreamEducationalTrail(req *spellbookv1.EducationalTrailRequest, stream spellbookv1.SpellbookService_StreamEducationalTrailServer) error {
+	ctx := stream.Context()
+	lang, err := s.prepare(ctx, "/api/v1/educational-trail", req, req.GetLanguage())
+	if err != nil {
+		return err
+	}
+
+	return streamGeneration(ctx, s.ProgressInterval, lang,
+		func(ctx context.Context) (*spellbookv1.EducationalTrail, error) {
+			trail, err := s.Service.GenerateEducationalTrail(ctx, req.GetTopic(), intPtr(req.AvailableDays), lang)
+			if err != nil {
+				return nil, err
+			}
+			return toEducationalTrail(trail), nil
+		},
+		func(progress *spellbookv1.Progress) error {
+			return stream.Send(&spellbookv1.EducationalTrailEvent{Event: &spellbookv1.EducationalTrailEvent_Progress{Progress: progress}})
+		},
+		func(result *spellbookv1.EducationalTrail) error {
+			return stream.Send(&spellbookv1.EducationalTrailEvent{Event: &spellbookv1.EducationalTrailEvent_Result{Result: result}})
+		},
+	)
+}
+
+// streamGeneration executa generate em segundo plano, chamando sendProgress a cada interval
+// enquanto a geração não termina e sendResult com o resultado
+func streamGeneration[T any](ctx context.Context, interval time.Duration, lang string,
+	generate func(context.Context) (T, error),
+	sendProgress func(*spellbookv1.Progress) error,
+	sendResult func(T) error,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type outcome struct {
+		result T
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := generate(ctx)
+		done <- outcome{result: result, err: err}
+	}()
+
+	start := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case out := <-done:
+			if out.err != nil {
+				return statusError(out.err, lang)
+			}
+			return sendResult(out.result)
+
+		case <-ticker.C:
+			// Erro ao enviar indica que o cliente desconectou: cancela a geração
+			if err := sendProgress(&spellbookv1.Progress{ElapsedMs: time.Since(start).Milliseconds()}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/services"
+)
+
+// KeyUsageSource informa o uso das API keys do Gemini (implementado por services.KeyPool)
+type KeyUsageSource interface {
+	Usage() []services.KeyUsage
+}
+
+// AdminHandler atende os endpoints de administração, protegidos por middleware.AdminAuth
+type AdminHandler struct {
+	Keys KeyUsageSource
+}
+
+// NewAdminHandler cria uma nova instância do handler de administração
+func NewAdminHandler(keys KeyUsageSource) *AdminHandler {
+	return &AdminHandler{Keys: keys}
+}
+
+// KeyUsage lista as API keys do Gemini (identificadas pelos últimos caracteres) com estado e uso
+func (h *AdminHandler) KeyUsage(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"keys": h.Keys.Usage()})
+}
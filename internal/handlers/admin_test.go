@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminHandler_KeyUsage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	pool := services.NewKeyPool("chave-aaaa", "chave-bbbb")
+	_, _ = pool.Acquire()
+	pool.Report("chave-bbbb", apperror.New(apperror.KindUnauthorized, apperror.CodeUpstreamRejected, "recusada"), 0)
+
+	router := gin.New()
+	router.GET("/admin/keys", NewAdminHandler(pool).KeyUsage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/admin/keys", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "chave-aaaa")
+
+	var response struct {
+		Keys []services.KeyUsage `json:"keys"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Keys, 2)
+	assert.Equal(t, "…aaaa", response.Keys[0].ID)
+	assert.Equal(t, services.KeyActive, response.Keys[0].Status)
+	assert.Equal(t, 1, response.Keys[0].Requests)
+	assert.Equal(t, services.KeyRevoked, response.Keys[1].Status)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/batch"
+	"github.com/spellbook/spellbook/internal/middleware"
+	"github.com/spellbook/spellbook/internal/services"
+)
+
+// BatchHandler gerencia as gerações em lote e os jobs assíncronos
+type BatchHandler struct {
+	Runner *batch.Runner
+	Jobs   *batch.JobStore
+
+	running sync.WaitGroup
+	// stopped é cancelado por Shutdown quando o prazo para concluir os jobs termina
+	stopped context.Context
+	stop    context.CancelFunc
+}
+
+// NewBatchHandler cria uma nova instância do handler de lotes
+func NewBatchHandler(geminiService services.GeminiServiceInterface) *BatchHandler {
+	stopped, stop := context.WithCancel(context.Background())
+	return &BatchHandler{
+		Runner:  batch.NewRunner(geminiService),
+		Jobs:    batch.NewJobStore(),
+		stopped: stopped,
+		stop:    stop,
+	}
+}
+
+// Shutdown aguarda os jobs assíncronos em andamento. Se o contexto terminar antes,
+// cancela os jobs restantes e retorna o erro do contexto.
+func (h *BatchHandler) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		h.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		h.stop()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// RunBatch executa um lote de gerações. Com async, responde 202 com o job e executa em segundo plano
+func (h *BatchHandler) RunBatch(c *gin.Context) {
+	var req batch.Request
+
+	// O corpo já foi validado contra o schema pelo middleware de validação
+	err := c.ShouldBindJSON(&req)
+	lang := responseLanguage(c, req.Language)
+
+	if err != nil {
+		apperror.Respond(c, lang, validationError(apperror.CodeRequestInvalid))
+		return
+	}
+
+	// Cada item conta como uma requisição no limite do cliente; a própria requisição já
+	// foi cobrada pelo middleware
+	if !middleware.ChargeRateLimit(c, len(req.Items)-1) {
+		return
+	}
+
+	if req.Async {
+		job := h.Jobs.Create(len(req.Items))
+
+		// O job continua após o fim da requisição, mas mantém request ID e trace nos logs;
+		// só é cancelado se o encerramento do servidor estourar o prazo
+		ctx, cancel := context.WithCancel(context.WithoutCancel(c.Request.Context()))
+		stopJob := context.AfterFunc(h.stopped, cancel)
+
+		h.running.Add(1)
+		go func() {
+			defer h.running.Done()
+			defer stopJob()
+			defer cancel()
+
+			h.Jobs.Start(job.ID)
+			h.Jobs.Complete(job.ID, h.Runner.Run(ctx, req.Items, lang))
+		}()
+
+		c.Header("Location", "/api/v1/jobs/"+job.ID)
+		c.JSON(http.StatusAccepted, job)
+		return
+	}
+
+	c.JSON(http.StatusOK, batch.Response{Results: h.Runner.Run(c.Request.Context(), req.Items, lang)})
+}
+
+// GetJob retorna o estado e, quando concluído, os resultados de um job
+func (h *BatchHandler) GetJob(c *gin.Context) {
+	lang := responseLanguage(c, "")
+
+	job, ok := h.Jobs.Get(c.Param("id"))
+	if !ok {
+		apperror.Respond(c, lang, apperror.New(apperror.KindNotFound, apperror.CodeJobNotFound, "job não encontrado"))
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
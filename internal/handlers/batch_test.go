@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/batch"
+	"github.com/spellbook/spellbook/internal/middleware"
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBatchRouter(handler *BatchHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/batch", handler.RunBatch)
+	router.GET("/api/v1/jobs/:id", handler.GetJob)
+	return router
+}
+
+func TestBatchHandler_RunBatch_Sync(t *testing.T) {
+	mockService := new(MockGeminiServiceTopics)
+	mockService.On("GenerateTopics", mock.Anything, "Go", 3, "en").
+		Return(&models.TopicsResponse{Subject: "Go", Topics: []string{"a", "b", "c"}}, nil)
+	mockService.On("GenerateTopics", mock.Anything, "Rust", 10, "en").
+		Return(nil, apperror.New(apperror.KindUpstreamUnavailable, apperror.CodeUpstreamUnavailable, "API indisponível"))
+
+	router := setupBatchRouter(NewBatchHandler(mockService))
+
+	body := `{"language": "en", "items": [
+		{"type": "topics", "request": {"subject": "Go", "count": 3}},
+		{"type": "topics", "request": {"subject": "Rust"}}
+	]}`
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Results []struct {
+			Index  int               `json:"index"`
+			Status int               `json:"status"`
+			Result json.RawMessage   `json:"result"`
+			Error  *apperror.Problem `json:"error"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Results, 2)
+
+	assert.Equal(t, http.StatusOK, response.Results[0].Status)
+	assert.Nil(t, response.Results[0].Error)
+	assert.Contains(t, string(response.Results[0].Result), `"Go"`)
+
+	assert.Equal(t, http.StatusServiceUnavailable, response.Results[1].Status)
+	require.NotNil(t, response.Results[1].Error)
+	assert.Equal(t, apperror.CodeUpstreamUnavailable, response.Results[1].Error.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestBatchHandler_RunBatch_ChargesRateLimitPerItem(t *testing.T) {
+	mockService := new(MockGeminiServiceTopics)
+	mockService.On("GenerateTopics", mock.Anything, mock.Anything, 10, "pt-BR").
+		Return(&models.TopicsResponse{Subject: "Go"}, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/batch", middleware.NewRateLimiter(0.001, 4).Middleware(), NewBatchHandler(mockService).RunBatch)
+	send := func(subjects ...string) int {
+		items := make([]string, len(subjects))
+		for i, subject := range subjects {
+			items[i] = `{"type": "topics", "request": {"subject": "` + subject + `"}}`
+		}
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/batch", bytes.NewBufferString(`{"items": [`+strings.Join(items, ",")+`]}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Rajada de 4: o lote de 2 itens cobra 2; o de 3 é recusado e cobra só a requisição
+	assert.Equal(t, http.StatusOK, send("Go", "Rust"))
+	assert.Equal(t, http.StatusTooManyRequests, send("Zig", "Odin", "Nim"))
+	assert.Equal(t, http.StatusOK, send("Elixir"))
+
+	mockService.AssertNumberOfCalls(t, "GenerateTopics", 3)
+}
+
+func TestBatchHandler_RunBatch_Async(t *testing.T) {
+	mockService := new(MockGeminiServiceTopics)
+	mockService.On("GenerateTopics", mock.Anything, "Go", 10, "pt-BR").
+		Return(&models.TopicsResponse{Subject: "Go", Topics: []string{"a"}}, nil)
+
+	router := setupBatchRouter(NewBatchHandler(mockService))
+
+	body := `{"async": true, "items": [{"type": "topics", "request": {"subject": "Go"}}]}`
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	var job batch.Job
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+	assert.NotEmpty(t, job.ID)
+	assert.Equal(t, 1, job.Total)
+	assert.Equal(t, "/api/v1/jobs/"+job.ID, w.Header().Get("Location"))
+
+	// Consulta o job até a conclusão
+	assert.Eventually(t, func() bool {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/jobs/"+job.ID, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			return false
+		}
+		var current batch.Job
+		if err := json.Unmarshal(w.Body.Bytes(), &current); err != nil {
+			return false
+		}
+		return current.Status == batch.JobCompleted && len(current.Results) == 1 && current.Results[0].Status == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestBatchHandler_GetJob_NotFound(t *testing.T) {
+	router := setupBatchRouter(NewBatchHandler(new(MockGeminiServiceTopics)))
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/jobs/inexistente", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, apperror.ContentType, w.Header().Get("Content-Type"))
+
+	var problem apperror.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, apperror.CodeJobNotFound, problem.Code)
+}
+
+func TestBatchHandler_Shutdown_WaitsForJobs(t *testing.T) {
+	release := make(chan struct{})
+	mockService := new(MockGeminiServiceTopics)
+	mockService.On("GenerateTopics", mock.Anything, "Go", 10, "pt-BR").
+		Return(&models.TopicsResponse{Subject: "Go", Topics: []string{"a"}}, nil).
+		Run(func(mock.Arguments) { <-release })
+
+	handler := NewBatchHandler(mockService)
+	router := setupBatchRouter(handler)
+
+	body := `{"async": true, "items": [{"type": "topics", "request": {"subject": "Go"}}]}`
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	var job batch.Job
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+
+	// Libera a geração depois que o encerramento já começou
+	time.AfterFunc(50*time.Millisecond, func() { close(release) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, handler.Shutdown(ctx))
+
+	current, ok := handler.Jobs.Get(job.ID)
+	require.True(t, ok)
+	assert.Equal(t, batch.JobCompleted, current.Status)
+	assert.Equal(t, http.StatusOK, current.Results[0].Status)
+}
+
+func TestBatchHandler_Shutdown_CancelsJobsAfterDeadline(t *testing.T) {
+	mockService := new(MockGeminiServiceTopics)
+	mockService.On("GenerateTopics", mock.Anything, "Go", 10, "pt-BR").
+		Return(nil, apperror.New(apperror.KindTimeout, apperror.CodeTimeout, "geração cancelada")).
+		Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		})
+
+	handler := NewBatchHandler(mockService)
+	router := setupBatchRouter(handler)
+
+	body := `{"async": true, "items": [{"type": "topics", "request": {"subject": "Go"}}]}`
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	var job batch.Job
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, handler.Shutdown(ctx), context.DeadlineExceeded)
+
+	current, ok := handler.Jobs.Get(job.ID)
+	require.True(t, ok)
+	assert.Equal(t, batch.JobCompleted, current.Status)
+	assert.Equal(t, http.StatusGatewayTimeout, current.Results[0].Status)
+}
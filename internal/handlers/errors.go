@@ -0,0 +1,11 @@
+package handlers
+
+import (
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/i18n"
+)
+
+// validationError cria o erro de validação com a mensagem do código no idioma padrão
+func validationError(code string) error {
+	return apperror.New(apperror.KindValidation, code, i18n.Message(i18n.DefaultLanguage, code))
+}
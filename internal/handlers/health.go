@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/health"
+)
+
+// ModelCatalogChecker verifica se o catálogo de modelos está acessível (implementado por GeminiService)
+type ModelCatalogChecker interface {
+	CheckModels(ctx context.Context) error
+}
+
+// HealthHandler atende /health (o processo está no ar), /ready (pode receber tráfego) e
+// /health/deep (estado detalhado de cada dependência)
+type HealthHandler struct {
+	Models ModelCatalogChecker
+	Deep   *health.Checker
+	// CheckTimeout limita o tempo da verificação do catálogo de modelos
+	CheckTimeout time.Duration
+
+	draining atomic.Bool
+}
+
+// NewHealthHandler cria uma nova instância do handler de health check
+func NewHealthHandler(models ModelCatalogChecker, deep *health.Checker) *HealthHandler {
+	return &HealthHandler{
+		Models:       models,
+		Deep:         deep,
+		CheckTimeout: 5 * time.Second,
+	}
+}
+
+// Health indica que o processo está no ar, sem verificar dependências
+func (h *HealthHandler) Health(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"service": "spellbook",
+	})
+}
+
+// Ready indica se a instância pode receber tráfego: responde 503 durante o encerramento
+// e quando o catálogo de modelos do Gemini não está acessível
+func (h *HealthHandler) Ready(c *gin.Context) {
+	if h.draining.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":  "draining",
+			"service": "spellbook",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.CheckTimeout)
+	defer cancel()
+
+	if err := h.Models.CheckModels(ctx); err != nil {
+		slog.WarnContext(ctx, "readiness: catálogo de modelos indisponível", "error", err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":  "unavailable",
+			"service": "spellbook",
+			"checks":  gin.H{"models": "unavailable"},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ready",
+		"service": "spellbook",
+		"checks":  gin.H{"models": "ok"},
+	})
+}
+
+// DeepHealth verifica cada dependência e responde com o estado por componente.
+// Responde 503 quando algum componente está unhealthy e 200 nos demais casos.
+func (h *HealthHandler) DeepHealth(c *gin.Context) {
+	report := h.Deep.Run(c.Request.Context())
+
+	status := http.StatusOK
+	if report.Status == health.StatusUnhealthy {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
+
+// SetDraining marca a instância como em encerramento: /ready passa a responder 503
+func (h *HealthHandler) SetDraining() {
+	h.draining.Store(true)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeModelChecker struct {
+	err   error
+	calls int
+}
+
+func (f *fakeModelChecker) CheckModels(ctx context.Context) error {
+	f.calls++
+	return f.err
+}
+
+func performHealthRequest(handler *HealthHandler, path string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/health", handler.Health)
+	router.GET("/ready", handler.Ready)
+	router.GET("/health/deep", handler.DeepHealth)
+
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func decodeStatus(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return body
+}
+
+func TestHealthHandler_Health_DoesNotCheckModels(t *testing.T) {
+	checker := &fakeModelChecker{err: apperror.ErrUpstreamUnavailable}
+	w := performHealthRequest(NewHealthHandler(checker, health.NewChecker()), "/health")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "ok", decodeStatus(t, w)["status"])
+	assert.Zero(t, checker.calls)
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		draining   bool
+		wantCode   int
+		wantStatus string
+		wantCalls  int
+	}{
+		{"catálogo acessível", nil, false, http.StatusOK, "ready", 1},
+		{"catálogo inacessível", apperror.ErrUpstreamUnavailable, false, http.StatusServiceUnavailable, "unavailable", 1},
+		{"em encerramento", nil, true, http.StatusServiceUnavailable, "draining", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := &fakeModelChecker{err: tt.err}
+			handler := NewHealthHandler(checker, health.NewChecker())
+			if tt.draining {
+				handler.SetDraining()
+			}
+
+			w := performHealthRequest(handler, "/ready")
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantStatus, decodeStatus(t, w)["status"])
+			assert.Equal(t, tt.wantCalls, checker.calls)
+		})
+	}
+}
+
+func TestHealthHandler_DeepHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   health.Status
+		wantCode int
+	}{
+		{"healthy", health.StatusHealthy, http.StatusOK},
+		{"degraded", health.StatusDegraded, http.StatusOK},
+		{"unhealthy", health.StatusUnhealthy, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deep := health.NewChecker()
+			deep.Register("provider", func(context.Context) map[string]health.Component {
+				return map[string]health.Component{"provider": {Status: tt.status}}
+			})
+
+			w := performHealthRequest(NewHealthHandler(&fakeModelChecker{}, deep), "/health/deep")
+
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			var report health.Report
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+			assert.Equal(t, tt.status, report.Status)
+			assert.Equal(t, tt.status, report.Components["provider"].Status)
+		})
+	}
+}
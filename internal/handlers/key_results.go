@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
 	"github.com/spellbook/spellbook/internal/models"
 	"github.com/spellbook/spellbook/internal/services"
 )
@@ -24,17 +25,17 @@ func NewKeyResultsHandler(geminiService services.GeminiServiceInterface) *KeyRes
 func (h *KeyResultsHandler) GenerateKeyResults(c *gin.Context) {
 	var req models.KeyResultsRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "objetivo é obrigatório",
-		})
+	// O campo language é preenchido mesmo quando a validação falha
+	err := c.ShouldBindJSON(&req)
+	lang := responseLanguage(c, req.Language)
+
+	if err != nil {
+		apperror.Respond(c, lang, validationError(apperror.CodeObjectiveRequired))
 		return
 	}
 
 	if req.Objective == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "objetivo não pode ser vazio",
-		})
+		apperror.Respond(c, lang, validationError(apperror.CodeObjectiveEmpty))
 		return
 	}
 
@@ -43,19 +44,9 @@ func (h *KeyResultsHandler) GenerateKeyResults(c *gin.Context) {
 		req.Count = 5
 	}
 
-	keyResults, err := h.GeminiService.GenerateKeyResults(req.Objective, req.Count, req.CompletionDate)
+	keyResults, err := h.GeminiService.GenerateKeyResults(c.Request.Context(), req.Objective, req.Count, req.CompletionDate, lang)
 	if err != nil {
-		// Verificar se é erro de API key
-		if err.Error() == "GEMINI_API_KEY não configurada. Configure no arquivo .env ou variável de ambiente" {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "API key do Gemini não configurada",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		apperror.Respond(c, lang, err)
 		return
 	}
 
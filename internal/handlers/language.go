@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/i18n"
+)
+
+// responseLanguage resolve o idioma da resposta a partir do campo language da requisição
+// e do header Accept-Language, e o informa ao cliente no header Content-Language
+func responseLanguage(c *gin.Context, requested string) string {
+	lang := i18n.Resolve(requested, c.GetHeader("Accept-Language"))
+	c.Header("Content-Language", lang)
+	return lang
+}
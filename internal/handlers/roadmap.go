@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
 	"github.com/spellbook/spellbook/internal/models"
 	"github.com/spellbook/spellbook/internal/services"
 )
@@ -24,33 +25,23 @@ func NewRoadmapHandler(geminiService services.GeminiServiceInterface) *RoadmapHa
 func (h *RoadmapHandler) GenerateRoadmap(c *gin.Context) {
 	var req models.RoadmapRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "tópico é obrigatório",
-		})
+	// O campo language é preenchido mesmo quando a validação falha
+	err := c.ShouldBindJSON(&req)
+	lang := responseLanguage(c, req.Language)
+
+	if err != nil {
+		apperror.Respond(c, lang, validationError(apperror.CodeTopicRequired))
 		return
 	}
 
 	if req.Topic == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "tópico não pode ser vazio",
-		})
+		apperror.Respond(c, lang, validationError(apperror.CodeTopicEmpty))
 		return
 	}
 
-	roadmap, err := h.GeminiService.GenerateRoadmap(req.Topic, req.AvailableDays, req.ExactItemCount)
+	roadmap, err := h.GeminiService.GenerateRoadmap(c.Request.Context(), req.Topic, req.AvailableDays, req.ExactItemCount, lang)
 	if err != nil {
-		// Verificar se é erro de API key
-		if err.Error() == "GEMINI_API_KEY não configurada. Configure no arquivo .env ou variável de ambiente" {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "API key do Gemini não configurada",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		apperror.Respond(c, lang, err)
 		return
 	}
 
@@ -61,33 +52,23 @@ func (h *RoadmapHandler) GenerateRoadmap(c *gin.Context) {
 func (h *RoadmapHandler) GenerateEducationalRoadmap(c *gin.Context) {
 	var req models.EducationalRoadmapRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "tópico é obrigatório",
-		})
+	// O campo language é preenchido mesmo quando a validação falha
+	err := c.ShouldBindJSON(&req)
+	lang := responseLanguage(c, req.Language)
+
+	if err != nil {
+		apperror.Respond(c, lang, validationError(apperror.CodeTopicRequired))
 		return
 	}
 
 	if req.Topic == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "tópico não pode ser vazio",
-		})
+		apperror.Respond(c, lang, validationError(apperror.CodeTopicEmpty))
 		return
 	}
 
-	educationalRoadmap, err := h.GeminiService.GenerateEducationalRoadmap(req.Topic)
+	educationalRoadmap, err := h.GeminiService.GenerateEducationalRoadmap(c.Request.Context(), req.Topic, lang)
 	if err != nil {
-		// Verificar se é erro de API key
-		if err.Error() == "GEMINI_API_KEY não configurada. Configure no arquivo .env ou variável de ambiente" {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "API key do Gemini não configurada",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		apperror.Respond(c, lang, err)
 		return
 	}
 
@@ -98,32 +79,23 @@ func (h *RoadmapHandler) GenerateEducationalRoadmap(c *gin.Context) {
 func (h *RoadmapHandler) GenerateEducationalTrail(c *gin.Context) {
 	var req models.EducationalTrailRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "tópico é obrigatório",
-		})
+	// O campo language é preenchido mesmo quando a validação falha
+	err := c.ShouldBindJSON(&req)
+	lang := responseLanguage(c, req.Language)
+
+	if err != nil {
+		apperror.Respond(c, lang, validationError(apperror.CodeTopicRequired))
 		return
 	}
 
 	if req.Topic == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "tópico não pode ser vazio",
-		})
+		apperror.Respond(c, lang, validationError(apperror.CodeTopicEmpty))
 		return
 	}
 
-	trail, err := h.GeminiService.GenerateEducationalTrail(req.Topic, req.AvailableDays)
+	trail, err := h.GeminiService.GenerateEducationalTrail(c.Request.Context(), req.Topic, req.AvailableDays, lang)
 	if err != nil {
-		if err.Error() == "GEMINI_API_KEY não configurada. Configure no arquivo .env ou variável de ambiente" {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "API key do Gemini não configurada",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		apperror.Respond(c, lang, err)
 		return
 	}
 
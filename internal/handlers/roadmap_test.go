@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -22,30 +23,46 @@ type MockGeminiService struct {
 	mock.Mock
 }
 
-func (m *MockGeminiService) GenerateRoadmap(topic string) (*models.Roadmap, error) {
-	args := m.Called(topic)
+func (m *MockGeminiService) GenerateRoadmap(ctx context.Context, topic string, availableDays *int, exactItemCount *int, language string) (*models.Roadmap, error) {
+	args := m.Called(ctx, topic, availableDays, exactItemCount, language)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.Roadmap), args.Error(1)
 }
 
-func (m *MockGeminiService) GenerateTopics(subject string, count int) (*models.TopicsResponse, error) {
-	args := m.Called(subject, count)
+func (m *MockGeminiService) GenerateTopics(ctx context.Context, subject string, count int, language string) (*models.TopicsResponse, error) {
+	args := m.Called(ctx, subject, count, language)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.TopicsResponse), args.Error(1)
 }
 
-func (m *MockGeminiService) GenerateEducationalRoadmap(topic string) (*models.EducationalRoadmap, error) {
-	args := m.Called(topic)
+func (m *MockGeminiService) GenerateKeyResults(ctx context.Context, objective string, count int, completionDate *string, language string) (*models.KeyResultsResponse, error) {
+	args := m.Called(ctx, objective, count, completionDate, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.KeyResultsResponse), args.Error(1)
+}
+
+func (m *MockGeminiService) GenerateEducationalRoadmap(ctx context.Context, topic string, language string) (*models.EducationalRoadmap, error) {
+	args := m.Called(ctx, topic, language)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.EducationalRoadmap), args.Error(1)
 }
 
+func (m *MockGeminiService) GenerateEducationalTrail(ctx context.Context, topic string, availableDays *int, language string) (*models.EducationalTrail, error) {
+	args := m.Called(ctx, topic, availableDays, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EducationalTrail), args.Error(1)
+}
+
 func TestRoadmapHandler_GenerateRoadmap_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -64,7 +81,7 @@ func TestRoadmapHandler_GenerateRoadmap_Success(t *testing.T) {
 		},
 	}
 
-	mockService.On("GenerateRoadmap", "Machine Learning").Return(expectedRoadmap, nil)
+	mockService.On("GenerateRoadmap", mock.Anything, "Machine Learning", (*int)(nil), (*int)(nil), "pt-BR").Return(expectedRoadmap, nil)
 
 	router := gin.New()
 	router.POST("/roadmap", handler.GenerateRoadmap)
@@ -115,7 +132,7 @@ func TestRoadmapHandler_GenerateRoadmap_ServiceError(t *testing.T) {
 	mockService := new(MockGeminiService)
 	handler := &RoadmapHandler{GeminiService: mockService}
 
-	mockService.On("GenerateRoadmap", "Test").Return(nil, assert.AnError)
+	mockService.On("GenerateRoadmap", mock.Anything, "Test", (*int)(nil), (*int)(nil), "pt-BR").Return(nil, assert.AnError)
 
 	router := gin.New()
 	router.POST("/roadmap", handler.GenerateRoadmap)
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/spells"
+)
+
+// SpellGenerator executa os spells (implementado por services.GeminiService)
+type SpellGenerator interface {
+	GenerateSpell(ctx context.Context, spell *spells.Spell, input map[string]interface{}, language string) (map[string]interface{}, error)
+}
+
+// SpellsHandler atende as gerações definidas em arquivos de configuração
+type SpellsHandler struct {
+	Spells    *spells.Registry
+	Generator SpellGenerator
+}
+
+// NewSpellsHandler cria uma nova instância do handler de spells
+func NewSpellsHandler(registry *spells.Registry, generator SpellGenerator) *SpellsHandler {
+	return &SpellsHandler{Spells: registry, Generator: generator}
+}
+
+// ListSpells lista os spells carregados, com os schemas de requisição e resposta
+func (h *SpellsHandler) ListSpells(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"spells": h.Spells.List()})
+}
+
+// CastSpell valida o corpo contra o schema do spell e executa a geração
+func (h *SpellsHandler) CastSpell(c *gin.Context) {
+	var input map[string]interface{}
+	bindErr := json.NewDecoder(c.Request.Body).Decode(&input)
+
+	// language é reservado, como nos endpoints embutidos, e não faz parte do schema do spell
+	requested, _ := input["language"].(string)
+	delete(input, "language")
+	lang := responseLanguage(c, requested)
+
+	spell, ok := h.Spells.Get(c.Param("name"))
+	if !ok {
+		apperror.Respond(c, lang, apperror.New(apperror.KindNotFound, apperror.CodeSpellNotFound, "spell não encontrado"))
+		return
+	}
+
+	var fields []apperror.FieldError
+	if bindErr != nil || input == nil {
+		fields = []apperror.FieldError{{Field: "", Message: "corpo JSON inválido"}}
+	} else {
+		fields = spell.ValidateRequest(input)
+	}
+	if len(fields) > 0 {
+		appErr := apperror.New(apperror.KindValidation, apperror.CodeRequestInvalid, "requisição não segue o schema do spell")
+		appErr.Fields = fields
+		apperror.Respond(c, lang, appErr)
+		return
+	}
+
+	output, err := h.Generator.GenerateSpell(c.Request.Context(), spell, input, lang)
+	if err != nil {
+		apperror.Respond(c, lang, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/spells"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockSpellGenerator struct {
+	mock.Mock
+}
+
+func (m *MockSpellGenerator) GenerateSpell(ctx context.Context, spell *spells.Spell, input map[string]interface{}, language string) (map[string]interface{}, error) {
+	args := m.Called(ctx, spell.Name, input, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]interface{}), args.Error(1)
+}
+
+func setupSpellsRouter(t *testing.T, generator SpellGenerator) *gin.Engine {
+	spell, err := spells.Parse("glossary.yaml", []byte(`
+description: Glossário
+request:
+  type: object
+  required: [topic]
+  properties:
+    topic: {type: string, minLength: 1}
+prompt: Termos sobre {{.topic}}
+response:
+  type: object
+  required: [terms]
+`))
+	require.NoError(t, err)
+	registry, err := spells.NewRegistry(spell)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewSpellsHandler(registry, generator)
+	router.GET("/api/v1/spells", handler.ListSpells)
+	router.POST("/api/v1/spells/:name", handler.CastSpell)
+	return router
+}
+
+func castSpell(router *gin.Engine, name, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/spells/"+name, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSpellsHandler_CastSpell(t *testing.T) {
+	t.Run("sucesso", func(t *testing.T) {
+		generator := new(MockSpellGenerator)
+		generator.On("GenerateSpell", mock.Anything, "glossary", map[string]interface{}{"topic": "Go"}, "en").
+			Return(map[string]interface{}{"terms": []interface{}{"goroutine"}}, nil)
+		router := setupSpellsRouter(t, generator)
+
+		w := castSpell(router, "glossary", `{"topic": "Go", "language": "en"}`)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"terms": ["goroutine"]}`, w.Body.String())
+		assert.Equal(t, "en", w.Header().Get("Content-Language"))
+		generator.AssertExpectations(t)
+	})
+
+	t.Run("requisição fora do schema", func(t *testing.T) {
+		generator := new(MockSpellGenerator)
+		router := setupSpellsRouter(t, generator)
+
+		w := castSpell(router, "glossary", `{"topic": ""}`)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		var problem apperror.Problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, apperror.CodeRequestInvalid, problem.Code)
+		require.Len(t, problem.Errors, 1)
+		assert.Equal(t, "/topic", problem.Errors[0].Field)
+		generator.AssertNotCalled(t, "GenerateSpell")
+	})
+
+	t.Run("corpo inválido", func(t *testing.T) {
+		w := castSpell(setupSpellsRouter(t, new(MockSpellGenerator)), "glossary", `{"topic":`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("spell inexistente", func(t *testing.T) {
+		w := castSpell(setupSpellsRouter(t, new(MockSpellGenerator)), "quiz", `{"topic": "Go"}`)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		var problem apperror.Problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, apperror.CodeSpellNotFound, problem.Code)
+	})
+
+	t.Run("erro da geração", func(t *testing.T) {
+		generator := new(MockSpellGenerator)
+		generator.On("GenerateSpell", mock.Anything, "glossary", mock.Anything, mock.Anything).
+			Return(nil, apperror.New(apperror.KindOutputInvalid, apperror.CodeOutputInvalid, "inválida"))
+
+		w := castSpell(setupSpellsRouter(t, generator), "glossary", `{"topic": "Go"}`)
+
+		assert.Equal(t, http.StatusBadGateway, w.Code)
+	})
+}
+
+func TestSpellsHandler_ListSpells(t *testing.T) {
+	router := setupSpellsRouter(t, new(MockSpellGenerator))
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/spells", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Spells []struct {
+			Name        string                 `json:"name"`
+			Description string                 `json:"description"`
+			Request     map[string]interface{} `json:"request"`
+		} `json:"spells"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Spells, 1)
+	assert.Equal(t, "glossary", response.Spells[0].Name)
+	assert.Equal(t, []interface{}{"topic"}, response.Spells[0].Request["required"])
+}
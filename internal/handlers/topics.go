@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
 	"github.com/spellbook/spellbook/internal/models"
 	"github.com/spellbook/spellbook/internal/services"
 )
@@ -24,17 +25,17 @@ func NewTopicsHandler(geminiService services.GeminiServiceInterface) *TopicsHand
 func (h *TopicsHandler) GenerateTopics(c *gin.Context) {
 	var req models.TopicsRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "assunto é obrigatório",
-		})
+	// O campo language é preenchido mesmo quando a validação falha
+	err := c.ShouldBindJSON(&req)
+	lang := responseLanguage(c, req.Language)
+
+	if err != nil {
+		apperror.Respond(c, lang, validationError(apperror.CodeSubjectRequired))
 		return
 	}
 
 	if req.Subject == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "assunto não pode ser vazio",
-		})
+		apperror.Respond(c, lang, validationError(apperror.CodeSubjectEmpty))
 		return
 	}
 
@@ -43,19 +44,9 @@ func (h *TopicsHandler) GenerateTopics(c *gin.Context) {
 		req.Count = 10
 	}
 
-	topics, err := h.GeminiService.GenerateTopics(req.Subject, req.Count)
+	topics, err := h.GeminiService.GenerateTopics(c.Request.Context(), req.Subject, req.Count, lang)
 	if err != nil {
-		// Verificar se é erro de API key
-		if err.Error() == "GEMINI_API_KEY não configurada. Configure no arquivo .env ou variável de ambiente" {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "API key do Gemini não configurada",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		apperror.Respond(c, lang, err)
 		return
 	}
 
@@ -2,12 +2,16 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
 	"github.com/spellbook/spellbook/internal/models"
 	"github.com/spellbook/spellbook/internal/services"
 	"github.com/stretchr/testify/assert"
@@ -23,30 +27,46 @@ type MockGeminiServiceTopics struct {
 	mock.Mock
 }
 
-func (m *MockGeminiServiceTopics) GenerateRoadmap(topic string) (*models.Roadmap, error) {
-	args := m.Called(topic)
+func (m *MockGeminiServiceTopics) GenerateRoadmap(ctx context.Context, topic string, availableDays *int, exactItemCount *int, language string) (*models.Roadmap, error) {
+	args := m.Called(ctx, topic, availableDays, exactItemCount, language)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.Roadmap), args.Error(1)
 }
 
-func (m *MockGeminiServiceTopics) GenerateTopics(subject string, count int) (*models.TopicsResponse, error) {
-	args := m.Called(subject, count)
+func (m *MockGeminiServiceTopics) GenerateTopics(ctx context.Context, subject string, count int, language string) (*models.TopicsResponse, error) {
+	args := m.Called(ctx, subject, count, language)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.TopicsResponse), args.Error(1)
 }
 
-func (m *MockGeminiServiceTopics) GenerateEducationalRoadmap(topic string) (*models.EducationalRoadmap, error) {
-	args := m.Called(topic)
+func (m *MockGeminiServiceTopics) GenerateKeyResults(ctx context.Context, objective string, count int, completionDate *string, language string) (*models.KeyResultsResponse, error) {
+	args := m.Called(ctx, objective, count, completionDate, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.KeyResultsResponse), args.Error(1)
+}
+
+func (m *MockGeminiServiceTopics) GenerateEducationalRoadmap(ctx context.Context, topic string, language string) (*models.EducationalRoadmap, error) {
+	args := m.Called(ctx, topic, language)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.EducationalRoadmap), args.Error(1)
 }
 
+func (m *MockGeminiServiceTopics) GenerateEducationalTrail(ctx context.Context, topic string, availableDays *int, language string) (*models.EducationalTrail, error) {
+	args := m.Called(ctx, topic, availableDays, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EducationalTrail), args.Error(1)
+}
+
 func TestTopicsHandler_GenerateTopics_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -58,7 +78,7 @@ func TestTopicsHandler_GenerateTopics_Success(t *testing.T) {
 		Topics:  []string{"OOP", "Decorators", "Context Managers"},
 	}
 
-	mockService.On("GenerateTopics", "Python", 10).Return(expectedTopics, nil)
+	mockService.On("GenerateTopics", mock.Anything, "Python", 10, "pt-BR").Return(expectedTopics, nil)
 
 	router := gin.New()
 	router.POST("/topics", handler.GenerateTopics)
@@ -115,7 +135,7 @@ func TestTopicsHandler_GenerateTopics_DefaultCount(t *testing.T) {
 	}
 
 	// Quando count não é especificado, deve usar 10 como default
-	mockService.On("GenerateTopics", "JavaScript", 10).Return(expectedTopics, nil)
+	mockService.On("GenerateTopics", mock.Anything, "JavaScript", 10, "pt-BR").Return(expectedTopics, nil)
 
 	router := gin.New()
 	router.POST("/topics", handler.GenerateTopics)
@@ -132,3 +152,146 @@ func TestTopicsHandler_GenerateTopics_DefaultCount(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	mockService.AssertExpectations(t)
 }
+
+func TestTopicsHandler_GenerateTopics_LanguageField(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockGeminiServiceTopics)
+	handler := NewTopicsHandler(mockService)
+
+	expectedTopics := &models.TopicsResponse{
+		Subject: "Python",
+		Topics:  []string{"Variables", "Functions"},
+	}
+
+	// O campo language tem prioridade sobre o Accept-Language
+	mockService.On("GenerateTopics", mock.Anything, "Python", 10, "en").Return(expectedTopics, nil)
+
+	router := gin.New()
+	router.POST("/topics", handler.GenerateTopics)
+
+	reqBody := models.TopicsRequest{Subject: "Python", Language: "en-US"}
+	jsonData, _ := json.Marshal(reqBody)
+
+	req, _ := http.NewRequest("POST", "/topics", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "es")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "en", w.Header().Get("Content-Language"))
+	mockService.AssertExpectations(t)
+}
+
+func TestTopicsHandler_GenerateTopics_LocalizedError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{name: "padrão", acceptLanguage: "", expected: "assunto é obrigatório"},
+		{name: "inglês", acceptLanguage: "en-GB,en;q=0.9", expected: "subject is required"},
+		{name: "espanhol", acceptLanguage: "es-AR", expected: "el asunto es obligatorio"},
+		{name: "não suportado", acceptLanguage: "ja", expected: "assunto é obrigatório"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewTopicsHandler(new(MockGeminiServiceTopics))
+
+			router := gin.New()
+			router.POST("/topics", handler.GenerateTopics)
+
+			req, _ := http.NewRequest("POST", "/topics", bytes.NewBufferString(`{"subject": ""}`))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response apperror.Problem
+			json.Unmarshal(w.Body.Bytes(), &response)
+			assert.Equal(t, tt.expected, response.Detail)
+			assert.Equal(t, apperror.CodeSubjectRequired, response.Code)
+		})
+	}
+}
+
+func TestTopicsHandler_GenerateTopics_ErrorMapping(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "quota",
+			err:            apperror.Wrap(apperror.KindUpstreamQuota, apperror.CodeUpstreamQuota, "quota excedida (429)", errors.New("corpo do upstream")),
+			expectedStatus: http.StatusTooManyRequests,
+			expectedCode:   apperror.CodeUpstreamQuota,
+		},
+		{
+			name:           "indisponível",
+			err:            fmt.Errorf("erro ao gerar tópicos: %w", apperror.New(apperror.KindUpstreamUnavailable, apperror.CodeUpstreamUnavailable, "erro da API")),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCode:   apperror.CodeUpstreamUnavailable,
+		},
+		{
+			name:           "resposta inválida",
+			err:            apperror.New(apperror.KindOutputInvalid, apperror.CodeOutputInvalid, "erro ao fazer parse do JSON"),
+			expectedStatus: http.StatusBadGateway,
+			expectedCode:   apperror.CodeOutputInvalid,
+		},
+		{
+			name:           "timeout",
+			err:            context.DeadlineExceeded,
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedCode:   apperror.CodeTimeout,
+		},
+		{
+			name:           "desconhecido",
+			err:            errors.New("erro da API: 500 - corpo do upstream"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   apperror.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockGeminiServiceTopics)
+			handler := NewTopicsHandler(mockService)
+
+			mockService.On("GenerateTopics", mock.Anything, "Python", 10, "pt-BR").Return(nil, tt.err)
+
+			router := gin.New()
+			router.POST("/topics", handler.GenerateTopics)
+
+			req, _ := http.NewRequest("POST", "/topics", bytes.NewBufferString(`{"subject": "Python"}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, apperror.ContentType, w.Header().Get("Content-Type"))
+
+			var response apperror.Problem
+			json.Unmarshal(w.Body.Bytes(), &response)
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.Equal(t, tt.expectedStatus, response.Status)
+			assert.Equal(t, "/topics", response.Instance)
+			// Detalhes do upstream não devem chegar ao cliente
+			assert.NotContains(t, w.Body.String(), "corpo do upstream")
+		})
+	}
+}
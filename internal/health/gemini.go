@@ -0,0 +1,298 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/services"
+)
+
+// GeminiSource é o que o health check consulta no serviço Gemini (implementado por GeminiService)
+type GeminiSource interface {
+	ProbeModels(ctx context.Context) ([]string, error)
+	ModelStats() []services.ModelStat
+	ModelsCache() services.ModelsCacheInfo
+	KeyUsage() []services.KeyUsage
+	CircuitBreakers() []services.BreakerState
+}
+
+// Thresholds define a partir de que taxa de erro recente um modelo fica degraded ou unhealthy
+type Thresholds struct {
+	DegradedErrorRate  float64
+	UnhealthyErrorRate float64
+	// MinCalls é o mínimo de tentativas na janela para avaliar a taxa de erro
+	MinCalls int
+	// QuotaCooldown é por quanto tempo um erro de quota deixa a quota degraded
+	QuotaCooldown time.Duration
+	// ProbeTTL é por quanto tempo o resultado da consulta à lista de modelos é reaproveitado,
+	// para que chamadas frequentes ao health check não consumam a quota da API key
+	ProbeTTL time.Duration
+}
+
+// DefaultThresholds: degraded a partir de 20% de erros, unhealthy a partir de 50%,
+// com pelo menos 5 tentativas; quota degraded por 1 minuto após um 429; consulta à lista de
+// modelos reaproveitada por 30 segundos
+var DefaultThresholds = Thresholds{
+	DegradedErrorRate:  0.2,
+	UnhealthyErrorRate: 0.5,
+	MinCalls:           5,
+	QuotaCooldown:      time.Minute,
+	ProbeTTL:           30 * time.Second,
+}
+
+// RegisterGemini registra as verificações do Gemini: provider (alcance da API), api_key,
+// models_cache, models (taxa de erro recente por modelo), circuit_breakers e quota
+func RegisterGemini(checker *Checker, source GeminiSource, thresholds Thresholds) {
+	probe := &modelsProbe{source: source, ttl: thresholds.ProbeTTL}
+	checker.Register("provider", func(ctx context.Context) map[string]Component {
+		result := probe.get(ctx)
+		provider, apiKey := probeComponents(result.models, result.err, result.latency)
+		provider = probedAt(provider, result.at)
+		apiKey = keysComponent(apiKey, source.KeyUsage())
+
+		// O cache é avaliado depois da consulta, que o renova quando tem sucesso
+		return map[string]Component{
+			"provider":     provider,
+			"api_key":      apiKey,
+			"models_cache": cacheComponent(source.ModelsCache()),
+		}
+	})
+
+	checker.Register("models", func(context.Context) map[string]Component {
+		return map[string]Component{"models": modelsComponent(source.ModelStats(), thresholds)}
+	})
+
+	checker.Register("circuit_breakers", func(context.Context) map[string]Component {
+		return map[string]Component{"circuit_breakers": breakersComponent(source.CircuitBreakers())}
+	})
+
+	checker.Register("quota", func(context.Context) map[string]Component {
+		return map[string]Component{"quota": quotaComponent(source.ModelStats(), thresholds, time.Now())}
+	})
+}
+
+// probeResult é o resultado de uma consulta à lista de modelos
+type probeResult struct {
+	models  []string
+	err     error
+	latency time.Duration
+	at      time.Time
+}
+
+// modelsProbe reaproveita a última consulta à lista de modelos por ttl. Chamadas simultâneas
+// esperam a mesma consulta em vez de repeti-la.
+type modelsProbe struct {
+	source GeminiSource
+	ttl    time.Duration
+
+	mu   sync.Mutex
+	last *probeResult
+}
+
+func (p *modelsProbe) get(ctx context.Context) probeResult {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.last != nil && time.Since(p.last.at) < p.ttl {
+		return *p.last
+	}
+
+	start := time.Now()
+	models, err := p.source.ProbeModels(ctx)
+	result := probeResult{models: models, err: err, latency: time.Since(start), at: start}
+	// Consultas interrompidas pelo prazo do health check não valem para as próximas chamadas
+	if ctx.Err() == nil {
+		p.last = &result
+	}
+	return result
+}
+
+// probedAt informa nos detalhes quando a lista de modelos foi consultada
+func probedAt(component Component, at time.Time) Component {
+	if component.Details != nil {
+		component.Details["probed_at"] = at.UTC()
+	}
+	return component
+}
+
+// probeComponents interpreta a consulta à lista de modelos: a API respondeu (provider) e
+// aceitou a API key (api_key)
+func probeComponents(models []string, err error, latency time.Duration) (provider, apiKey Component) {
+	details := map[string]interface{}{"latency_ms": latency.Milliseconds()}
+
+	if err == nil {
+		details["models"] = len(models)
+		if len(models) == 0 {
+			return Component{Status: StatusDegraded, Message: "nenhum modelo disponível", Details: details},
+				Component{Status: StatusHealthy}
+		}
+		return Component{Status: StatusHealthy, Details: details}, Component{Status: StatusHealthy}
+	}
+
+	appErr := apperror.From(err)
+	switch {
+	case appErr.Code == apperror.CodeAPIKeyMissing:
+		return Component{Status: StatusDegraded, Message: "não verificado: API key não configurada"},
+			Component{Status: StatusUnhealthy, Message: "GEMINI_API_KEY não configurada"}
+	case errors.Is(err, apperror.ErrUnauthorized):
+		return Component{Status: StatusHealthy, Details: details},
+			Component{Status: StatusUnhealthy, Message: "API key recusada pela API"}
+	case errors.Is(err, apperror.ErrUpstreamQuota):
+		return Component{Status: StatusDegraded, Message: "quota excedida ao listar modelos", Details: details},
+			Component{Status: StatusHealthy}
+	default:
+		return Component{Status: StatusUnhealthy, Message: appErr.Message, Details: details},
+			Component{Status: StatusDegraded, Message: "não verificado: API inacessível"}
+	}
+}
+
+// keysComponent completa o componente api_key com o estado das chaves do pool: degraded
+// quando alguma está revogada ou em espera por quota, unhealthy quando todas foram revogadas
+func keysComponent(apiKey Component, usage []services.KeyUsage) Component {
+	if len(usage) == 0 {
+		return apiKey
+	}
+
+	counts := make(map[string]int)
+	for _, key := range usage {
+		counts[key.Status]++
+	}
+	details := map[string]interface{}{
+		"keys":                  len(usage),
+		services.KeyActive:      counts[services.KeyActive],
+		services.KeyCoolingDown: counts[services.KeyCoolingDown],
+		services.KeyRevoked:     counts[services.KeyRevoked],
+	}
+	for name, value := range apiKey.Details {
+		details[name] = value
+	}
+	apiKey.Details = details
+
+	unavailable := counts[services.KeyRevoked] + counts[services.KeyCoolingDown]
+	switch {
+	case counts[services.KeyRevoked] == len(usage):
+		apiKey.Status = StatusUnhealthy
+		apiKey.Message = "todas as API keys foram recusadas pela API"
+	case unavailable > 0 && apiKey.Status == StatusHealthy:
+		apiKey.Status = StatusDegraded
+		apiKey.Message = fmt.Sprintf("%d de %d API keys revogadas ou em espera por quota", unavailable, len(usage))
+	}
+	return apiKey
+}
+
+// modelsComponent avalia a taxa de erro recente de cada modelo. O componente fica unhealthy
+// quando todos os modelos avaliados estão unhealthy (o fallback não tem para onde ir) e
+// degraded quando algum deles está degraded ou unhealthy.
+func modelsComponent(stats []services.ModelStat, thresholds Thresholds) Component {
+	perModel := make(map[string]interface{}, len(stats))
+	evaluated, unhealthy := 0, 0
+	worst := StatusHealthy
+
+	for _, stat := range stats {
+		status := StatusHealthy
+		if stat.Calls >= thresholds.MinCalls {
+			evaluated++
+			switch rate := stat.ErrorRate(); {
+			case rate >= thresholds.UnhealthyErrorRate:
+				status = StatusUnhealthy
+				unhealthy++
+			case rate >= thresholds.DegradedErrorRate:
+				status = StatusDegraded
+			}
+		}
+		worst = Worst(worst, status)
+
+		perModel[stat.Model] = map[string]interface{}{
+			"status":     status,
+			"calls":      stat.Calls,
+			"errors":     stat.Errors,
+			"error_rate": stat.ErrorRate(),
+		}
+	}
+
+	component := Component{Status: StatusHealthy, Details: map[string]interface{}{"models": perModel}}
+	switch {
+	case evaluated > 0 && unhealthy == evaluated:
+		component.Status = StatusUnhealthy
+		component.Message = "todos os modelos com taxa de erro alta"
+	case worst != StatusHealthy:
+		component.Status = StatusDegraded
+		component.Message = "modelos com taxa de erro alta"
+	}
+	return component
+}
+
+// breakersComponent descreve os circuit breakers dos modelos: degraded quando algum modelo
+// está sendo pulado (circuito aberto ou em teste) e unhealthy quando todos estão abertos,
+// pois as gerações falham sem chamar a API
+func breakersComponent(states []services.BreakerState) Component {
+	perModel := make(map[string]interface{}, len(states))
+	open, notClosed := 0, 0
+	for _, state := range states {
+		perModel[state.Model] = state
+		switch state.State {
+		case services.CircuitOpen:
+			open++
+			notClosed++
+		case services.CircuitHalfOpen:
+			notClosed++
+		}
+	}
+
+	component := Component{Status: StatusHealthy, Details: map[string]interface{}{"models": perModel}}
+	switch {
+	case len(states) > 0 && open == len(states):
+		component.Status = StatusUnhealthy
+		component.Message = "circuito aberto em todos os modelos"
+	case notClosed > 0:
+		component.Status = StatusDegraded
+		component.Message = fmt.Sprintf("%d modelo(s) com circuito aberto ou em teste", notClosed)
+	}
+	return component
+}
+
+// cacheComponent descreve o cache da lista de modelos. Sem cache válido, as gerações
+// usam apenas a lista fixa de modelos de fallback.
+func cacheComponent(info services.ModelsCacheInfo) Component {
+	details := map[string]interface{}{"entries": info.Entries}
+	if !info.CachedAt.IsZero() {
+		details["cached_at"] = info.CachedAt.UTC()
+	}
+
+	if !info.Fresh {
+		return Component{Status: StatusDegraded, Message: "lista de modelos indisponível, usando apenas os modelos de fallback", Details: details}
+	}
+	return Component{Status: StatusHealthy, Details: details}
+}
+
+// quotaComponent estima a folga de quota pelos erros 429 recentes: degraded logo após um 429
+// e unhealthy quando a maior parte das tentativas recentes recebeu 429
+func quotaComponent(stats []services.ModelStat, thresholds Thresholds, now time.Time) Component {
+	calls, quota := 0, 0
+	var lastQuota time.Time
+	for _, stat := range stats {
+		calls += stat.Calls
+		quota += stat.Quota
+		if stat.LastQuota.After(lastQuota) {
+			lastQuota = stat.LastQuota
+		}
+	}
+
+	details := map[string]interface{}{"calls": calls, "quota_errors": quota}
+	if !lastQuota.IsZero() {
+		details["last_quota_error"] = lastQuota.UTC()
+	}
+
+	switch {
+	case calls >= thresholds.MinCalls && float64(quota)/float64(calls) >= thresholds.UnhealthyErrorRate:
+		return Component{Status: StatusUnhealthy, Message: "quota esgotada na maior parte das tentativas", Details: details}
+	case !lastQuota.IsZero() && now.Sub(lastQuota) < thresholds.QuotaCooldown:
+		return Component{Status: StatusDegraded, Message: "quota excedida recentemente", Details: details}
+	default:
+		return Component{Status: StatusHealthy, Details: details}
+	}
+}
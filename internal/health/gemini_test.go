@@ -0,0 +1,180 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSource struct {
+	models []string
+	err    error
+	stats  []services.ModelStat
+	cache  services.ModelsCacheInfo
+	keys   []services.KeyUsage
+	states []services.BreakerState
+	probes int32
+}
+
+func (f *fakeSource) ProbeModels(context.Context) ([]string, error) {
+	atomic.AddInt32(&f.probes, 1)
+	return f.models, f.err
+}
+
+func (f *fakeSource) ModelStats() []services.ModelStat         { return f.stats }
+func (f *fakeSource) ModelsCache() services.ModelsCacheInfo    { return f.cache }
+func (f *fakeSource) KeyUsage() []services.KeyUsage            { return f.keys }
+func (f *fakeSource) CircuitBreakers() []services.BreakerState { return f.states }
+
+func TestRegisterGemini_Probe(t *testing.T) {
+	freshCache := services.ModelsCacheInfo{Entries: 2, CachedAt: time.Now(), Fresh: true}
+
+	tests := []struct {
+		name         string
+		source       *fakeSource
+		wantProvider Status
+		wantAPIKey   Status
+		wantCache    Status
+		wantOverall  Status
+	}{
+		{
+			name:         "API acessível",
+			source:       &fakeSource{models: []string{"gemini-2.5-flash"}, cache: freshCache},
+			wantProvider: StatusHealthy, wantAPIKey: StatusHealthy, wantCache: StatusHealthy, wantOverall: StatusHealthy,
+		},
+		{
+			name:         "API key recusada",
+			source:       &fakeSource{err: apperror.New(apperror.KindUnauthorized, apperror.CodeUpstreamRejected, "recusada")},
+			wantProvider: StatusHealthy, wantAPIKey: StatusUnhealthy, wantCache: StatusDegraded, wantOverall: StatusUnhealthy,
+		},
+		{
+			name:         "API key ausente",
+			source:       &fakeSource{err: apperror.New(apperror.KindUpstreamUnavailable, apperror.CodeAPIKeyMissing, "ausente")},
+			wantProvider: StatusDegraded, wantAPIKey: StatusUnhealthy, wantCache: StatusDegraded, wantOverall: StatusUnhealthy,
+		},
+		{
+			name:         "API fora do ar com cache válido",
+			source:       &fakeSource{err: apperror.New(apperror.KindUpstreamUnavailable, apperror.CodeUpstreamUnavailable, "fora do ar"), cache: freshCache},
+			wantProvider: StatusUnhealthy, wantAPIKey: StatusDegraded, wantCache: StatusHealthy, wantOverall: StatusUnhealthy,
+		},
+		{
+			name:         "quota excedida ao listar modelos",
+			source:       &fakeSource{err: apperror.New(apperror.KindUpstreamQuota, apperror.CodeUpstreamQuota, "429"), cache: freshCache},
+			wantProvider: StatusDegraded, wantAPIKey: StatusHealthy, wantCache: StatusHealthy, wantOverall: StatusDegraded,
+		},
+		{
+			name: "uma das API keys revogada",
+			source: &fakeSource{models: []string{"gemini-2.5-flash"}, cache: freshCache, keys: []services.KeyUsage{
+				{ID: "…aaaa", Status: services.KeyActive},
+				{ID: "…bbbb", Status: services.KeyRevoked},
+			}},
+			wantProvider: StatusHealthy, wantAPIKey: StatusDegraded, wantCache: StatusHealthy, wantOverall: StatusDegraded,
+		},
+		{
+			name: "todas as API keys revogadas",
+			source: &fakeSource{err: apperror.New(apperror.KindUnauthorized, apperror.CodeUpstreamRejected, "recusada"), cache: freshCache, keys: []services.KeyUsage{
+				{ID: "…aaaa", Status: services.KeyRevoked},
+				{ID: "…bbbb", Status: services.KeyRevoked},
+			}},
+			wantProvider: StatusHealthy, wantAPIKey: StatusUnhealthy, wantCache: StatusHealthy, wantOverall: StatusUnhealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker()
+			RegisterGemini(checker, tt.source, DefaultThresholds)
+
+			report := checker.Run(context.Background())
+
+			assert.Equal(t, tt.wantProvider, report.Components["provider"].Status)
+			assert.Equal(t, tt.wantAPIKey, report.Components["api_key"].Status)
+			assert.Equal(t, tt.wantCache, report.Components["models_cache"].Status)
+			assert.Equal(t, tt.wantOverall, report.Status)
+		})
+	}
+}
+
+func TestRegisterGemini_ReusesProbe(t *testing.T) {
+	source := &fakeSource{models: []string{"gemini-2.5-flash"}}
+	thresholds := DefaultThresholds
+	thresholds.ProbeTTL = 50 * time.Millisecond
+	checker := NewChecker()
+	RegisterGemini(checker, source, thresholds)
+
+	for i := 0; i < 3; i++ {
+		report := checker.Run(context.Background())
+		assert.Equal(t, StatusHealthy, report.Components["provider"].Status)
+		assert.Contains(t, report.Components["provider"].Details, "probed_at")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&source.probes))
+
+	// Depois do TTL a lista de modelos é consultada de novo
+	time.Sleep(60 * time.Millisecond)
+	checker.Run(context.Background())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&source.probes))
+}
+
+func TestModelsComponent(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats []services.ModelStat
+		want  Status
+	}{
+		{"sem tentativas", nil, StatusHealthy},
+		{"poucas tentativas não são avaliadas", []services.ModelStat{{Model: "a", Calls: 2, Errors: 2}}, StatusHealthy},
+		{"um modelo com erros", []services.ModelStat{
+			{Model: "a", Calls: 10, Errors: 6},
+			{Model: "b", Calls: 10, Errors: 0},
+		}, StatusDegraded},
+		{"taxa de erro moderada", []services.ModelStat{{Model: "a", Calls: 10, Errors: 3}}, StatusDegraded},
+		{"todos os modelos com erros", []services.ModelStat{
+			{Model: "a", Calls: 10, Errors: 6},
+			{Model: "b", Calls: 5, Errors: 5},
+		}, StatusUnhealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, modelsComponent(tt.stats, DefaultThresholds).Status)
+		})
+	}
+}
+
+func TestQuotaComponent(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		stats []services.ModelStat
+		want  Status
+	}{
+		{"sem erros de quota", []services.ModelStat{{Model: "a", Calls: 10}}, StatusHealthy},
+		{"429 recente", []services.ModelStat{{Model: "a", Calls: 10, Errors: 1, Quota: 1, LastQuota: now.Add(-10 * time.Second)}}, StatusDegraded},
+		{"429 antigo", []services.ModelStat{{Model: "a", Calls: 10, Errors: 1, Quota: 1, LastQuota: now.Add(-5 * time.Minute)}}, StatusHealthy},
+		{"quota esgotada", []services.ModelStat{{Model: "a", Calls: 6, Errors: 4, Quota: 4, LastQuota: now.Add(-5 * time.Minute)}}, StatusUnhealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, quotaComponent(tt.stats, DefaultThresholds, now).Status)
+		})
+	}
+}
+
+func TestBreakersComponent(t *testing.T) {
+	closed := services.BreakerState{Model: "gemini-2.5-flash", State: services.CircuitClosed}
+	open := services.BreakerState{Model: "gemini-2.5-pro", State: services.CircuitOpen, ConsecutiveFailures: 5}
+	halfOpen := services.BreakerState{Model: "gemini-2.5-pro", State: services.CircuitHalfOpen}
+
+	assert.Equal(t, StatusHealthy, breakersComponent(nil).Status)
+	assert.Equal(t, StatusHealthy, breakersComponent([]services.BreakerState{closed}).Status)
+	assert.Equal(t, StatusDegraded, breakersComponent([]services.BreakerState{closed, open}).Status)
+	assert.Equal(t, StatusDegraded, breakersComponent([]services.BreakerState{closed, halfOpen}).Status)
+	assert.Equal(t, StatusUnhealthy, breakersComponent([]services.BreakerState{open}).Status)
+}
@@ -0,0 +1,133 @@
+// Package health monta o health check detalhado: cada verificação informa o estado de um ou
+// mais componentes e o estado geral é o pior entre eles.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Status é o estado de um componente ou do serviço
+type Status string
+
+// Estados possíveis, do melhor para o pior
+const (
+	StatusHealthy   Status = "healthy"
+	StatusDegraded  Status = "degraded"
+	StatusUnhealthy Status = "unhealthy"
+)
+
+func (s Status) severity() int {
+	switch s {
+	case StatusHealthy:
+		return 0
+	case StatusDegraded:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Worst retorna o pior dos estados informados (healthy se nenhum for informado)
+func Worst(statuses ...Status) Status {
+	worst := StatusHealthy
+	for _, status := range statuses {
+		if status.severity() > worst.severity() {
+			worst = status
+		}
+	}
+	return worst
+}
+
+// Component é o estado de um componente, com detalhes para dashboards
+type Component struct {
+	Status  Status                 `json:"status"`
+	Message string                 `json:"message,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// Report é o resultado do health check detalhado
+type Report struct {
+	Status     Status               `json:"status"`
+	CheckedAt  time.Time            `json:"checked_at"`
+	DurationMs int64                `json:"duration_ms"`
+	Components map[string]Component `json:"components"`
+}
+
+// Check verifica um ou mais componentes, retornando o estado de cada um pelo nome
+type Check func(ctx context.Context) map[string]Component
+
+// Checker executa as verificações registradas em paralelo
+type Checker struct {
+	// Timeout limita cada verificação; quem não responder no prazo fica unhealthy
+	Timeout time.Duration
+
+	names  []string
+	checks []Check
+}
+
+// NewChecker cria um checker com timeout de 5s por verificação
+func NewChecker() *Checker {
+	return &Checker{Timeout: 5 * time.Second}
+}
+
+// Register adiciona uma verificação. O nome identifica a verificação quando ela estoura o prazo.
+func (c *Checker) Register(name string, check Check) {
+	c.names = append(c.names, name)
+	c.checks = append(c.checks, check)
+}
+
+// Run executa as verificações e monta o relatório
+func (c *Checker) Run(ctx context.Context) Report {
+	start := time.Now()
+	report := Report{
+		CheckedAt:  start.UTC(),
+		Components: make(map[string]Component),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			components := c.run(ctx, c.names[i], check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			for name, component := range components {
+				report.Components[name] = component
+			}
+		}()
+	}
+	wg.Wait()
+
+	statuses := make([]Status, 0, len(report.Components))
+	for _, component := range report.Components {
+		statuses = append(statuses, component.Status)
+	}
+	report.Status = Worst(statuses...)
+	report.DurationMs = time.Since(start).Milliseconds()
+	return report
+}
+
+// run executa uma verificação com o timeout do checker
+func (c *Checker) run(ctx context.Context, name string, check Check) map[string]Component {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	done := make(chan map[string]Component, 1)
+	go func() { done <- check(ctx) }()
+
+	select {
+	case components := <-done:
+		return components
+	case <-ctx.Done():
+		return map[string]Component{name: {
+			Status:  StatusUnhealthy,
+			Message: fmt.Sprintf("verificação não respondeu em %s", c.Timeout),
+		}}
+	}
+}
@@ -0,0 +1,52 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func staticCheck(name string, status Status) Check {
+	return func(context.Context) map[string]Component {
+		return map[string]Component{name: {Status: status}}
+	}
+}
+
+func TestWorst(t *testing.T) {
+	assert.Equal(t, StatusHealthy, Worst())
+	assert.Equal(t, StatusDegraded, Worst(StatusHealthy, StatusDegraded))
+	assert.Equal(t, StatusUnhealthy, Worst(StatusUnhealthy, StatusDegraded, StatusHealthy))
+}
+
+func TestChecker_Run(t *testing.T) {
+	checker := NewChecker()
+	checker.Register("a", staticCheck("a", StatusHealthy))
+	checker.Register("b", staticCheck("b", StatusDegraded))
+
+	report := checker.Run(context.Background())
+
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Len(t, report.Components, 2)
+	assert.Equal(t, StatusHealthy, report.Components["a"].Status)
+	assert.Equal(t, StatusDegraded, report.Components["b"].Status)
+}
+
+func TestChecker_Run_Timeout(t *testing.T) {
+	checker := NewChecker()
+	checker.Timeout = 20 * time.Millisecond
+	checker.Register("lento", func(ctx context.Context) map[string]Component {
+		time.Sleep(time.Second)
+		return nil
+	})
+	checker.Register("rapido", staticCheck("rapido", StatusHealthy))
+
+	start := time.Now()
+	report := checker.Run(context.Background())
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.Equal(t, StatusUnhealthy, report.Components["lento"].Status)
+	assert.Equal(t, StatusHealthy, report.Components["rapido"].Status)
+}
@@ -0,0 +1,21 @@
+package health
+
+import (
+	"context"
+
+	"github.com/spellbook/spellbook/internal/batch"
+)
+
+// RegisterJobs registra a verificação do armazenamento de jobs de lote (jobs), com a
+// quantidade de jobs por estado
+func RegisterJobs(checker *Checker, store *batch.JobStore) {
+	checker.Register("jobs", func(context.Context) map[string]Component {
+		counts := store.Counts()
+
+		details := make(map[string]interface{}, len(counts))
+		for status, count := range counts {
+			details[status] = count
+		}
+		return map[string]Component{"jobs": {Status: StatusHealthy, Details: details}}
+	})
+}
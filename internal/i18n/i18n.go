@@ -0,0 +1,60 @@
+package i18n
+
+import (
+	"golang.org/x/text/language"
+)
+
+// DefaultLanguage é o idioma usado quando o cliente não informa um idioma suportado
+const DefaultLanguage = "pt-BR"
+
+// Idiomas suportados, na ordem de preferência do matcher (o primeiro é o padrão)
+var supportedTags = []language.Tag{
+	language.BrazilianPortuguese,
+	language.English,
+	language.Spanish,
+}
+
+// supportedNames são os códigos BCP 47 devolvidos por Resolve, na mesma ordem de supportedTags
+var supportedNames = []string{"pt-BR", "en", "es"}
+
+var matcher = language.NewMatcher(supportedTags)
+
+// Supported retorna os códigos dos idiomas suportados
+func Supported() []string {
+	return append([]string(nil), supportedNames...)
+}
+
+// Resolve escolhe o idioma da resposta: primeiro o campo language da requisição (BCP 47),
+// depois o header Accept-Language e, por fim, o idioma padrão
+func Resolve(requested, acceptLanguage string) string {
+	if requested != "" {
+		if tag, err := language.Parse(requested); err == nil {
+			if name, ok := match(tag); ok {
+				return name
+			}
+		}
+	}
+
+	if acceptLanguage != "" {
+		if tags, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil {
+			if name, ok := match(tags...); ok {
+				return name
+			}
+		}
+	}
+
+	return DefaultLanguage
+}
+
+// match retorna o idioma suportado mais próximo das tags informadas
+func match(tags ...language.Tag) (string, bool) {
+	if len(tags) == 0 {
+		return "", false
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return "", false
+	}
+	return supportedNames[index], true
+}
@@ -0,0 +1,48 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name           string
+		requested      string
+		acceptLanguage string
+		expected       string
+	}{
+		{name: "campo language tem prioridade", requested: "en", acceptLanguage: "es", expected: "en"},
+		{name: "variante regional", requested: "en-GB", expected: "en"},
+		{name: "português sem região", requested: "pt", expected: "pt-BR"},
+		{name: "Accept-Language como fallback", acceptLanguage: "es-MX,es;q=0.9,en;q=0.8", expected: "es"},
+		{name: "Accept-Language com qualidade", acceptLanguage: "fr;q=0.9,en;q=0.8", expected: "en"},
+		{name: "idioma não suportado", requested: "ja", expected: "pt-BR"},
+		{name: "tag inválida usa Accept-Language", requested: "???", acceptLanguage: "en", expected: "en"},
+		{name: "nada informado", expected: "pt-BR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Resolve(tt.requested, tt.acceptLanguage))
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	assert.Equal(t, "topic cannot be empty", Message("en", MsgTopicEmpty))
+	assert.Equal(t, "el tema no puede estar vacío", Message("es", MsgTopicEmpty))
+	assert.Equal(t, "tópico não pode ser vazio", Message("fr", MsgTopicEmpty))
+	assert.Equal(t, "chave_desconhecida", Message("en", "chave_desconhecida"))
+}
+
+func TestMessages_SameKeys(t *testing.T) {
+	for _, lang := range Supported() {
+		for key := range messages[DefaultLanguage] {
+			_, ok := messages[lang][key]
+			assert.True(t, ok, "%s sem a chave %s", lang, key)
+		}
+		assert.Len(t, messages[lang], len(messages[DefaultLanguage]), lang)
+	}
+}
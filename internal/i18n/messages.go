@@ -0,0 +1,196 @@
+package i18n
+
+// Chaves das mensagens da API. As chaves de erro são os códigos estáveis devolvidos
+// no campo code das respostas (ver internal/apperror)
+const (
+	MsgTopicRequired       = "topic_required"
+	MsgTopicEmpty          = "topic_empty"
+	MsgSubjectRequired     = "subject_required"
+	MsgSubjectEmpty        = "subject_empty"
+	MsgObjectiveRequired   = "objective_required"
+	MsgObjectiveEmpty      = "objective_empty"
+	MsgRequestInvalid      = "request_invalid"
+	MsgJobNotFound         = "job_not_found"
+	MsgSpellNotFound       = "spell_not_found"
+	MsgRateLimited         = "rate_limited"
+	MsgAdminUnauthorized   = "admin_unauthorized"
+	MsgAPIKeyMissing       = "api_key_missing"
+	MsgUpstreamQuota       = "upstream_quota"
+	MsgUpstreamUnavailable = "upstream_unavailable"
+	MsgUpstreamRejected    = "upstream_unauthorized"
+	MsgModelForbidden      = "model_forbidden"
+	MsgOutputInvalid       = "output_invalid"
+	MsgTimeout             = "timeout"
+	MsgInternal            = "internal_error"
+)
+
+// Chaves dos títulos das respostas de erro, uma por categoria
+const (
+	TitleValidation          = "title.validation"
+	TitleUpstreamQuota       = "title.upstream_quota"
+	TitleUpstreamUnavailable = "title.upstream_unavailable"
+	TitleOutputInvalid       = "title.output_invalid"
+	TitleTimeout             = "title.timeout"
+	TitleUnauthorized        = "title.unauthorized"
+	TitleNotFound            = "title.not_found"
+	TitleRateLimited         = "title.rate_limited"
+	TitleUnauthenticated     = "title.unauthenticated"
+	TitleInternal            = "title.internal"
+)
+
+// Chaves dos problemas encontrados na validação das trilhas educacionais, repassados ao
+// modelo na próxima tentativa. As mensagens são formatos de fmt.Sprintf.
+const (
+	TrailTotalDays       = "trail.total_days"
+	TrailStepCount       = "trail.step_count"
+	TrailDayOrder        = "trail.day_order"
+	TrailActivityCount   = "trail.activity_count"
+	TrailActivityType    = "trail.activity_type"
+	TrailProjectTooEarly = "trail.project_too_early"
+	TrailChapterMissing  = "trail.chapter_missing"
+	TrailMoreProblems    = "trail.more_problems"
+)
+
+// messages guarda as mensagens traduzidas por idioma e chave
+var messages = map[string]map[string]string{
+	"pt-BR": {
+		MsgTopicRequired:       "tópico é obrigatório",
+		MsgTopicEmpty:          "tópico não pode ser vazio",
+		MsgSubjectRequired:     "assunto é obrigatório",
+		MsgSubjectEmpty:        "assunto não pode ser vazio",
+		MsgObjectiveRequired:   "objetivo é obrigatório",
+		MsgObjectiveEmpty:      "objetivo não pode ser vazio",
+		MsgRequestInvalid:      "a requisição não segue o esquema da API",
+		MsgJobNotFound:         "job não encontrado ou expirado",
+		MsgSpellNotFound:       "spell não encontrado",
+		MsgRateLimited:         "muitas requisições, aguarde antes de tentar novamente",
+		MsgAdminUnauthorized:   "token de administração ausente ou inválido",
+		MsgAPIKeyMissing:       "API key do Gemini não configurada",
+		MsgUpstreamQuota:       "quota do modelo excedida, tente novamente mais tarde",
+		MsgUpstreamUnavailable: "o serviço de IA está indisponível no momento",
+		MsgUpstreamRejected:    "o serviço de IA recusou a credencial configurada",
+		MsgModelForbidden:      "o serviço de IA negou acesso aos modelos disponíveis",
+		MsgOutputInvalid:       "o modelo não gerou uma resposta válida",
+		MsgTimeout:             "a geração excedeu o tempo limite",
+		MsgInternal:            "erro interno",
+
+		TitleValidation:          "Requisição inválida",
+		TitleUpstreamQuota:       "Quota excedida",
+		TitleUpstreamUnavailable: "Serviço indisponível",
+		TitleOutputInvalid:       "Resposta inválida do modelo",
+		TitleTimeout:             "Tempo limite excedido",
+		TitleUnauthorized:        "Credencial recusada",
+		TitleNotFound:            "Não encontrado",
+		TitleRateLimited:         "Muitas requisições",
+		TitleUnauthenticated:     "Não autenticado",
+		TitleInternal:            "Erro interno",
+
+		TrailTotalDays:       "total_days é %d, esperado %d",
+		TrailStepCount:       "a trilha tem %d dias em steps, esperado %d",
+		TrailDayOrder:        "steps[%d] é o dia %d, esperado %d (os dias devem ir de 1 a %d, em ordem e sem lacunas)",
+		TrailActivityCount:   "dia %d tem %d atividades, esperado de %d a %d",
+		TrailActivityType:    "dia %d: tipo de atividade %q inválido (use %s)",
+		TrailProjectTooEarly: "dia %d: projeto antes do dia %d (projetos ficam no final da trilha)",
+		TrailChapterMissing:  "dia %d: capítulo %q não existe em %q (capítulos: %s)",
+		TrailMoreProblems:    "e mais %d problemas",
+	},
+	"en": {
+		MsgTopicRequired:       "topic is required",
+		MsgTopicEmpty:          "topic cannot be empty",
+		MsgSubjectRequired:     "subject is required",
+		MsgSubjectEmpty:        "subject cannot be empty",
+		MsgObjectiveRequired:   "objective is required",
+		MsgObjectiveEmpty:      "objective cannot be empty",
+		MsgRequestInvalid:      "the request does not match the API schema",
+		MsgJobNotFound:         "job not found or expired",
+		MsgSpellNotFound:       "spell not found",
+		MsgRateLimited:         "too many requests, please wait before retrying",
+		MsgAdminUnauthorized:   "missing or invalid admin token",
+		MsgAPIKeyMissing:       "Gemini API key is not configured",
+		MsgUpstreamQuota:       "model quota exceeded, please try again later",
+		MsgUpstreamUnavailable: "the AI service is currently unavailable",
+		MsgUpstreamRejected:    "the AI service rejected the configured credential",
+		MsgModelForbidden:      "the AI service denied access to the available models",
+		MsgOutputInvalid:       "the model did not produce a valid response",
+		MsgTimeout:             "generation timed out",
+		MsgInternal:            "internal error",
+
+		TitleValidation:          "Invalid request",
+		TitleUpstreamQuota:       "Quota exceeded",
+		TitleUpstreamUnavailable: "Service unavailable",
+		TitleOutputInvalid:       "Invalid model response",
+		TitleTimeout:             "Timeout",
+		TitleUnauthorized:        "Credential rejected",
+		TitleNotFound:            "Not found",
+		TitleRateLimited:         "Too many requests",
+		TitleUnauthenticated:     "Unauthenticated",
+		TitleInternal:            "Internal error",
+
+		TrailTotalDays:       "total_days is %d, expected %d",
+		TrailStepCount:       "the trail has %d days in steps, expected %d",
+		TrailDayOrder:        "steps[%d] is day %d, expected %d (days must go from 1 to %d, in order and without gaps)",
+		TrailActivityCount:   "day %d has %d activities, expected %d to %d",
+		TrailActivityType:    "day %d: invalid activity type %q (use %s)",
+		TrailProjectTooEarly: "day %d: project before day %d (projects belong at the end of the trail)",
+		TrailChapterMissing:  "day %d: chapter %q does not exist in %q (chapters: %s)",
+		TrailMoreProblems:    "and %d more problems",
+	},
+	"es": {
+		MsgTopicRequired:       "el tema es obligatorio",
+		MsgTopicEmpty:          "el tema no puede estar vacío",
+		MsgSubjectRequired:     "el asunto es obligatorio",
+		MsgSubjectEmpty:        "el asunto no puede estar vacío",
+		MsgObjectiveRequired:   "el objetivo es obligatorio",
+		MsgObjectiveEmpty:      "el objetivo no puede estar vacío",
+		MsgRequestInvalid:      "la solicitud no sigue el esquema de la API",
+		MsgJobNotFound:         "job no encontrado o expirado",
+		MsgSpellNotFound:       "spell no encontrado",
+		MsgRateLimited:         "demasiadas solicitudes, espera antes de intentarlo de nuevo",
+		MsgAdminUnauthorized:   "token de administración ausente o inválido",
+		MsgAPIKeyMissing:       "la API key de Gemini no está configurada",
+		MsgUpstreamQuota:       "cuota del modelo excedida, inténtalo de nuevo más tarde",
+		MsgUpstreamUnavailable: "el servicio de IA no está disponible en este momento",
+		MsgUpstreamRejected:    "el servicio de IA rechazó la credencial configurada",
+		MsgModelForbidden:      "el servicio de IA denegó el acceso a los modelos disponibles",
+		MsgOutputInvalid:       "el modelo no generó una respuesta válida",
+		MsgTimeout:             "la generación excedió el tiempo límite",
+		MsgInternal:            "error interno",
+
+		TitleValidation:          "Solicitud inválida",
+		TitleUpstreamQuota:       "Cuota excedida",
+		TitleUpstreamUnavailable: "Servicio no disponible",
+		TitleOutputInvalid:       "Respuesta inválida del modelo",
+		TitleTimeout:             "Tiempo límite excedido",
+		TitleUnauthorized:        "Credencial rechazada",
+		TitleNotFound:            "No encontrado",
+		TitleRateLimited:         "Demasiadas solicitudes",
+		TitleUnauthenticated:     "No autenticado",
+		TitleInternal:            "Error interno",
+
+		TrailTotalDays:       "total_days es %d, se esperaba %d",
+		TrailStepCount:       "la ruta tiene %d días en steps, se esperaba %d",
+		TrailDayOrder:        "steps[%d] es el día %d, se esperaba %d (los días deben ir de 1 a %d, en orden y sin huecos)",
+		TrailActivityCount:   "el día %d tiene %d actividades, se esperaba de %d a %d",
+		TrailActivityType:    "día %d: tipo de actividad %q inválido (usa %s)",
+		TrailProjectTooEarly: "día %d: proyecto antes del día %d (los proyectos van al final de la ruta)",
+		TrailChapterMissing:  "día %d: el capítulo %q no existe en %q (capítulos: %s)",
+		TrailMoreProblems:    "y %d problemas más",
+	},
+}
+
+// Message retorna a mensagem traduzida, caindo para o idioma padrão e, por fim, para a própria chave
+func Message(lang, key string) string {
+	if msg, ok := Lookup(lang, key); ok {
+		return msg
+	}
+	return key
+}
+
+// Lookup retorna a mensagem traduzida, caindo para o idioma padrão. ok é falso quando a chave não existe
+func Lookup(lang, key string) (string, bool) {
+	if msg, ok := messages[lang][key]; ok {
+		return msg, true
+	}
+	msg, ok := messages[DefaultLanguage][key]
+	return msg, ok
+}
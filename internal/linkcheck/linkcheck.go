@@ -0,0 +1,299 @@
+// Package linkcheck verifica se as URLs de recursos geradas pelos modelos existem. Cada URL é
+// consultada com HEAD (ou GET, quando o servidor não aceita HEAD), seguindo redirecionamentos,
+// com concorrência e tempo limitados; os resultados ficam em cache.
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/metrics"
+)
+
+// Modos de tratamento dos links quebrados
+const (
+	// ModeOff desativa a verificação
+	ModeOff = "off"
+	// ModeFlag mantém as URLs e informa o resultado em link_status
+	ModeFlag = "flag"
+	// ModeStrip remove as URLs quebradas e informa o resultado das demais
+	ModeStrip = "strip"
+)
+
+// Resultados da verificação de uma URL
+const (
+	StatusOK = "ok"
+	// StatusBroken é uma URL inexistente: 404/410, domínio inexistente ou endereço inválido
+	StatusBroken = "broken"
+	// StatusUnverified é uma URL que não pôde ser confirmada (timeout, 5xx, acesso negado)
+	StatusUnverified = "unverified"
+)
+
+// Settings configura a verificação de links
+type Settings struct {
+	Mode string
+	// Timeout limita cada URL, incluindo os redirecionamentos
+	Timeout time.Duration
+	// Concurrency é o número máximo de URLs verificadas ao mesmo tempo em uma resposta
+	Concurrency int
+	// CacheTTL é por quanto tempo o resultado de uma URL é reaproveitado
+	CacheTTL time.Duration
+}
+
+// DefaultSettings: desativado; ao ativar, 5s por URL, 8 em paralelo e cache de 24 horas
+var DefaultSettings = Settings{
+	Mode:        ModeOff,
+	Timeout:     5 * time.Second,
+	Concurrency: 8,
+	CacheTTL:    24 * time.Hour,
+}
+
+// maxRedirects limita os redirecionamentos seguidos por URL
+const maxRedirects = 5
+
+// maxCacheEntries limita o tamanho do cache
+const maxCacheEntries = 10000
+
+// Result é o resultado da verificação de uma URL
+type Result struct {
+	URL    string `json:"url"`
+	Status string `json:"status"`
+	// HTTPStatus é o status da última resposta (0 quando não houve resposta)
+	HTTPStatus int `json:"http_status,omitempty"`
+	// FinalURL é o destino após os redirecionamentos, quando diferente de URL
+	FinalURL  string    `json:"final_url,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Checker verifica URLs usando o transporte HTTP informado
+type Checker struct {
+	transport http.RoundTripper
+	now       func() time.Time
+
+	mu       sync.RWMutex
+	settings Settings
+	cache    map[string]Result
+}
+
+// New cria um verificador. Com transport nil, usa um transporte que só conecta a endereços
+// públicos, para uma URL inventada pelo modelo não alcançar a rede interna.
+func New(settings Settings, transport http.RoundTripper) *Checker {
+	if transport == nil {
+		transport = PublicTransport()
+	}
+	return &Checker{
+		transport: transport,
+		now:       time.Now,
+		settings:  settings,
+		cache:     make(map[string]Result),
+	}
+}
+
+// SetSettings troca as configurações. O cache é mantido.
+func (c *Checker) SetSettings(settings Settings) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.settings = settings
+}
+
+// Settings retorna as configurações em uso
+func (c *Checker) Settings() Settings {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.settings
+}
+
+// CheckAll verifica as URLs (sem repetições) com a concorrência configurada
+func (c *Checker) CheckAll(ctx context.Context, urls []string) map[string]Result {
+	settings := c.Settings()
+	results := make(map[string]Result, len(urls))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := make(map[string]bool, len(urls))
+	sem := make(chan struct{}, max(settings.Concurrency, 1))
+	for _, rawURL := range urls {
+		if seen[rawURL] {
+			continue
+		}
+		seen[rawURL] = true
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			result := c.Check(ctx, rawURL)
+			mu.Lock()
+			results[rawURL] = result
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Check verifica uma URL, usando o cache quando o resultado ainda é válido. Resultados
+// inconclusivos não entram no cache, para a próxima geração tentar de novo.
+func (c *Checker) Check(ctx context.Context, rawURL string) Result {
+	settings := c.Settings()
+
+	c.mu.RLock()
+	cached, ok := c.cache[rawURL]
+	c.mu.RUnlock()
+	if ok && c.now().Sub(cached.CheckedAt) < settings.CacheTTL {
+		metrics.RecordCache("links", true)
+		return cached
+	}
+	metrics.RecordCache("links", false)
+
+	result := c.check(ctx, rawURL, settings.Timeout)
+	metrics.LinkChecksTotal.WithLabelValues(result.Status).Inc()
+
+	if result.Status != StatusUnverified {
+		c.mu.Lock()
+		c.store(result)
+		c.mu.Unlock()
+	}
+	return result
+}
+
+// store guarda o resultado, descartando os expirados (ou qualquer um) quando o cache está cheio
+func (c *Checker) store(result Result) {
+	if len(c.cache) >= maxCacheEntries {
+		for key, entry := range c.cache {
+			if c.now().Sub(entry.CheckedAt) >= c.settings.CacheTTL {
+				delete(c.cache, key)
+			}
+		}
+		for key := range c.cache {
+			if len(c.cache) < maxCacheEntries {
+				break
+			}
+			delete(c.cache, key)
+		}
+	}
+	c.cache[result.URL] = result
+}
+
+func (c *Checker) check(ctx context.Context, rawURL string, timeout time.Duration) Result {
+	result := Result{URL: rawURL, CheckedAt: c.now()}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		result.Status = StatusBroken
+		result.Error = "URL inválida"
+		return result
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	client := &http.Client{
+		Transport: c.transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return fmt.Errorf("mais de %d redirecionamentos", maxRedirects)
+			}
+			return nil
+		},
+	}
+
+	resp, err := c.request(ctx, client, http.MethodHead, rawURL)
+	// Alguns servidores não implementam HEAD ou o recusam; GET confirma
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented ||
+		resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+		resp, err = c.request(ctx, client, http.MethodGet, rawURL)
+	}
+	if err != nil {
+		result.Status, result.Error = classifyError(err)
+		return result
+	}
+
+	result.HTTPStatus = resp.StatusCode
+	if final := resp.Request.URL.String(); final != rawURL {
+		result.FinalURL = final
+	}
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		result.Status = StatusOK
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		result.Status = StatusBroken
+	default:
+		// 401, 403, 429 e 5xx não dizem se o recurso existe
+		result.Status = StatusUnverified
+	}
+	return result
+}
+
+// request faz a requisição e descarta o corpo, que não é usado
+func (c *Checker) request(ctx context.Context, client *http.Client, method, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Spellbook-LinkCheck/1.0")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+	return resp, nil
+}
+
+// classifyError separa os erros que mostram que o link não existe dos inconclusivos
+func classifyError(err error) (status, message string) {
+	var dnsErr *net.DNSError
+	switch {
+	case errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+		return StatusBroken, "domínio inexistente"
+	case errors.Is(err, errPrivateAddress):
+		return StatusBroken, errPrivateAddress.Error()
+	case errors.Is(err, context.DeadlineExceeded):
+		return StatusUnverified, "tempo esgotado"
+	default:
+		return StatusUnverified, err.Error()
+	}
+}
+
+// errPrivateAddress é devolvido ao tentar conectar a um endereço que não é público
+var errPrivateAddress = errors.New("endereço não público")
+
+// PublicTransport retorna um transporte HTTP que recusa conexões a endereços de loopback,
+// privados, link-local e não especificados (inclusive após redirecionamentos)
+func PublicTransport() http.RoundTripper {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+				ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast() {
+				return errPrivateAddress
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
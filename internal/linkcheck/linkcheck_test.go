@@ -0,0 +1,191 @@
+package linkcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStubServer simula os sites dos recursos e conta as requisições por caminho
+func newStubServer(t *testing.T) (*httptest.Server, *stubCounts) {
+	counts := &stubCounts{hits: make(map[string]*atomic.Int32)}
+	for _, path := range []string{"/ok", "/missing", "/gone", "/moved", "/get-only", "/error", "/slow"} {
+		counts.hits[path] = &atomic.Int32{}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		counts.hit(r)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		counts.hit(r)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		counts.hit(r)
+		w.WriteHeader(http.StatusGone)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		counts.hit(r)
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/get-only", func(w http.ResponseWriter, r *http.Request) {
+		counts.hit(r)
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+		counts.hit(r)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		counts.hit(r)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, counts
+}
+
+// stubCounts conta as requisições recebidas pelo servidor de teste, por caminho
+type stubCounts struct {
+	hits map[string]*atomic.Int32
+}
+
+func (s *stubCounts) hit(r *http.Request) {
+	s.hits[r.URL.Path].Add(1)
+}
+
+func (s *stubCounts) count(path string) int {
+	return int(s.hits[path].Load())
+}
+
+func newTestChecker(server *httptest.Server) *Checker {
+	settings := DefaultSettings
+	settings.Mode = ModeFlag
+	return New(settings, server.Client().Transport)
+}
+
+func TestChecker_Check(t *testing.T) {
+	server, _ := newStubServer(t)
+	checker := newTestChecker(server)
+
+	tests := []struct {
+		name       string
+		url        string
+		status     string
+		httpStatus int
+	}{
+		{"existente", server.URL + "/ok", StatusOK, http.StatusOK},
+		{"404", server.URL + "/missing", StatusBroken, http.StatusNotFound},
+		{"410", server.URL + "/gone", StatusBroken, http.StatusGone},
+		{"sem HEAD usa GET", server.URL + "/get-only", StatusOK, http.StatusOK},
+		{"5xx não é conclusivo", server.URL + "/error", StatusUnverified, http.StatusServiceUnavailable},
+		{"esquema inválido", "ftp://example.com/livro.pdf", StatusBroken, 0},
+		{"sem host", "https:///livro", StatusBroken, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checker.Check(context.Background(), tt.url)
+
+			assert.Equal(t, tt.status, result.Status)
+			assert.Equal(t, tt.httpStatus, result.HTTPStatus)
+		})
+	}
+}
+
+func TestChecker_Check_FollowsRedirects(t *testing.T) {
+	server, _ := newStubServer(t)
+	checker := newTestChecker(server)
+
+	result := checker.Check(context.Background(), server.URL+"/moved")
+
+	assert.Equal(t, StatusOK, result.Status)
+	assert.Equal(t, server.URL+"/ok", result.FinalURL)
+}
+
+func TestChecker_Check_Timeout(t *testing.T) {
+	server, _ := newStubServer(t)
+	checker := newTestChecker(server)
+	settings := checker.Settings()
+	settings.Timeout = 50 * time.Millisecond
+	checker.SetSettings(settings)
+
+	start := time.Now()
+	result := checker.Check(context.Background(), server.URL+"/slow")
+
+	assert.Equal(t, StatusUnverified, result.Status)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestChecker_Check_Cache(t *testing.T) {
+	server, counts := newStubServer(t)
+	checker := newTestChecker(server)
+	now := time.Now()
+	checker.now = func() time.Time { return now }
+
+	checker.Check(context.Background(), server.URL+"/missing")
+	checker.Check(context.Background(), server.URL+"/missing")
+	// HEAD e GET na primeira verificação; a segunda vem do cache
+	assert.Equal(t, 2, counts.count("/missing"))
+
+	// Resultados inconclusivos são verificados de novo
+	checker.Check(context.Background(), server.URL+"/error")
+	checker.Check(context.Background(), server.URL+"/error")
+	assert.Equal(t, 2, counts.count("/error"))
+
+	// Depois do TTL, o cache expira
+	now = now.Add(DefaultSettings.CacheTTL)
+	checker.Check(context.Background(), server.URL+"/missing")
+	assert.Equal(t, 4, counts.count("/missing"))
+}
+
+func TestChecker_CheckAll(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	t.Cleanup(server.Close)
+
+	checker := New(Settings{Mode: ModeFlag, Timeout: time.Second, Concurrency: 2, CacheTTL: time.Hour}, server.Client().Transport)
+	urls := []string{server.URL + "/a", server.URL + "/b", server.URL + "/c", server.URL + "/d", server.URL + "/a"}
+
+	results := checker.CheckAll(context.Background(), urls)
+
+	require.Len(t, results, 4)
+	for _, result := range results {
+		assert.Equal(t, StatusOK, result.Status)
+	}
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestPublicTransport_RejectsPrivateAddresses(t *testing.T) {
+	server, counts := newStubServer(t)
+	checker := New(DefaultSettings, nil)
+
+	result := checker.Check(context.Background(), server.URL+"/ok")
+
+	assert.Equal(t, StatusBroken, result.Status)
+	assert.Contains(t, result.Error, "endereço não público")
+	assert.Zero(t, counts.count("/ok"))
+}
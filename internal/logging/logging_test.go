@@ -0,0 +1,47 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactURL(t *testing.T) {
+	redacted := RedactURL("https://example.com/v1beta/models/gemini-pro:generateContent?key=segredo")
+
+	assert.NotContains(t, redacted, "segredo")
+	assert.Contains(t, redacted, "key=REDACTED")
+}
+
+func TestRedactError(t *testing.T) {
+	err := &url.Error{Op: "Post", URL: "https://example.com/models?key=segredo", Err: errors.New("timeout")}
+
+	redacted := RedactError(err)
+
+	assert.NotContains(t, redacted.Error(), "segredo")
+}
+
+func TestNew_AddsRequestIDFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, "debug")
+
+	ctx := WithRequestID(context.Background(), "abc-123")
+	logger.DebugContext(ctx, "mensagem", "model", "gemini-pro")
+
+	var entry map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "abc-123", entry["request_id"])
+	assert.Equal(t, "gemini-pro", entry["model"])
+}
+
+func TestParseLevel(t *testing.T) {
+	assert.Equal(t, slog.LevelDebug, ParseLevel("DEBUG"))
+	assert.Equal(t, slog.LevelWarn, ParseLevel("warn"))
+	assert.Equal(t, slog.LevelInfo, ParseLevel("invalido"))
+}
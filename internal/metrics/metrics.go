@@ -0,0 +1,174 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Resultados possíveis de uma chamada a um modelo
+const (
+	OutcomeOK                 = "ok"
+	OutcomeQuota              = "quota"
+	OutcomeError              = "error"
+	OutcomeParseError         = "parse_error"
+	OutcomeValidationRejected = "validation_rejected"
+	// OutcomeCancelled é uma tentativa interrompida (cliente desistiu ou outra consulta venceu o hedging)
+	OutcomeCancelled = "cancelled"
+)
+
+// Registry é o registro usado pelo endpoint /metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequestsTotal conta as requisições HTTP por rota, método e status
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spellbook",
+		Name:      "http_requests_total",
+		Help:      "Total de requisições HTTP por rota, método e status.",
+	}, []string{"route", "method", "status"})
+
+	// HTTPRequestDuration mede a latência das requisições HTTP
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "spellbook",
+		Name:      "http_request_duration_seconds",
+		Help:      "Latência das requisições HTTP por rota, método e status.",
+		Buckets:   []float64{0.05, 0.1, 0.5, 1, 2.5, 5, 10, 20, 30, 60, 120, 180},
+	}, []string{"route", "method", "status"})
+
+	// ModelCallsTotal conta as chamadas aos modelos por modelo e resultado
+	ModelCallsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spellbook",
+		Name:      "model_calls_total",
+		Help:      "Total de chamadas aos modelos por modelo e resultado.",
+	}, []string{"model", "outcome"})
+
+	// ModelCallDuration mede a latência das chamadas aos modelos
+	ModelCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "spellbook",
+		Name:      "model_call_duration_seconds",
+		Help:      "Latência das chamadas generateContent por modelo.",
+		Buckets:   []float64{0.5, 1, 2.5, 5, 10, 20, 30, 60, 120, 180},
+	}, []string{"model"})
+
+	// ModelRetriesTotal conta as novas tentativas feitas após erro de quota ou API key recusada
+	ModelRetriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spellbook",
+		Name:      "model_retries_total",
+		Help:      "Total de novas tentativas por modelo e motivo.",
+	}, []string{"model", "reason"})
+
+	// ModelCircuitState indica o estado do circuit breaker de cada modelo
+	ModelCircuitState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "spellbook",
+		Name:      "model_circuit_state",
+		Help:      "Estado do circuit breaker por modelo (0 fechado, 1 meio aberto, 2 aberto).",
+	}, []string{"model"})
+
+	// ModelCircuitSkipsTotal conta as tentativas que pularam um modelo com o circuito aberto
+	ModelCircuitSkipsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spellbook",
+		Name:      "model_circuit_skips_total",
+		Help:      "Total de tentativas que pularam o modelo por circuito aberto.",
+	}, []string{"model"})
+
+	// HedgesTotal conta as consultas extras do hedging: lançadas, vencedoras e barradas pelo limite
+	HedgesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spellbook",
+		Name:      "hedges_total",
+		Help:      "Consultas extras do hedging por operação e resultado (launched, won, throttled).",
+	}, []string{"operation", "result"})
+
+	// CoalescedRequestsTotal conta as requisições atendidas por uma geração idêntica já em andamento
+	CoalescedRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spellbook",
+		Name:      "coalesced_requests_total",
+		Help:      "Requisições agrupadas com uma geração idêntica em andamento, por operação.",
+	}, []string{"operation"})
+
+	// LinkChecksTotal conta as URLs de recursos verificadas, por resultado
+	LinkChecksTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spellbook",
+		Name:      "link_checks_total",
+		Help:      "URLs de recursos verificadas por resultado (ok, broken, unverified).",
+	}, []string{"result"})
+
+	// CatalogResourcesTotal conta os recursos do catálogo usados nas respostas: sugeridos pelo
+	// modelo e completados com os dados curados (matched) ou no lugar de uma sugestão (replaced)
+	CatalogResourcesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spellbook",
+		Name:      "catalog_resources_total",
+		Help:      "Recursos do catálogo usados nas respostas por operação e resultado (matched, replaced).",
+	}, []string{"operation", "result"})
+
+	// TrailResourceFixesTotal conta as correções nos recursos das trilhas geradas: recursos
+	// repetidos mesclados e referências inexistentes reparadas ou removidas
+	TrailResourceFixesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spellbook",
+		Name:      "trail_resource_fixes_total",
+		Help:      "Correções nos recursos das trilhas educacionais por tipo (merged, repaired, dropped).",
+	}, []string{"fix"})
+
+	// CacheRequestsTotal conta consultas aos caches internos (hit/miss)
+	CacheRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spellbook",
+		Name:      "cache_requests_total",
+		Help:      "Consultas aos caches internos por cache e resultado (hit ou miss).",
+	}, []string{"cache", "result"})
+
+	// GenerationsInFlight indica quantas gerações estão em andamento
+	GenerationsInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "spellbook",
+		Name:      "generations_in_flight",
+		Help:      "Gerações em andamento por operação.",
+	}, []string{"operation"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestsTotal,
+		HTTPRequestDuration,
+		ModelCallsTotal,
+		ModelCallDuration,
+		ModelRetriesTotal,
+		ModelCircuitState,
+		ModelCircuitSkipsTotal,
+		HedgesTotal,
+		CoalescedRequestsTotal,
+		LinkChecksTotal,
+		CatalogResourcesTotal,
+		TrailResourceFixesTotal,
+		CacheRequestsTotal,
+		GenerationsInFlight,
+	)
+}
+
+// Handler retorna o handler HTTP que expõe as métricas no formato Prometheus
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// TrackInFlight incrementa o gauge de gerações em andamento e retorna a função que o decrementa
+func TrackInFlight(operation string) func() {
+	gauge := GenerationsInFlight.WithLabelValues(operation)
+	gauge.Inc()
+	return gauge.Dec
+}
+
+// RecordModelCall registra o resultado de uma tentativa em um modelo
+func RecordModelCall(model, outcome string) {
+	ModelCallsTotal.WithLabelValues(model, outcome).Inc()
+}
+
+// RecordCache registra um hit ou miss em um cache interno
+func RecordCache(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	CacheRequestsTotal.WithLabelValues(cache, result).Inc()
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/i18n"
+)
+
+// AdminAuth exige o token de administração no header Authorization (Bearer). Sem token
+// configurado, os endpoints de administração ficam desativados. O token pode ser trocado
+// com o servidor em execução.
+type AdminAuth struct {
+	token atomic.Pointer[string]
+}
+
+// NewAdminAuth cria a verificação com o token informado (vazio desativa a administração)
+func NewAdminAuth(token string) *AdminAuth {
+	auth := &AdminAuth{}
+	auth.SetToken(token)
+	return auth
+}
+
+// SetToken troca o token aceito
+func (a *AdminAuth) SetToken(token string) {
+	a.token.Store(&token)
+}
+
+// Middleware responde 401 (problem+json) quando o token está ausente ou não confere
+func (a *AdminAuth) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := *a.token.Load()
+		presented, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if ok && token != "" && subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1 {
+			c.Next()
+			return
+		}
+
+		c.Header("WWW-Authenticate", `Bearer realm="spellbook-admin"`)
+		apperror.Respond(c, i18n.Resolve("", c.GetHeader("Accept-Language")),
+			apperror.New(apperror.KindUnauthenticated, apperror.CodeAdminUnauthorized, "token de administração ausente ou inválido"))
+	}
+}
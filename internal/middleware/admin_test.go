@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	auth := NewAdminAuth("segredo")
+	router := gin.New()
+	router.GET("/admin/keys", auth.Middleware(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	request := func(authorization string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, "/admin/keys", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, request("Bearer segredo").Code)
+
+	w := request("")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, apperror.ContentType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), apperror.CodeAdminUnauthorized)
+	assert.Equal(t, http.StatusUnauthorized, request("Bearer outro").Code)
+	assert.Equal(t, http.StatusUnauthorized, request("segredo").Code)
+
+	// Sem token configurado, a administração fica desativada
+	auth.SetToken("")
+	assert.Equal(t, http.StatusUnauthorized, request("Bearer ").Code)
+
+	auth.SetToken("novo")
+	assert.Equal(t, http.StatusOK, request("Bearer novo").Code)
+}
@@ -1,14 +1,58 @@
 package middleware
 
-import "github.com/gin-gonic/gin"
+import (
+	"slices"
+	"sync/atomic"
 
-// CORSMiddleware configura CORS para permitir requisições de outros serviços
-func CORSMiddleware() gin.HandlerFunc {
+	"github.com/gin-gonic/gin"
+)
+
+// CORS aplica a política de CORS. As origens aceitas podem ser trocadas com o servidor em execução.
+type CORS struct {
+	origins atomic.Pointer[[]string]
+}
+
+// NewCORS cria a política com as origens aceitas ("*" aceita qualquer origem)
+func NewCORS(origins []string) *CORS {
+	cors := &CORS{}
+	cors.SetOrigins(origins)
+	return cors
+}
+
+// SetOrigins troca as origens aceitas
+func (p *CORS) SetOrigins(origins []string) {
+	origins = append([]string(nil), origins...)
+	p.origins.Store(&origins)
+}
+
+// allowOrigin retorna o valor de Access-Control-Allow-Origin para a origem da requisição
+// (vazio quando a origem não é aceita)
+func (p *CORS) allowOrigin(origin string) string {
+	origins := *p.origins.Load()
+	if slices.Contains(origins, "*") {
+		return "*"
+	}
+	if origin != "" && slices.Contains(origins, origin) {
+		return origin
+	}
+	return ""
+}
+
+// Middleware configura CORS para permitir requisições das origens aceitas
+func (p *CORS) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		allowed := p.allowOrigin(c.GetHeader("Origin"))
+		if allowed != "*" {
+			// A resposta depende da origem da requisição
+			c.Writer.Header().Add("Vary", "Origin")
+		}
+		if allowed != "" {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", allowed)
+			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Request-ID")
+			c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, Retry-After")
+			c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		}
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
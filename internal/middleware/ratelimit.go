@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/i18n"
+)
+
+// RateLimiter limita as requisições por cliente (IP) com um token bucket: cada cliente pode
+// fazer uma rajada de Burst requisições e recupera RPS requisições por segundo. O limite
+// pode ser trocado com o servidor em execução; RPS 0 desativa a limitação.
+type RateLimiter struct {
+	mu      sync.Mutex
+	rps     float64
+	burst   int
+	clients map[string]*bucket
+	swept   time.Time
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// clientIdleTTL é por quanto tempo o estado de um cliente sem requisições é mantido
+const clientIdleTTL = 10 * time.Minute
+
+// NewRateLimiter cria um limitador com o ritmo e a rajada informados. Rajada 0 equivale a
+// um segundo de requisições (no mínimo 1).
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	limiter := &RateLimiter{clients: make(map[string]*bucket), now: time.Now}
+	limiter.SetLimit(rps, burst)
+	return limiter
+}
+
+// SetLimit troca o ritmo e a rajada. O estado dos clientes é descartado.
+func (l *RateLimiter) SetLimit(rps float64, burst int) {
+	if burst <= 0 {
+		burst = int(math.Max(1, math.Ceil(rps)))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rps = rps
+	l.burst = burst
+	l.clients = make(map[string]*bucket)
+}
+
+// allow consome n tokens do cliente, todos ou nenhum. Sem tokens suficientes, retorna a espera
+// até eles se acumularem (ou falso para sempre, se n passar da rajada).
+func (l *RateLimiter) allow(client string, n int) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rps <= 0 || n <= 0 {
+		return true, 0
+	}
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.clients[client]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.clients[client] = b
+	}
+
+	b.tokens = math.Min(float64(l.burst), b.tokens+now.Sub(b.last).Seconds()*l.rps)
+	b.last = now
+
+	if b.tokens < float64(n) {
+		return false, time.Duration((float64(n) - b.tokens) / l.rps * float64(time.Second))
+	}
+	b.tokens -= float64(n)
+	return true, 0
+}
+
+// sweep remove clientes ociosos, no máximo uma vez por clientIdleTTL (chamado com o lock)
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < clientIdleTTL {
+		return
+	}
+	l.swept = now
+	for client, b := range l.clients {
+		if now.Sub(b.last) > clientIdleTTL {
+			delete(l.clients, client)
+		}
+	}
+}
+
+// rateLimiterKey guarda o limitador no contexto da requisição, para ChargeRateLimit
+const rateLimiterKey = "rate_limiter"
+
+// Middleware responde 429 (problem+json) com Retry-After quando o cliente excede o limite
+func (l *RateLimiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ok, wait := l.allow(c.ClientIP(), 1)
+		if !ok {
+			rejectRateLimited(c, wait)
+			return
+		}
+		c.Set(rateLimiterKey, l)
+		c.Next()
+	}
+}
+
+// ChargeRateLimit cobra mais n requisições do cliente, além da que o Middleware já cobrou,
+// para rotas que valem por várias gerações (ex: o lote, um token por item). Sem tokens
+// suficientes, nada é cobrado, a resposta 429 é enviada e o retorno é falso. Fora de uma rota
+// com limite, sempre retorna verdadeiro.
+func ChargeRateLimit(c *gin.Context, n int) bool {
+	value, _ := c.Get(rateLimiterKey)
+	l, ok := value.(*RateLimiter)
+	if !ok {
+		return true
+	}
+	allowed, wait := l.allow(c.ClientIP(), n)
+	if !allowed {
+		rejectRateLimited(c, wait)
+	}
+	return allowed
+}
+
+func rejectRateLimited(c *gin.Context, wait time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	apperror.Respond(c, i18n.Resolve("", c.GetHeader("Accept-Language")),
+		apperror.New(apperror.KindRateLimited, apperror.CodeRateLimited, "limite de requisições excedido"))
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(1, 2)
+	limiter.now = func() time.Time { return now }
+
+	router := gin.New()
+	router.Use(limiter.Middleware())
+	router.POST("/api/v1/topics", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	request := func(ip string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/topics", nil)
+		req.RemoteAddr = ip + ":12345"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Rajada de 2 requisições; a terceira excede o limite
+	assert.Equal(t, http.StatusOK, request("10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, request("10.0.0.1").Code)
+
+	w := request("10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.Equal(t, apperror.ContentType, w.Header().Get("Content-Type"))
+
+	var problem apperror.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, apperror.CodeRateLimited, problem.Code)
+
+	// Cada cliente tem seu próprio limite
+	assert.Equal(t, http.StatusOK, request("10.0.0.2").Code)
+
+	// Após um segundo, o cliente recupera uma requisição
+	now = now.Add(time.Second)
+	assert.Equal(t, http.StatusOK, request("10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, request("10.0.0.1").Code)
+
+	// RPS 0 desativa a limitação
+	limiter.SetLimit(0, 0)
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, request("10.0.0.1").Code)
+	}
+}
+
+func TestChargeRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(1, 5)
+	limiter.now = func() time.Time { return now }
+
+	router := gin.New()
+	router.POST("/api/v1/batch", limiter.Middleware(), func(c *gin.Context) {
+		if ChargeRateLimit(c, 3) {
+			c.Status(http.StatusOK)
+		}
+	})
+	request := func() *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/batch", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// 1 token do middleware e 3 da rota; sobra 1, que não basta para a próxima
+	assert.Equal(t, http.StatusOK, request().Code)
+	w := request()
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "3", w.Header().Get("Retry-After"))
+
+	// Fora de uma rota com limite, não cobra nada
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	assert.True(t, ChargeRateLimit(c, 100))
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/i18n"
+	"github.com/spellbook/spellbook/internal/openapi"
+)
+
+// RequestValidationMiddleware valida o corpo das requisições contra o schema da operação no
+// documento OpenAPI e responde 400 (problem+json) com os campos inválidos antes do handler
+func RequestValidationMiddleware(spec *openapi.Spec) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := spec.RequestSchema(c.Request.Method, c.FullPath()); !ok {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			apperror.Respond(c, i18n.Resolve("", c.GetHeader("Accept-Language")), err)
+			return
+		}
+		// Devolver o corpo para o handler fazer o bind
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		fields := spec.ValidateRequest(c.Request.Method, c.FullPath(), body)
+		if len(fields) == 0 {
+			c.Next()
+			return
+		}
+
+		var req struct {
+			Language string `json:"language"`
+		}
+		_ = json.Unmarshal(body, &req)
+		lang := i18n.Resolve(req.Language, c.GetHeader("Accept-Language"))
+		c.Header("Content-Language", lang)
+
+		appErr := apperror.New(apperror.KindValidation, apperror.CodeRequestInvalid, "requisição não segue o schema")
+		appErr.Fields = fields
+		apperror.Respond(c, lang, appErr)
+	}
+}
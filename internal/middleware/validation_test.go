@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestValidationMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var received string
+	router := gin.New()
+	router.Use(RequestValidationMiddleware(openapi.Load()))
+	router.POST("/api/v1/topics", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		received = string(body)
+		c.Status(http.StatusOK)
+	})
+
+	// Requisição válida chega ao handler com o corpo intacto
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/topics", bytes.NewBufferString(`{"subject": "Go"}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"subject": "Go"}`, received)
+
+	// Requisição inválida é rejeitada com os campos no idioma da requisição
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/api/v1/topics", bytes.NewBufferString(`{"subject": "Go", "count": -3, "language": "en"}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, apperror.ContentType, w.Header().Get("Content-Type"))
+
+	var problem apperror.Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, apperror.CodeRequestInvalid, problem.Code)
+	assert.Equal(t, "the request does not match the API schema", problem.Detail)
+	if assert.Len(t, problem.Errors, 1) {
+		assert.Equal(t, "/count", problem.Errors[0].Field)
+	}
+}
+
+func TestRequestValidationMiddleware_PathParameter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	called := false
+	router := gin.New()
+	router.Use(RequestValidationMiddleware(openapi.Load()))
+	router.POST("/api/v1/spells/:name", func(c *gin.Context) {
+		called = true
+		c.Status(http.StatusOK)
+	})
+
+	// O documento declara /api/v1/spells/{name}; a rota do gin é /api/v1/spells/:name
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/spells/glossary", bytes.NewBufferString(`["Go"]`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.False(t, called)
+	var problem apperror.Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, apperror.CodeRequestInvalid, problem.Code)
+}
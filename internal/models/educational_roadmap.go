@@ -8,20 +8,25 @@ type EducationalResource struct {
 	Chapters    []string `json:"chapters,omitempty"`
 	Duration    string   `json:"duration,omitempty"`
 	Author      string   `json:"author,omitempty"`
+	// Source é "catalog" quando o recurso vem do catálogo curado
+	Source string `json:"source,omitempty"`
+	// LinkStatus é o resultado da verificação da URL (ok, broken, unverified), quando ativa
+	LinkStatus string `json:"link_status,omitempty"`
 }
 
 // EducationalRoadmap representa um roadmap educacional completo
 type EducationalRoadmap struct {
-	Topic    string                `json:"topic"`
-	Books    []EducationalResource `json:"books"`
-	Courses  []EducationalResource `json:"courses"`
-	Videos   []EducationalResource `json:"videos"`
-	Articles []EducationalResource `json:"articles"`
-	Projects []EducationalResource `json:"projects"`
+	Topic         string                `json:"topic"`
+	Books         []EducationalResource `json:"books"`
+	Courses       []EducationalResource `json:"courses"`
+	Videos        []EducationalResource `json:"videos"`
+	Articles      []EducationalResource `json:"articles"`
+	Projects      []EducationalResource `json:"projects"`
+	PromptVersion string                `json:"prompt_version,omitempty"` // Versão do template de prompt usado na geração
 }
 
 // EducationalRoadmapRequest representa a requisição para gerar um roadmap educacional
 type EducationalRoadmapRequest struct {
-	Topic string `json:"topic" binding:"required"`
+	Topic    string `json:"topic" binding:"required"`
+	Language string `json:"language,omitempty"` // Idioma da resposta em BCP 47 (ex: pt-BR, en, es)
 }
-
@@ -5,10 +5,12 @@ type KeyResultsRequest struct {
 	Objective      string  `json:"objective" binding:"required"`
 	Count          int     `json:"count"`
 	CompletionDate *string `json:"completion_date,omitempty"`
+	Language       string  `json:"language,omitempty"` // Idioma da resposta em BCP 47 (ex: pt-BR, en, es)
 }
 
 // KeyResultsResponse representa a resposta com lista de Key Results
 type KeyResultsResponse struct {
-	Objective  string   `json:"objective"`
-	KeyResults []string `json:"key_results"`
+	Objective     string   `json:"objective"`
+	KeyResults    []string `json:"key_results"`
+	PromptVersion string   `json:"prompt_version,omitempty"` // Versão do template de prompt usado na geração
 }
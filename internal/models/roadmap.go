@@ -15,14 +15,15 @@ type RoadmapCategory struct {
 
 // Roadmap representa o roadmap completo
 type Roadmap struct {
-	Topic   string            `json:"topic"`
-	Roadmap []RoadmapCategory `json:"roadmap"`
+	Topic         string            `json:"topic"`
+	Roadmap       []RoadmapCategory `json:"roadmap"`
+	PromptVersion string            `json:"prompt_version,omitempty"` // Versão do template de prompt usado na geração
 }
 
 // RoadmapRequest representa a requisição para gerar um roadmap
 type RoadmapRequest struct {
-	Topic        string `json:"topic" binding:"required"`
-	AvailableDays *int  `json:"available_days,omitempty"`
-	ExactItemCount *int `json:"exact_item_count,omitempty"` // Número exato de itens a serem gerados
+	Topic          string `json:"topic" binding:"required"`
+	AvailableDays  *int   `json:"available_days,omitempty"`
+	ExactItemCount *int   `json:"exact_item_count,omitempty"` // Número exato de itens a serem gerados
+	Language       string `json:"language,omitempty"`         // Idioma da resposta em BCP 47 (ex: pt-BR, en, es)
 }
-
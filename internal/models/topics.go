@@ -2,13 +2,14 @@ package models
 
 // TopicsRequest representa a requisição para gerar tópicos
 type TopicsRequest struct {
-	Subject string `json:"subject" binding:"required"`
-	Count   int    `json:"count"`
+	Subject  string `json:"subject" binding:"required"`
+	Count    int    `json:"count"`
+	Language string `json:"language,omitempty"` // Idioma da resposta em BCP 47 (ex: pt-BR, en, es)
 }
 
 // TopicsResponse representa a resposta com lista de tópicos
 type TopicsResponse struct {
-	Subject string   `json:"subject"`
-	Topics  []string `json:"topics"`
+	Subject       string   `json:"subject"`
+	Topics        []string `json:"topics"`
+	PromptVersion string   `json:"prompt_version,omitempty"` // Versão do template de prompt usado na geração
 }
-
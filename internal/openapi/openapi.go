@@ -0,0 +1,135 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+//go:embed openapi.json
+var document []byte
+
+//go:embed docs.html
+var docsPage []byte
+
+// Document retorna o documento OpenAPI 3.1 da API
+func Document() []byte {
+	return document
+}
+
+// DocsPage retorna a página HTML que renderiza o documento com o Swagger UI
+func DocsPage() []byte {
+	return docsPage
+}
+
+// Spec é o documento OpenAPI carregado, com os schemas do corpo de cada operação
+type Spec struct {
+	schemas    map[string]*Schema
+	operations map[string]*Schema
+}
+
+type rawDocument struct {
+	Paths map[string]map[string]struct {
+		RequestBody *struct {
+			Content map[string]struct {
+				Schema *Schema `json:"schema"`
+			} `json:"content"`
+		} `json:"requestBody"`
+	} `json:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// Load carrega o documento embutido. Entra em pânico se ele for inválido, pois é erro de programação.
+func Load() *Spec {
+	spec, err := Parse(document)
+	if err != nil {
+		panic(err)
+	}
+	return spec
+}
+
+// Parse carrega um documento OpenAPI e resolve as referências dos schemas de requisição
+func Parse(data []byte) (*Spec, error) {
+	var raw rawDocument
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("documento OpenAPI inválido: %w", err)
+	}
+
+	spec := &Spec{
+		schemas:    raw.Components.Schemas,
+		operations: make(map[string]*Schema),
+	}
+
+	for path, methods := range raw.Paths {
+		for method, operation := range methods {
+			if operation.RequestBody == nil {
+				continue
+			}
+			media, ok := operation.RequestBody.Content["application/json"]
+			if !ok || media.Schema == nil {
+				continue
+			}
+			if err := spec.checkRefs(media.Schema); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			spec.operations[operationKey(method, ginPath(path))] = media.Schema
+		}
+	}
+
+	return spec, nil
+}
+
+// RequestSchema retorna o schema do corpo da operação, se houver. O caminho segue a sintaxe
+// das rotas do gin (c.FullPath(), ex: /api/v1/spells/:name).
+func (s *Spec) RequestSchema(method, path string) (*Schema, bool) {
+	schema, ok := s.operations[operationKey(method, path)]
+	return schema, ok
+}
+
+// resolve segue uma referência #/components/schemas/<nome>
+func (s *Spec) resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = s.schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+	return schema
+}
+
+// checkRefs garante que todas as referências do schema existem
+func (s *Spec) checkRefs(schema *Schema) error {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		target, ok := s.schemas[name]
+		if !ok {
+			return fmt.Errorf("referência %q não encontrada", schema.Ref)
+		}
+		return s.checkRefs(target)
+	}
+	for _, property := range schema.Properties {
+		if err := s.checkRefs(property); err != nil {
+			return err
+		}
+	}
+	if err := s.checkRefs(schema.Items); err != nil {
+		return err
+	}
+	return s.checkRefs(schema.AdditionalProperties)
+}
+
+func operationKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+// pathParam casa os parâmetros de caminho do OpenAPI (ex: {name})
+var pathParam = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// ginPath converte o caminho do documento para a sintaxe das rotas do gin ({name} vira :name)
+func ginPath(path string) string {
+	return pathParam.ReplaceAllString(path, ":$1")
+}
@@ -0,0 +1,138 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_AllGenerationEndpointsHaveRequestSchema(t *testing.T) {
+	spec := Load()
+
+	for _, path := range []string{
+		"/api/v1/roadmap",
+		"/api/v1/topics",
+		"/api/v1/key-results",
+		"/api/v1/educational-roadmap",
+		"/api/v1/educational-trail",
+		"/api/v1/batch",
+		"/api/v1/spells/:name",
+	} {
+		_, ok := spec.RequestSchema("POST", path)
+		assert.True(t, ok, path)
+	}
+
+	_, ok := spec.RequestSchema("GET", "/health")
+	assert.False(t, ok)
+}
+
+// Os schemas devem acompanhar os campos JSON dos modelos em internal/models
+func TestSchemas_MatchModels(t *testing.T) {
+	spec := Load()
+
+	for name, model := range map[string]interface{}{
+		"RoadmapRequest":            models.RoadmapRequest{},
+		"Roadmap":                   models.Roadmap{},
+		"RoadmapCategory":           models.RoadmapCategory{},
+		"RoadmapItem":               models.RoadmapItem{},
+		"TopicsRequest":             models.TopicsRequest{},
+		"TopicsResponse":            models.TopicsResponse{},
+		"KeyResultsRequest":         models.KeyResultsRequest{},
+		"KeyResultsResponse":        models.KeyResultsResponse{},
+		"EducationalRoadmapRequest": models.EducationalRoadmapRequest{},
+		"EducationalRoadmap":        models.EducationalRoadmap{},
+		"EducationalResource":       models.EducationalResource{},
+		"EducationalTrailRequest":   models.EducationalTrailRequest{},
+		"EducationalTrail":          models.EducationalTrail{},
+		"EducationalTrailStep":      models.EducationalTrailStep{},
+		"Activity":                  models.Activity{},
+	} {
+		schema, ok := spec.schemas[name]
+		if !assert.True(t, ok, name) {
+			continue
+		}
+
+		expected := jsonFields(reflect.TypeOf(model))
+		actual := make([]string, 0, len(schema.Properties))
+		for property := range schema.Properties {
+			actual = append(actual, property)
+		}
+		sort.Strings(actual)
+
+		assert.Equal(t, expected, actual, name)
+	}
+}
+
+func jsonFields(typ reflect.Type) []string {
+	fields := make([]string, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		name := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func TestDocument_IsOpenAPI31(t *testing.T) {
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+	}
+	require.NoError(t, json.Unmarshal(Document(), &doc))
+
+	assert.Equal(t, "3.1.0", doc.OpenAPI)
+}
+
+func TestParse_MissingReference(t *testing.T) {
+	_, err := Parse([]byte(`{
+		"paths": {"/x": {"post": {"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Nada"}}}}}}},
+		"components": {"schemas": {}}
+	}`))
+
+	assert.Error(t, err)
+}
+
+func TestValidateRequest(t *testing.T) {
+	spec := Load()
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		fields []string
+	}{
+		{name: "tópicos válido", path: "/api/v1/topics", body: `{"subject": "Go", "count": 5, "language": "en-US"}`},
+		{name: "count negativo", path: "/api/v1/topics", body: `{"subject": "Go", "count": -1}`, fields: []string{"/count"}},
+		{name: "count fracionado", path: "/api/v1/topics", body: `{"subject": "Go", "count": 2.5}`, fields: []string{"/count"}},
+		{name: "assunto ausente", path: "/api/v1/topics", body: `{"count": 5}`, fields: []string{"/subject"}},
+		{name: "data válida", path: "/api/v1/key-results", body: `{"objective": "Crescer", "completion_date": "2026-12-31"}`},
+		{name: "data malformada", path: "/api/v1/key-results", body: `{"objective": "Crescer", "completion_date": "31/12/2026"}`, fields: []string{"/completion_date"}},
+		{name: "dias nulos", path: "/api/v1/educational-trail", body: `{"topic": "SQL", "available_days": null}`},
+		{name: "dias como texto", path: "/api/v1/roadmap", body: `{"topic": "SQL", "available_days": "10"}`, fields: []string{"/available_days"}},
+		{name: "idioma inválido", path: "/api/v1/educational-roadmap", body: `{"topic": "SQL", "language": "português"}`, fields: []string{"/language"}},
+		{name: "JSON inválido", path: "/api/v1/roadmap", body: `{"topic":`, fields: []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := spec.ValidateRequest("POST", tt.path, []byte(tt.body))
+
+			fields := make([]string, 0, len(errs))
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			if tt.fields == nil {
+				assert.Empty(t, errs)
+				return
+			}
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+)
+
+// Schema é o subconjunto de JSON Schema usado nos corpos de requisição da API
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	// Default é o valor usado quando a propriedade não é enviada (só documentação na API)
+	Default interface{} `json:"default,omitempty"`
+}
+
+// Types aceita "type" como texto ou lista (ex: ["integer", "null"]), como no OpenAPI 3.1
+type Types []string
+
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// ValidateRequest valida o corpo JSON da operação. Operações sem schema não são validadas.
+func (s *Spec) ValidateRequest(method, path string, body []byte) []apperror.FieldError {
+	schema, ok := s.RequestSchema(method, path)
+	if !ok {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []apperror.FieldError{{Field: "", Message: "corpo JSON inválido"}}
+	}
+
+	var errs []apperror.FieldError
+	s.validate(schema, value, "", &errs)
+	return errs
+}
+
+// Validate valida um valor JSON já decodificado contra um schema avulso, sem referências ($ref)
+func (schema *Schema) Validate(value interface{}) []apperror.FieldError {
+	var errs []apperror.FieldError
+	(&Spec{}).validate(schema, value, "", &errs)
+	return errs
+}
+
+func (s *Spec) validate(schema *Schema, value interface{}, pointer string, errs *[]apperror.FieldError) {
+	schema = s.resolve(schema)
+	if schema == nil {
+		return
+	}
+
+	addError := func(format string, args ...interface{}) {
+		*errs = append(*errs, apperror.FieldError{Field: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(schema.Type) > 0 && !matchesType(schema.Type, value) {
+		addError("deve ser do tipo %s", strings.Join(schema.Type, " ou "))
+		return
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		addError("valor não permitido")
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, ok := v[name]; !ok {
+				*errs = append(*errs, apperror.FieldError{Field: pointer + "/" + name, Message: "campo obrigatório"})
+			}
+		}
+		// Ordenar para que as mensagens sejam estáveis
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := schema.Properties[name]; ok {
+				s.validate(property, v[name], pointer+"/"+name, errs)
+			} else if schema.AdditionalProperties != nil {
+				s.validate(schema.AdditionalProperties, v[name], pointer+"/"+name, errs)
+			}
+		}
+
+	case []interface{}:
+		if schema.MinItems != nil && len(v) < *schema.MinItems {
+			addError("deve ter pelo menos %d itens", *schema.MinItems)
+		}
+		if schema.MaxItems != nil && len(v) > *schema.MaxItems {
+			addError("deve ter no máximo %d itens", *schema.MaxItems)
+		}
+		if schema.Items != nil {
+			for i, item := range v {
+				s.validate(schema.Items, item, fmt.Sprintf("%s/%d", pointer, i), errs)
+			}
+		}
+
+	case string:
+		length := utf8.RuneCountInString(v)
+		if schema.MinLength != nil && length < *schema.MinLength {
+			addError("deve ter pelo menos %d caracteres", *schema.MinLength)
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			addError("deve ter no máximo %d caracteres", *schema.MaxLength)
+		}
+		if schema.Pattern != "" {
+			if re, err := regexp.Compile(schema.Pattern); err == nil && !re.MatchString(v) {
+				addError("formato inválido")
+			}
+		}
+		if schema.Format == "date" {
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				addError("deve ser uma data no formato AAAA-MM-DD")
+			}
+		}
+
+	case float64:
+		if schema.Minimum != nil && v < *schema.Minimum {
+			addError("deve ser maior ou igual a %v", *schema.Minimum)
+		}
+		if schema.Maximum != nil && v > *schema.Maximum {
+			addError("deve ser menor ou igual a %v", *schema.Maximum)
+		}
+	}
+}
+
+// matchesType verifica se o valor decodificado corresponde a algum dos tipos JSON Schema
+func matchesType(types Types, value interface{}) bool {
+	for _, typ := range types {
+		switch typ {
+		case "null":
+			if value == nil {
+				return true
+			}
+		case "object":
+			if _, ok := value.(map[string]interface{}); ok {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]interface{}); ok {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "number":
+			if _, ok := value.(float64); ok {
+				return true
+			}
+		case "integer":
+			if n, ok := value.(float64); ok && n == math.Trunc(n) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
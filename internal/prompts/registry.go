@@ -0,0 +1,223 @@
+package prompts
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/spellbook/spellbook/internal/i18n"
+)
+
+//go:embed templates
+var embeddedTemplates embed.FS
+
+// templateFileName segue o padrão <nome>.v<versão>.tmpl (ex: roadmap.v1.tmpl)
+var templateFileName = regexp.MustCompile(`^([a-z0-9_]+)\.v(\d+)\.tmpl$`)
+
+// Prompt é o resultado da renderização de um template
+type Prompt struct {
+	Name     string
+	Version  string
+	Language string
+	Text     string
+}
+
+// Registry guarda os templates de prompt por idioma, nome e versão
+type Registry struct {
+	templates map[string]map[string]map[int]*template.Template
+}
+
+// Default retorna um registry apenas com os templates embutidos no binário.
+// Entra em pânico se algum template embutido for inválido, pois é erro de programação.
+func Default() *Registry {
+	registry, err := NewRegistry("")
+	if err != nil {
+		panic(err)
+	}
+	return registry
+}
+
+// NewRegistry carrega os templates embutidos e, se overrideDir não for vazio, os templates
+// desse diretório. Arquivos do diretório substituem os embutidos com mesmo idioma, nome e versão.
+// Cada subdiretório é um idioma (ex: en/topics.v2.tmpl); arquivos na raiz valem para o idioma padrão.
+func NewRegistry(overrideDir string) (*Registry, error) {
+	registry := &Registry{templates: make(map[string]map[string]map[int]*template.Template)}
+
+	embedded, err := fs.Sub(embeddedTemplates, "templates")
+	if err != nil {
+		return nil, err
+	}
+	if err := registry.loadLanguages(embedded); err != nil {
+		return nil, err
+	}
+
+	if overrideDir != "" {
+		override := os.DirFS(overrideDir)
+		if err := registry.load(override, i18n.DefaultLanguage); err != nil {
+			return nil, fmt.Errorf("erro ao carregar prompts de %s: %w", overrideDir, err)
+		}
+		if err := registry.loadLanguages(override); err != nil {
+			return nil, fmt.Errorf("erro ao carregar prompts de %s: %w", overrideDir, err)
+		}
+	}
+
+	return registry, nil
+}
+
+// loadLanguages carrega cada subdiretório da raiz como os templates de um idioma
+func (r *Registry) loadLanguages(fsys fs.FS) error {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		languageFS, err := fs.Sub(fsys, entry.Name())
+		if err != nil {
+			return err
+		}
+		if err := r.load(languageFS, entry.Name()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// load lê todos os arquivos .tmpl da raiz do sistema de arquivos para o idioma informado
+func (r *Registry) load(fsys fs.FS, language string) error {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := templateFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		name := match[1]
+		version, _ := strconv.Atoi(match[2])
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return err
+		}
+
+		tmpl, err := template.New(entry.Name()).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return fmt.Errorf("template %s/%s inválido: %w", language, entry.Name(), err)
+		}
+
+		if r.templates[language] == nil {
+			r.templates[language] = make(map[string]map[int]*template.Template)
+		}
+		if r.templates[language][name] == nil {
+			r.templates[language][name] = make(map[int]*template.Template)
+		}
+		r.templates[language][name][version] = tmpl
+	}
+
+	return nil
+}
+
+// Render renderiza a versão mais recente do template no idioma informado. Quando o idioma
+// não tem o template, usa o idioma padrão (pt-BR).
+func (r *Registry) Render(language, name string, vars map[string]interface{}) (Prompt, error) {
+	language = r.resolveLanguage(language, name)
+	versions := r.Versions(language, name)
+	if len(versions) == 0 {
+		return Prompt{}, fmt.Errorf("template de prompt %q não encontrado", name)
+	}
+	return r.RenderVersion(language, name, versions[len(versions)-1], vars)
+}
+
+// RenderVersion renderiza uma versão específica do template (ex: "v1") no idioma informado
+func (r *Registry) RenderVersion(language, name, version string, vars map[string]interface{}) (Prompt, error) {
+	number, err := strconv.Atoi(strings.TrimPrefix(version, "v"))
+	if err != nil || !strings.HasPrefix(version, "v") {
+		return Prompt{}, fmt.Errorf("versão de prompt inválida: %q", version)
+	}
+
+	language = r.resolveLanguage(language, name)
+	tmpl, ok := r.templates[language][name][number]
+	if !ok {
+		return Prompt{}, fmt.Errorf("template de prompt %q versão %s não encontrado", name, version)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return Prompt{}, fmt.Errorf("erro ao renderizar prompt %s@%s: %w", name, version, err)
+	}
+
+	return Prompt{Name: name, Version: version, Language: language, Text: buf.String()}, nil
+}
+
+// resolveLanguage devolve o idioma se ele tiver o template, senão o idioma padrão
+func (r *Registry) resolveLanguage(language, name string) string {
+	if len(r.templates[language][name]) > 0 {
+		return language
+	}
+	return i18n.DefaultLanguage
+}
+
+// Versions retorna as versões disponíveis de um template no idioma, em ordem crescente
+func (r *Registry) Versions(language, name string) []string {
+	numbers := make([]int, 0, len(r.templates[language][name]))
+	for number := range r.templates[language][name] {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	versions := make([]string, len(numbers))
+	for i, number := range numbers {
+		versions[i] = "v" + strconv.Itoa(number)
+	}
+	return versions
+}
+
+// Names retorna os nomes de todos os templates registrados, em ordem alfabética
+func (r *Registry) Names() []string {
+	seen := make(map[string]bool)
+	for _, templates := range r.templates {
+		for name := range templates {
+			seen[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Languages retorna os idiomas que possuem templates, em ordem alfabética
+func (r *Registry) Languages() []string {
+	languages := make([]string, 0, len(r.templates))
+	for language := range r.templates {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// String identifica o prompt no formato nome@versão, usado em logs e traces
+func (p Prompt) String() string {
+	return p.Name + "@" + p.Version
+}
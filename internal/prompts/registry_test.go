@@ -0,0 +1,159 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault_RendersEmbeddedTemplates(t *testing.T) {
+	registry := Default()
+
+	tests := []struct {
+		name     string
+		vars     map[string]interface{}
+		contains []string
+	}{
+		{
+			name: "roadmap",
+			vars: map[string]interface{}{
+				"Topic": "Go", "TargetItemCount": 10, "DaysAvailable": 10,
+				"NumCategories": "3-4", "ItemsPerCategory": "3-5", "Pace": "short",
+			},
+			contains: []string{`sobre: "Go"`, "EXATAMENTE 10 itens", "Priorize apenas o ESSENCIAL"},
+		},
+		{
+			name:     "topics",
+			vars:     map[string]interface{}{"Subject": "Python", "Count": 7},
+			contains: []string{"lista de 7 tópicos", `"subject": "Python"`},
+		},
+		{
+			name: "key_results",
+			vars: map[string]interface{}{
+				"Objective": "Crescer", "Count": 3, "CompletionDate": "2020-01-01",
+				"DaysRemaining": -10, "MonthsRemaining": 0, "Deadline": "past",
+			},
+			contains: []string{"lista de 3 Key Results", "(2020-01-01) já passou", "realizáveis imediatamente"},
+		},
+		{
+			name:     "educational_roadmap",
+			vars:     map[string]interface{}{"Topic": "Rust"},
+			contains: []string{`"topic": "Rust"`},
+		},
+		{
+			name: "educational_trail",
+			vars: map[string]interface{}{
+				"Topic": "SQL", "TotalDays": 5, "ActivitiesPerDay": "1-2", "ProjectsFromDay": 4, "Pace": "short",
+			},
+			contains: []string{"trilha educacional de 5 dias", "EXATAMENTE 5 dias, 1-2 atividades por dia", "PRAZO LIMITADO", "só a partir do dia 4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompt, err := registry.Render("pt-BR", tt.name, tt.vars)
+			require.NoError(t, err)
+			assert.Equal(t, "v1", prompt.Version)
+			assert.Equal(t, "pt-BR", prompt.Language)
+			for _, expected := range tt.contains {
+				assert.Contains(t, prompt.Text, expected)
+			}
+		})
+	}
+}
+
+func TestRender_MissingVariable(t *testing.T) {
+	_, err := Default().Render("pt-BR", "topics", map[string]interface{}{"Subject": "Go"})
+
+	assert.Error(t, err)
+}
+
+func TestRender_UnknownTemplate(t *testing.T) {
+	_, err := Default().Render("pt-BR", "inexistente", nil)
+
+	assert.Error(t, err)
+}
+
+func TestNewRegistry_OverrideDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "topics.v2.tmpl"), []byte("Liste {{.Count}} tópicos de {{.Subject}}"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignorado"), 0o644))
+
+	registry, err := NewRegistry(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"v1", "v2"}, registry.Versions("pt-BR", "topics"))
+
+	prompt, err := registry.Render("pt-BR", "topics", map[string]interface{}{"Subject": "Go", "Count": 3})
+	require.NoError(t, err)
+	assert.Equal(t, "v2", prompt.Version)
+	assert.Equal(t, "Liste 3 tópicos de Go", prompt.Text)
+	assert.Equal(t, "topics@v2", prompt.String())
+
+	// A versão anterior continua disponível
+	previous, err := registry.RenderVersion("pt-BR", "topics", "v1", map[string]interface{}{"Subject": "Go", "Count": 3})
+	require.NoError(t, err)
+	assert.Contains(t, previous.Text, "lista de 3 tópicos")
+}
+
+func TestDefault_AllLanguagesHaveAllTemplates(t *testing.T) {
+	registry := Default()
+
+	assert.Equal(t, []string{"en", "es", "pt-BR"}, registry.Languages())
+	for _, language := range registry.Languages() {
+		for _, name := range registry.Names() {
+			assert.NotEmpty(t, registry.Versions(language, name), "%s/%s", language, name)
+		}
+	}
+}
+
+func TestRender_Language(t *testing.T) {
+	registry := Default()
+	vars := map[string]interface{}{"Subject": "Python", "Count": 7}
+
+	english, err := registry.Render("en", "topics", vars)
+	require.NoError(t, err)
+	assert.Equal(t, "en", english.Language)
+	assert.Contains(t, english.Text, "list of 7 important and relevant topics")
+
+	spanish, err := registry.Render("es", "topics", vars)
+	require.NoError(t, err)
+	assert.Equal(t, "es", spanish.Language)
+	assert.Contains(t, spanish.Text, "lista de 7 tópicos importantes")
+}
+
+func TestRender_UnknownLanguageFallsBackToDefault(t *testing.T) {
+	prompt, err := Default().Render("fr", "topics", map[string]interface{}{"Subject": "Go", "Count": 3})
+
+	require.NoError(t, err)
+	assert.Equal(t, "pt-BR", prompt.Language)
+	assert.Contains(t, prompt.Text, "lista de 3 tópicos")
+}
+
+func TestNewRegistry_OverrideLanguageDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "en"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "en", "topics.v2.tmpl"), []byte("List {{.Count}} topics about {{.Subject}}"), 0o644))
+
+	registry, err := NewRegistry(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"v1", "v2"}, registry.Versions("en", "topics"))
+	assert.Equal(t, []string{"v1"}, registry.Versions("pt-BR", "topics"))
+
+	prompt, err := registry.Render("en", "topics", map[string]interface{}{"Subject": "Go", "Count": 3})
+	require.NoError(t, err)
+	assert.Equal(t, "List 3 topics about Go", prompt.Text)
+}
+
+func TestNewRegistry_InvalidOverrideTemplate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "topics.v2.tmpl"), []byte("{{.Subject"), 0o644))
+
+	_, err := NewRegistry(dir)
+
+	assert.Error(t, err)
+}
@@ -3,29 +3,56 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/spellbook/spellbook/internal/handlers"
+	"github.com/spellbook/spellbook/internal/metrics"
 	"github.com/spellbook/spellbook/internal/middleware"
+	"github.com/spellbook/spellbook/internal/openapi"
 )
 
 // SetupRoutes configura todas as rotas da aplicação
-func SetupRoutes(router *gin.Engine, roadmapHandler *handlers.RoadmapHandler, topicsHandler *handlers.TopicsHandler, keyResultsHandler *handlers.KeyResultsHandler) {
+func SetupRoutes(router *gin.Engine, roadmapHandler *handlers.RoadmapHandler, topicsHandler *handlers.TopicsHandler, keyResultsHandler *handlers.KeyResultsHandler, batchHandler *handlers.BatchHandler, healthHandler *handlers.HealthHandler, adminHandler *handlers.AdminHandler, spellsHandler *handlers.SpellsHandler, cors *middleware.CORS, rateLimiter *middleware.RateLimiter, adminAuth *middleware.AdminAuth) {
 	// Aplicar middleware global
-	router.Use(middleware.CORSMiddleware())
-
-	// Health check
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "ok",
-			"service": "spellbook",
-		})
+	router.Use(middleware.TracingMiddleware())
+	router.Use(middleware.RequestIDMiddleware())
+	router.Use(middleware.LoggerMiddleware())
+	router.Use(cors.Middleware())
+	router.Use(middleware.MetricsMiddleware())
+	router.Use(middleware.RequestValidationMiddleware(openapi.Load()))
+
+	// Métricas no formato Prometheus
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// Documentação da API (OpenAPI 3.1)
+	router.GET("/openapi.json", func(c *gin.Context) {
+		c.Data(200, "application/json", openapi.Document())
 	})
+	router.GET("/docs", func(c *gin.Context) {
+		c.Data(200, "text/html; charset=utf-8", openapi.DocsPage())
+	})
+
+	// Health check (liveness), readiness e health check detalhado
+	router.GET("/health", healthHandler.Health)
+	router.GET("/health/deep", healthHandler.DeepHealth)
+	router.GET("/ready", healthHandler.Ready)
 
-	// Rotas da API com prefixo /api/v1
-	api := router.Group("/api/v1")
+	// Rotas da API com prefixo /api/v1, com limite de requisições por cliente
+	api := router.Group("/api/v1", rateLimiter.Middleware())
 	{
 		api.POST("/roadmap", roadmapHandler.GenerateRoadmap)
 		api.POST("/topics", topicsHandler.GenerateTopics)
 		api.POST("/key-results", keyResultsHandler.GenerateKeyResults)
 		api.POST("/educational-roadmap", roadmapHandler.GenerateEducationalRoadmap)
 		api.POST("/educational-trail", roadmapHandler.GenerateEducationalTrail)
+		api.POST("/batch", batchHandler.RunBatch)
+		api.GET("/jobs/:id", batchHandler.GetJob)
+
+		// Gerações definidas em arquivos (SPELLS_DIR)
+		api.GET("/spells", spellsHandler.ListSpells)
+		api.POST("/spells/:name", spellsHandler.CastSpell)
+	}
+
+	// Administração, protegida pelo token em ADMIN_TOKEN
+	admin := router.Group("/admin", adminAuth.Middleware())
+	{
+		admin.GET("/keys", adminHandler.KeyUsage)
 	}
 }
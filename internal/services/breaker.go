@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/metrics"
+)
+
+// Estados do circuit breaker de um modelo
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// BreakerSettings define quando o circuito de um modelo abre e como ele se recupera
+type BreakerSettings struct {
+	// FailureThreshold é o número de falhas seguidas que abre o circuito (0 desativa o breaker)
+	FailureThreshold int
+	// OpenDuration é por quanto tempo o modelo é pulado antes de uma tentativa de teste
+	OpenDuration time.Duration
+	// HalfOpenSuccesses é o número de tentativas de teste com sucesso que fecha o circuito
+	HalfOpenSuccesses int
+}
+
+// DefaultBreakerSettings: abre após 5 falhas seguidas, testa o modelo de novo após 30
+// segundos e fecha com 1 teste bem-sucedido
+var DefaultBreakerSettings = BreakerSettings{
+	FailureThreshold:  5,
+	OpenDuration:      30 * time.Second,
+	HalfOpenSuccesses: 1,
+}
+
+// Breakers mantém um circuit breaker por modelo, consultado pelo loop de fallback. Um modelo
+// que falha seguidamente (5xx, timeout) é pulado até o fim de OpenDuration; depois disso, uma
+// tentativa de teste por vez decide se o circuito fecha ou volta a abrir.
+type Breakers struct {
+	mu       sync.Mutex
+	settings BreakerSettings
+	models   map[string]*breaker
+	now      func() time.Time
+}
+
+type breaker struct {
+	state     string
+	failures  int // Falhas seguidas no estado fechado
+	successes int // Testes bem-sucedidos no estado meio aberto
+	openedAt  time.Time
+	probing   bool // Há uma tentativa de teste em andamento
+}
+
+// BreakerState é o estado do circuit breaker de um modelo
+type BreakerState struct {
+	Model               string     `json:"model"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	// RetryAt é quando o modelo volta a receber uma tentativa de teste (circuito aberto)
+	RetryAt *time.Time `json:"retry_at,omitempty"`
+}
+
+// NewBreakers cria os circuit breakers com as configurações informadas
+func NewBreakers(settings BreakerSettings) *Breakers {
+	return &Breakers{
+		settings: settings,
+		models:   make(map[string]*breaker),
+		now:      time.Now,
+	}
+}
+
+// SetSettings troca as configurações. Desativar o breaker fecha todos os circuitos.
+func (b *Breakers) SetSettings(settings BreakerSettings) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.settings = settings
+	if settings.FailureThreshold <= 0 {
+		for model, br := range b.models {
+			b.transition(model, br, CircuitClosed)
+		}
+	}
+}
+
+// Allow informa se o modelo pode ser tentado. Com o circuito aberto, só libera uma tentativa
+// de teste depois de OpenDuration; a partir daí o circuito fica meio aberto até Record.
+func (b *Breakers) Allow(model string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.settings.FailureThreshold <= 0 {
+		return true
+	}
+
+	br := b.get(model)
+	switch br.state {
+	case CircuitOpen:
+		if b.now().Sub(br.openedAt) < b.settings.OpenDuration {
+			metrics.ModelCircuitSkipsTotal.WithLabelValues(model).Inc()
+			return false
+		}
+		b.transition(model, br, CircuitHalfOpen)
+		br.probing = true
+		return true
+	case CircuitHalfOpen:
+		// Uma tentativa de teste por vez
+		if br.probing {
+			metrics.ModelCircuitSkipsTotal.WithLabelValues(model).Inc()
+			return false
+		}
+		br.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Record registra o resultado de uma tentativa liberada por Allow. Erros de disponibilidade
+// (5xx, timeout) contam como falha; quota, credencial recusada e cancelamento pelo cliente
+// não dizem nada sobre o modelo e só liberam o teste em andamento. Qualquer outro resultado,
+// inclusive uma resposta inválida, mostra que o modelo respondeu e conta como sucesso.
+func (b *Breakers) Record(model string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.settings.FailureThreshold <= 0 {
+		return
+	}
+
+	br := b.get(model)
+	br.probing = false
+
+	switch {
+	case err != nil && isAvailabilityError(err):
+		br.failures++
+		if br.state == CircuitHalfOpen || br.failures >= b.settings.FailureThreshold {
+			br.openedAt = b.now()
+			b.transition(model, br, CircuitOpen)
+		}
+	case err != nil && isNeutralError(err):
+	default:
+		br.failures = 0
+		if br.state == CircuitHalfOpen {
+			br.successes++
+			if br.successes >= max(b.settings.HalfOpenSuccesses, 1) {
+				b.transition(model, br, CircuitClosed)
+			}
+		}
+	}
+}
+
+// Snapshot retorna o estado de cada modelo já tentado, ordenado pelo nome
+func (b *Breakers) Snapshot() []BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	states := make([]BreakerState, 0, len(b.models))
+	for model, br := range b.models {
+		state := BreakerState{Model: model, State: br.state, ConsecutiveFailures: br.failures}
+		if br.state != CircuitClosed {
+			openedAt := br.openedAt.UTC()
+			state.OpenedAt = &openedAt
+		}
+		if br.state == CircuitOpen {
+			retryAt := br.openedAt.Add(b.settings.OpenDuration).UTC()
+			state.RetryAt = &retryAt
+		}
+		states = append(states, state)
+	}
+
+	sort.Slice(states, func(i, j int) bool { return states[i].Model < states[j].Model })
+	return states
+}
+
+func (b *Breakers) get(model string) *breaker {
+	br, ok := b.models[model]
+	if !ok {
+		br = &breaker{state: CircuitClosed}
+		b.models[model] = br
+		metrics.ModelCircuitState.WithLabelValues(model).Set(0)
+	}
+	return br
+}
+
+// transition muda o estado do circuito, zerando os contadores do estado anterior
+func (b *Breakers) transition(model string, br *breaker, state string) {
+	if br.state == state {
+		return
+	}
+	br.state = state
+	br.successes = 0
+	br.probing = false
+	if state == CircuitClosed {
+		br.failures = 0
+	}
+
+	gauge := map[string]float64{CircuitClosed: 0, CircuitHalfOpen: 1, CircuitOpen: 2}[state]
+	metrics.ModelCircuitState.WithLabelValues(model).Set(gauge)
+	if state == CircuitOpen {
+		slog.Warn("circuito aberto, modelo será pulado", "model", model, "consecutive_failures", br.failures)
+	} else {
+		slog.Info("circuito do modelo mudou de estado", "model", model, "state", state)
+	}
+}
+
+// isAvailabilityError indica se o erro mostra que o modelo está indisponível
+func isAvailabilityError(err error) bool {
+	return errors.Is(err, apperror.ErrUpstreamUnavailable) || errors.Is(err, apperror.ErrTimeout)
+}
+
+// isNeutralError indica se o erro não depende do modelo (quota, credencial, requisição
+// cancelada ou prazo da requisição esgotado durante a espera por quota)
+func isNeutralError(err error) bool {
+	return errors.Is(err, apperror.ErrUpstreamQuota) || errors.Is(err, apperror.ErrUnauthorized) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
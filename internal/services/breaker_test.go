@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/fakegemini"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errServer = apperror.New(apperror.KindUpstreamUnavailable, apperror.CodeUpstreamUnavailable, "erro da API")
+
+func newTestBreakers(settings BreakerSettings) (*Breakers, *time.Time) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	breakers := NewBreakers(settings)
+	breakers.now = func() time.Time { return now }
+	return breakers, &now
+}
+
+func state(t *testing.T, breakers *Breakers, model string) BreakerState {
+	t.Helper()
+	for _, s := range breakers.Snapshot() {
+		if s.Model == model {
+			return s
+		}
+	}
+	t.Fatalf("modelo %s sem circuit breaker", model)
+	return BreakerState{}
+}
+
+func TestBreakers_OpensAfterConsecutiveFailures(t *testing.T) {
+	breakers, _ := newTestBreakers(BreakerSettings{FailureThreshold: 3, OpenDuration: time.Minute, HalfOpenSuccesses: 1})
+
+	for range 2 {
+		require.True(t, breakers.Allow("m"))
+		breakers.Record("m", errServer)
+	}
+	// Um sucesso zera as falhas seguidas
+	require.True(t, breakers.Allow("m"))
+	breakers.Record("m", nil)
+	assert.Equal(t, 0, state(t, breakers, "m").ConsecutiveFailures)
+
+	for range 3 {
+		require.True(t, breakers.Allow("m"))
+		breakers.Record("m", errServer)
+	}
+	assert.Equal(t, CircuitOpen, state(t, breakers, "m").State)
+	assert.False(t, breakers.Allow("m"))
+}
+
+func TestBreakers_NeutralErrorsDoNotCount(t *testing.T) {
+	breakers, _ := newTestBreakers(BreakerSettings{FailureThreshold: 1, OpenDuration: time.Minute, HalfOpenSuccesses: 1})
+
+	breakers.Allow("m")
+	breakers.Record("m", apperror.New(apperror.KindUpstreamQuota, apperror.CodeUpstreamQuota, "429"))
+	breakers.Allow("m")
+	breakers.Record("m", context.Canceled)
+	breakers.Allow("m")
+	breakers.Record("m", apperror.New(apperror.KindOutputInvalid, apperror.CodeOutputInvalid, "JSON inválido"))
+
+	assert.Equal(t, CircuitClosed, state(t, breakers, "m").State)
+}
+
+func TestBreakers_HalfOpen(t *testing.T) {
+	breakers, now := newTestBreakers(BreakerSettings{FailureThreshold: 1, OpenDuration: time.Minute, HalfOpenSuccesses: 2})
+
+	breakers.Allow("m")
+	breakers.Record("m", errServer)
+	require.Equal(t, CircuitOpen, state(t, breakers, "m").State)
+	assert.Equal(t, now.Add(time.Minute), *state(t, breakers, "m").RetryAt)
+
+	// Depois de OpenDuration, uma tentativa de teste por vez
+	*now = now.Add(time.Minute)
+	assert.True(t, breakers.Allow("m"))
+	assert.False(t, breakers.Allow("m"))
+	assert.Equal(t, CircuitHalfOpen, state(t, breakers, "m").State)
+
+	// Falha no teste reabre o circuito
+	breakers.Record("m", errServer)
+	assert.Equal(t, CircuitOpen, state(t, breakers, "m").State)
+	assert.False(t, breakers.Allow("m"))
+
+	// Dois testes bem-sucedidos fecham o circuito
+	*now = now.Add(time.Minute)
+	for range 2 {
+		require.True(t, breakers.Allow("m"))
+		breakers.Record("m", nil)
+	}
+	assert.Equal(t, CircuitClosed, state(t, breakers, "m").State)
+}
+
+func TestBreakers_Disabled(t *testing.T) {
+	breakers, _ := newTestBreakers(BreakerSettings{FailureThreshold: 1, OpenDuration: time.Minute, HalfOpenSuccesses: 1})
+	breakers.Allow("m")
+	breakers.Record("m", errServer)
+	require.False(t, breakers.Allow("m"))
+
+	breakers.SetSettings(BreakerSettings{OpenDuration: time.Minute, HalfOpenSuccesses: 1})
+
+	assert.True(t, breakers.Allow("m"))
+	assert.Equal(t, CircuitClosed, state(t, breakers, "m").State)
+}
+
+func TestGeminiService_CircuitBreakerSkipsFailingModel(t *testing.T) {
+	service, gemini := newFakeService(t)
+	service.FallbackModels = nil
+	service.Breakers.SetSettings(BreakerSettings{FailureThreshold: 2, OpenDuration: time.Hour, HalfOpenSuccesses: 1})
+	gemini.OnModel("gemini-2.5-flash", fakegemini.ServerError())
+
+	for range 3 {
+		_, err := service.GenerateTopics(context.Background(), "Go", 3, "pt-BR")
+		require.NoError(t, err)
+	}
+
+	// Duas falhas abrem o circuito; a terceira geração vai direto ao próximo modelo
+	assert.Equal(t, []string{
+		"gemini-2.5-flash", "gemini-2.5-pro",
+		"gemini-2.5-flash", "gemini-2.5-pro",
+		"gemini-2.5-pro",
+	}, gemini.CalledModels())
+
+	states := service.CircuitBreakers()
+	require.Len(t, states, 2)
+	assert.Equal(t, CircuitOpen, states[0].State)
+	assert.Equal(t, 2, states[0].ConsecutiveFailures)
+	assert.Equal(t, CircuitClosed, states[1].State)
+}
+
+func TestGeminiService_CircuitBreakerFeedbackRetryIsOneProbe(t *testing.T) {
+	service, gemini := newFakeService(t)
+	service.Breakers.SetSettings(BreakerSettings{FailureThreshold: 1, OpenDuration: time.Minute, HalfOpenSuccesses: 2})
+	now := time.Now()
+	service.Breakers.now = func() time.Time { return now }
+	service.Breakers.Allow("gemini-2.5-flash")
+	service.Breakers.Record("gemini-2.5-flash", errServer)
+	now = now.Add(time.Minute)
+
+	// Teste do circuito meio aberto: resposta rejeitada e nova tentativa com o feedback
+	invalid := validTrail()
+	invalid.TotalDays = 3
+	gemini.Enqueue(fakegemini.JSON(invalid), fakegemini.JSON(validTrail()))
+	days := 6
+
+	_, err := service.GenerateEducationalTrail(context.Background(), "Go", &days, "pt-BR")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gemini-2.5-flash", "gemini-2.5-flash"}, gemini.CalledModels())
+	// As duas chamadas contam como um teste só: o circuito continua meio aberto e livre
+	assert.Equal(t, CircuitHalfOpen, state(t, service.Breakers, "gemini-2.5-flash").State)
+	assert.True(t, service.Breakers.Allow("gemini-2.5-flash"))
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/spellbook/spellbook/internal/catalog"
+	"github.com/spellbook/spellbook/internal/metrics"
+	"github.com/spellbook/spellbook/internal/models"
+)
+
+// catalogEntriesPerType limita as entradas do catálogo de cada tipo incluídas no prompt
+const catalogEntriesPerType = 5
+
+// sourceCatalog marca os recursos que vêm do catálogo curado
+const sourceCatalog = "catalog"
+
+// grounding reúne o catálogo e as entradas que combinam com o tema de uma geração
+type grounding struct {
+	catalog *catalog.Catalog
+	mode    string
+	// entries são as entradas do catálogo que combinam com o tema, incluídas no prompt
+	entries []catalog.Entry
+}
+
+// grounding busca no catálogo as entradas para o tema
+func (s *GeminiService) grounding(topic string) grounding {
+	settings := s.current()
+	return grounding{
+		catalog: settings.Catalog,
+		mode:    settings.CatalogMode,
+		entries: settings.Catalog.Search(topic, catalogEntriesPerType),
+	}
+}
+
+// roadmap troca os recursos sugeridos pelo modelo que estão no catálogo pelos dados curados
+// e, no modo replace, os que não estão por entradas ainda não usadas do mesmo tipo. Os
+// recursos do catálogo ficam no início de cada lista.
+func (g grounding) roadmap(operation string, roadmap *models.EducationalRoadmap) {
+	if g.catalog.Len() == 0 {
+		return
+	}
+
+	for _, list := range []struct {
+		kind      string
+		resources []models.EducationalResource
+	}{
+		{catalog.TypeBook, roadmap.Books},
+		{catalog.TypeCourse, roadmap.Courses},
+		{catalog.TypeVideo, roadmap.Videos},
+		{catalog.TypeArticle, roadmap.Articles},
+		{catalog.TypeProject, roadmap.Projects},
+	} {
+		used := make(map[string]bool)
+		for i, resource := range list.resources {
+			if entry, ok := g.catalog.Lookup(list.kind, resource.Title); ok {
+				list.resources[i] = fromCatalog(entry, resource)
+				used[entry.Title] = true
+				metrics.CatalogResourcesTotal.WithLabelValues(operation, "matched").Inc()
+			}
+		}
+
+		if g.mode == catalog.ModeReplace {
+			candidates := g.unused(list.kind, used)
+			for i, resource := range list.resources {
+				if resource.Source == sourceCatalog || len(candidates) == 0 {
+					continue
+				}
+				list.resources[i] = fromCatalog(candidates[0], models.EducationalResource{})
+				candidates = candidates[1:]
+				metrics.CatalogResourcesTotal.WithLabelValues(operation, "replaced").Inc()
+			}
+		}
+
+		sort.SliceStable(list.resources, func(i, j int) bool {
+			return list.resources[i].Source == sourceCatalog && list.resources[j].Source != sourceCatalog
+		})
+	}
+}
+
+// trail troca os recursos da trilha que estão no catálogo pelos dados curados, levando a URL
+// curada às atividades que usam o recurso. Os demais recursos são mantidos mesmo no modo
+// replace, porque as atividades citam capítulos e trechos do recurso sugerido.
+func (g grounding) trail(operation string, trail *models.EducationalTrail) {
+	if g.catalog.Len() == 0 {
+		return
+	}
+
+	// O tipo do recurso vem das atividades que o usam
+	kinds := make(map[string]string)
+	for _, step := range trail.Steps {
+		for _, activity := range step.Activities {
+			if kind, ok := activityKinds[activity.Type]; ok {
+				kinds[activity.ResourceID] = kind
+			}
+		}
+	}
+
+	matched := make(map[string]catalog.Entry)
+	for id, resource := range trail.Resources {
+		entry, ok := g.lookupAny(kinds[id], resource.Title)
+		if !ok {
+			continue
+		}
+		trail.Resources[id] = fromCatalog(entry, resource)
+		matched[id] = entry
+		metrics.CatalogResourcesTotal.WithLabelValues(operation, "matched").Inc()
+	}
+
+	for i := range trail.Steps {
+		for j := range trail.Steps[i].Activities {
+			activity := &trail.Steps[i].Activities[j]
+			entry, ok := matched[activity.ResourceID]
+			if !ok {
+				continue
+			}
+			if entry.URL != "" {
+				activity.URL = entry.URL
+			}
+			if len(entry.Chapters) > 0 {
+				curateChapters(activity, entry.Chapters)
+			}
+		}
+	}
+}
+
+// curateChapters troca os capítulos citados pela atividade pela grafia do catálogo e descarta os
+// que ele não tem, já que a lista do recurso foi substituída pela curada. Sem nenhum capítulo
+// restante, a leitura passa a ser do livro inteiro.
+func curateChapters(activity *models.Activity, chapters []string) {
+	if len(activity.Chapters) == 0 {
+		return
+	}
+	curated := make(map[string]string, len(chapters))
+	for _, chapter := range chapters {
+		curated[catalog.TitleKey(chapter)] = chapter
+	}
+
+	var kept []string
+	for _, chapter := range activity.Chapters {
+		if name, ok := curated[catalog.TitleKey(chapter)]; ok {
+			kept = append(kept, name)
+		}
+	}
+	activity.Chapters = kept
+	if len(kept) == 0 && activity.Type == "read_chapters" {
+		activity.Type = "read_book"
+	}
+}
+
+// activityKinds relaciona os tipos de atividade da trilha aos tipos do catálogo
+var activityKinds = map[string]string{
+	"read_book":     catalog.TypeBook,
+	"read_chapters": catalog.TypeBook,
+	"take_course":   catalog.TypeCourse,
+	"watch_video":   catalog.TypeVideo,
+	"read_article":  catalog.TypeArticle,
+	"do_project":    catalog.TypeProject,
+}
+
+// lookupAny procura o título no tipo informado ou, sem tipo, em todos
+func (g grounding) lookupAny(kind, title string) (catalog.Entry, bool) {
+	if kind != "" {
+		return g.catalog.Lookup(kind, title)
+	}
+	for _, kind := range catalog.Types {
+		if entry, ok := g.catalog.Lookup(kind, title); ok {
+			return entry, true
+		}
+	}
+	return catalog.Entry{}, false
+}
+
+// unused retorna as entradas do tipo encontradas para o tema que ainda não estão na resposta
+func (g grounding) unused(kind string, used map[string]bool) []catalog.Entry {
+	var entries []catalog.Entry
+	for _, entry := range g.entries {
+		if entry.Type == kind && !used[entry.Title] {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// fromCatalog monta o recurso com os dados curados, mantendo os do modelo que o catálogo não tem
+func fromCatalog(entry catalog.Entry, suggested models.EducationalResource) models.EducationalResource {
+	resource := suggested
+	resource.Title = entry.Title
+	resource.Source = sourceCatalog
+	if entry.URL != "" {
+		resource.URL = entry.URL
+	}
+	if entry.Description != "" {
+		resource.Description = entry.Description
+	}
+	if entry.Author != "" {
+		resource.Author = entry.Author
+	}
+	if entry.Duration != "" {
+		resource.Duration = entry.Duration
+	}
+	if len(entry.Chapters) > 0 {
+		resource.Chapters = entry.Chapters
+	}
+	return resource
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spellbook/spellbook/internal/catalog"
+	"github.com/spellbook/spellbook/internal/fakegemini"
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCatalogService(t *testing.T, mode string) (*GeminiService, *fakegemini.Server) {
+	resources, err := catalog.New(
+		catalog.Entry{Type: catalog.TypeBook, Title: "The Go Programming Language", Author: "Donovan, Kernighan", URL: "https://www.gopl.io/", Tags: []string{"go"}},
+		catalog.Entry{Type: catalog.TypeBook, Title: "Concurrency in Go", Author: "Katherine Cox-Buday", Tags: []string{"go"}},
+		catalog.Entry{Type: catalog.TypeCourse, Title: "A Tour of Go", URL: "https://go.dev/tour/", Tags: []string{"go"}},
+		catalog.Entry{Type: catalog.TypeBook, Title: "Clean Code", Tags: []string{"boas práticas"}},
+	)
+	require.NoError(t, err)
+
+	service, gemini := newFakeService(t)
+	service.Apply(Settings{APIKeys: []string{"fake-key"}, Catalog: resources, CatalogMode: mode})
+	return service, gemini
+}
+
+func TestGeminiService_CatalogGrounding(t *testing.T) {
+	suggested := models.EducationalRoadmap{
+		Topic: "Go",
+		Books: []models.EducationalResource{
+			{Title: "Go Inventado", Description: "Não existe", URL: "https://example.com/inventado"},
+			{Title: "the go programming language", Description: "Sugerido", Chapters: []string{"Tutorial"}},
+		},
+		Courses: []models.EducationalResource{{Title: "Curso de Go"}},
+	}
+
+	t.Run("entradas do tema vão para o prompt", func(t *testing.T) {
+		service, gemini := newCatalogService(t, catalog.ModePrefer)
+		gemini.Enqueue(fakegemini.JSON(suggested))
+
+		_, err := service.GenerateEducationalRoadmap(context.Background(), "Go", "pt-BR")
+
+		require.NoError(t, err)
+		prompt := gemini.Requests()[0].Prompt
+		assert.Contains(t, prompt, "[book] The Go Programming Language (Donovan, Kernighan) - https://www.gopl.io/")
+		assert.Contains(t, prompt, "[course] A Tour of Go - https://go.dev/tour/")
+		assert.NotContains(t, prompt, "Clean Code")
+	})
+
+	t.Run("prefer completa os recursos do catálogo e os coloca primeiro", func(t *testing.T) {
+		service, gemini := newCatalogService(t, catalog.ModePrefer)
+		gemini.Enqueue(fakegemini.JSON(suggested))
+
+		roadmap, err := service.GenerateEducationalRoadmap(context.Background(), "Go", "pt-BR")
+
+		require.NoError(t, err)
+		require.Len(t, roadmap.Books, 2)
+		assert.Equal(t, models.EducationalResource{
+			Title:       "The Go Programming Language",
+			Description: "Sugerido",
+			Author:      "Donovan, Kernighan",
+			URL:         "https://www.gopl.io/",
+			Chapters:    []string{"Tutorial"},
+			Source:      "catalog",
+		}, roadmap.Books[0])
+		assert.Equal(t, "Go Inventado", roadmap.Books[1].Title)
+		assert.Equal(t, "Curso de Go", roadmap.Courses[0].Title)
+	})
+
+	t.Run("replace troca as sugestões fora do catálogo", func(t *testing.T) {
+		service, gemini := newCatalogService(t, catalog.ModeReplace)
+		gemini.Enqueue(fakegemini.JSON(suggested))
+
+		roadmap, err := service.GenerateEducationalRoadmap(context.Background(), "Go", "pt-BR")
+
+		require.NoError(t, err)
+		// A sugestão fora do catálogo dá lugar a uma entrada do tema ainda não usada
+		assert.Equal(t, models.EducationalResource{Title: "Concurrency in Go", Author: "Katherine Cox-Buday", Source: "catalog"}, roadmap.Books[0])
+		assert.Equal(t, "The Go Programming Language", roadmap.Books[1].Title)
+		assert.Equal(t, "A Tour of Go", roadmap.Courses[0].Title)
+	})
+
+	t.Run("trilha usa os dados curados nos recursos e atividades", func(t *testing.T) {
+		service, gemini := newCatalogService(t, catalog.ModeReplace)
+		days := 1
+		gemini.Enqueue(fakegemini.JSON(models.EducationalTrail{
+			Topic:     "Go",
+			TotalDays: 1,
+			Steps: []models.EducationalTrailStep{{Day: 1, Activities: []models.Activity{
+				{Type: "read_chapters", ResourceID: "livro", URL: "https://example.com/inventado"},
+				{Type: "watch_video", ResourceID: "video"},
+			}}},
+			Resources: map[string]models.EducationalResource{
+				"livro": {Title: "The Go Programming Language", URL: "https://example.com/inventado"},
+				"video": {Title: "Vídeo sobre Go"},
+			},
+		}))
+
+		trail, err := service.GenerateEducationalTrail(context.Background(), "Go", &days, "pt-BR")
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://www.gopl.io/", trail.Resources["the-go-programming-language"].URL)
+		assert.Equal(t, "catalog", trail.Resources["the-go-programming-language"].Source)
+		assert.Equal(t, "https://www.gopl.io/", trail.Steps[0].Activities[0].URL)
+		assert.Equal(t, models.EducationalResource{Title: "Vídeo sobre Go"}, trail.Resources["video-sobre-go"])
+	})
+	t.Run("sugestões diferentes do mesmo recurso do catálogo viram uma só", func(t *testing.T) {
+		service, gemini := newCatalogService(t, catalog.ModePrefer)
+		days := 1
+		// Autores diferentes impediriam a junção pelos dados do modelo; os dados curados são iguais
+		gemini.Enqueue(fakegemini.JSON(models.EducationalTrail{
+			Topic:     "Go",
+			TotalDays: 1,
+			Steps: []models.EducationalTrailStep{{Day: 1, Activities: []models.Activity{
+				{Type: "read_chapters", ResourceID: "livro"},
+				{Type: "read_book", ResourceID: "livro_2"},
+			}}},
+			Resources: map[string]models.EducationalResource{
+				"livro":   {Title: "The Go Programming Language", Author: "Alan Donovan"},
+				"livro_2": {Title: "the go programming language: 2nd edition", Author: "Brian Kernighan"},
+			},
+		}))
+
+		trail, err := service.GenerateEducationalTrail(context.Background(), "Go", &days, "pt-BR")
+
+		require.NoError(t, err)
+		require.Len(t, trail.Resources, 1)
+		assert.Equal(t, "Donovan, Kernighan", trail.Resources["the-go-programming-language"].Author)
+		assert.Equal(t, "the-go-programming-language", trail.Steps[0].Activities[0].ResourceID)
+		assert.Equal(t, "the-go-programming-language", trail.Steps[0].Activities[1].ResourceID)
+	})
+}
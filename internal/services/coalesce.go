@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/spellbook/spellbook/internal/metrics"
+)
+
+// coalescer agrupa gerações idênticas em andamento: a primeira requisição faz a chamada ao
+// Gemini e as que chegam enquanto ela não termina recebem o mesmo resultado
+type coalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+type coalescedCall struct {
+	done  chan struct{}
+	value any
+	err   error
+	// waiters é o número de requisições aguardando; quando todas desistem, a geração é cancelada
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// coalesce executa generate uma única vez para cada chave em andamento. Cada requisição
+// respeita o próprio contexto: ao ser cancelada, deixa de esperar sem afetar as demais, e a
+// geração só é cancelada quando nenhuma requisição espera mais por ela. O resultado é
+// compartilhado entre as requisições e não deve ser alterado.
+func coalesce[T any](ctx context.Context, c *coalescer, operation, key string, generate func(ctx context.Context) (T, error)) (T, error) {
+	key = operation + "\x00" + key
+
+	c.mu.Lock()
+	if c.calls == nil {
+		c.calls = make(map[string]*coalescedCall)
+	}
+	call, shared := c.calls[key]
+	if shared {
+		call.waiters++
+	} else {
+		// A geração não herda o cancelamento da primeira requisição, só seus valores (ID, trace)
+		genCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &coalescedCall{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		c.calls[key] = call
+		go func() {
+			defer cancel()
+			call.value, call.err = generate(genCtx)
+			c.mu.Lock()
+			if c.calls[key] == call {
+				delete(c.calls, key)
+			}
+			c.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	c.mu.Unlock()
+
+	if shared {
+		metrics.CoalescedRequestsTotal.WithLabelValues(operation).Inc()
+		slog.DebugContext(ctx, "requisição agrupada com geração idêntica em andamento", "operation", operation)
+	}
+
+	var zero T
+	select {
+	case <-call.done:
+		if call.err != nil {
+			return zero, call.err
+		}
+		return call.value.(T), nil
+	case <-ctx.Done():
+		c.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// Ninguém mais espera: cancela a geração e deixa a próxima requisição começar outra
+			call.cancel()
+			if c.calls[key] == call {
+				delete(c.calls, key)
+			}
+			slog.DebugContext(ctx, "geração agrupada cancelada, nenhuma requisição aguardando", "operation", operation)
+		}
+		c.mu.Unlock()
+		return zero, ctx.Err()
+	}
+}
+
+// coalesceKey monta a chave de agrupamento a partir dos parâmetros normalizados da geração:
+// textos sem diferença de maiúsculas e espaços, ponteiros nil distintos de qualquer valor
+func coalesceKey(language string, params ...any) string {
+	parts := []string{strings.ToLower(language)}
+	for _, param := range params {
+		switch v := param.(type) {
+		case string:
+			parts = append(parts, strings.ToLower(strings.Join(strings.Fields(v), " ")))
+		case *int:
+			if v == nil {
+				parts = append(parts, "-")
+			} else {
+				parts = append(parts, fmt.Sprint(*v))
+			}
+		case *string:
+			if v == nil {
+				parts = append(parts, "-")
+			} else {
+				parts = append(parts, strings.TrimSpace(*v))
+			}
+		default:
+			parts = append(parts, fmt.Sprint(v))
+		}
+	}
+	// Separador que não aparece nos parâmetros, para "a b"+"c" não colidir com "a"+"b c"
+	return strings.Join(parts, "\x00")
+}
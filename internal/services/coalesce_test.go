@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/fakegemini"
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeminiService_Coalescing(t *testing.T) {
+	t.Run("requisições idênticas compartilham a geração", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		gemini.OnModel("gemini-2.5-flash", fakegemini.Slow(200*time.Millisecond, fakegemini.Topics("Go", 3)))
+
+		subjects := []string{"Go", "go", "  GO ", "Go"}
+		results := make([]*models.TopicsResponse, len(subjects))
+		var wg sync.WaitGroup
+		for i, subject := range subjects {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				topics, err := service.GenerateTopics(context.Background(), subject, 3, "pt-BR")
+				assert.NoError(t, err)
+				results[i] = topics
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, []string{"gemini-2.5-flash"}, gemini.CalledModels())
+		for _, topics := range results {
+			assert.Same(t, results[0], topics)
+		}
+	})
+
+	t.Run("parâmetros diferentes não são agrupados", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		gemini.OnModel("gemini-2.5-flash", fakegemini.Slow(100*time.Millisecond, fakegemini.Topics("Go", 3)))
+
+		var wg sync.WaitGroup
+		for _, language := range []string{"pt-BR", "en"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := service.GenerateTopics(context.Background(), "Go", 3, language)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, gemini.CalledModels(), 2)
+	})
+
+	t.Run("nova requisição após o fim da geração chama o modelo de novo", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+
+		for range 2 {
+			_, err := service.GenerateTopics(context.Background(), "Go", 3, "pt-BR")
+			require.NoError(t, err)
+		}
+
+		assert.Len(t, gemini.CalledModels(), 2)
+	})
+}
+
+func TestCoalesce_Cancellation(t *testing.T) {
+	var c coalescer
+	release := make(chan struct{})
+	started := make(chan context.Context, 2)
+	generate := func(ctx context.Context) (string, error) {
+		started <- ctx
+		select {
+		case <-release:
+			return "roadmap", nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	t.Run("requisição cancelada não afeta as demais", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(context.Background())
+		errs := make(chan error, 1)
+		go func() {
+			_, err := coalesce(cancelled, &c, "roadmap", "go", generate)
+			errs <- err
+		}()
+		genCtx := <-started
+
+		values := make(chan string, 1)
+		go func() {
+			value, err := coalesce(context.Background(), &c, "roadmap", "go", generate)
+			assert.NoError(t, err)
+			values <- value
+		}()
+		require.Eventually(t, func() bool {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			return c.calls["roadmap\x00go"].waiters == 2
+		}, time.Second, time.Millisecond)
+
+		cancel()
+		assert.ErrorIs(t, <-errs, context.Canceled)
+		assert.NoError(t, genCtx.Err())
+
+		close(release)
+		assert.Equal(t, "roadmap", <-values)
+		assert.Empty(t, started)
+	})
+
+	t.Run("geração é cancelada quando ninguém mais espera", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		generate := func(ctx context.Context) (string, error) {
+			started <- ctx
+			<-ctx.Done()
+			return "", ctx.Err()
+		}
+		errs := make(chan error, 1)
+		go func() {
+			_, err := coalesce(ctx, &c, "roadmap", "rust", generate)
+			errs <- err
+		}()
+		genCtx := <-started
+
+		cancel()
+		assert.ErrorIs(t, <-errs, context.Canceled)
+		assert.Eventually(t, func() bool { return errors.Is(genCtx.Err(), context.Canceled) }, time.Second, time.Millisecond)
+
+		// A próxima requisição começa uma geração nova
+		go func() { _, _ = coalesce(context.Background(), &c, "roadmap", "rust", generate) }()
+		next := <-started
+		assert.NoError(t, next.Err())
+	})
+}
+
+func TestCoalesceKey(t *testing.T) {
+	days, otherDays := 30, 15
+	date := "2026-12-31"
+
+	assert.Equal(t, coalesceKey("pt-BR", "Go  Avançado", &days), coalesceKey("pt-br", " go avançado ", &days))
+	assert.NotEqual(t, coalesceKey("pt-BR", "Go", &days), coalesceKey("pt-BR", "Go", &otherDays))
+	assert.NotEqual(t, coalesceKey("pt-BR", "Go", (*int)(nil)), coalesceKey("pt-BR", "Go", &days))
+	assert.NotEqual(t, coalesceKey("pt-BR", "Go", 5, (*string)(nil)), coalesceKey("pt-BR", "Go", 5, &date))
+	assert.NotEqual(t, coalesceKey("pt-BR", "a b", "c"), coalesceKey("pt-BR", "a", "b c"))
+}
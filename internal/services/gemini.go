@@ -2,47 +2,254 @@ package services
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/catalog"
+	"github.com/spellbook/spellbook/internal/linkcheck"
+	"github.com/spellbook/spellbook/internal/logging"
+	"github.com/spellbook/spellbook/internal/metrics"
 	"github.com/spellbook/spellbook/internal/models"
+	"github.com/spellbook/spellbook/internal/prompts"
+	"github.com/spellbook/spellbook/internal/tracing"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // GeminiService gerencia a integração com a API do Gemini
 type GeminiService struct {
-	APIKey     string
+	// Keys distribui as chamadas entre as API keys configuradas
+	Keys       *KeyPool
 	HTTPClient *http.Client
 	BaseURL    string
+
+	// Prompts guarda os templates usados para montar os prompts
+	Prompts *prompts.Registry
+
+	// ModelsCacheTTL define por quanto tempo a lista de modelos disponíveis fica em cache
+	ModelsCacheTTL time.Duration
+
+	// QuotaRetryDelay é a espera antes de tentar novamente um modelo que respondeu 429
+	QuotaRetryDelay time.Duration
+
+	// PreferredModels são tentados antes dos modelos listados pela API; FallbackModels, depois
+	PreferredModels []string
+	FallbackModels  []string
+
+	// Stats guarda o resultado das tentativas recentes por modelo
+	Stats *ModelStats
+
+	// Breakers pulam no fallback os modelos que estão falhando seguidamente
+	Breakers *Breakers
+
+	// Hedge configura as consultas em paralelo de GenerateTopics
+	Hedge  HedgeSettings
+	hedges hedgeBudget
+
+	// Catalog é o catálogo curado de recursos educacionais; CatalogMode define como ele é
+	// usado na resposta (catalog.ModePrefer ou catalog.ModeReplace)
+	Catalog     *catalog.Catalog
+	CatalogMode string
+
+	// Links verifica as URLs dos recursos educacionais (ver LINK_CHECK_MODE)
+	Links *linkcheck.Checker
+
+	// inflight agrupa as gerações idênticas em andamento
+	inflight coalescer
+
+	// settingsMu protege as opções trocadas por Apply
+	settingsMu sync.RWMutex
+
+	modelsMu       sync.Mutex
+	cachedModels   []string
+	modelsCachedAt time.Time
 }
 
-// NewGeminiService cria uma nova instância do serviço Gemini
-func NewGeminiService(apiKey string) *GeminiService {
+// NewGeminiService cria uma nova instância do serviço Gemini. Com mais de uma API key,
+// as chamadas são distribuídas entre elas em rodízio.
+func NewGeminiService(apiKeys ...string) *GeminiService {
 	return &GeminiService{
-		APIKey: apiKey,
+		Keys: NewKeyPool(apiKeys...),
 		HTTPClient: &http.Client{
-			Timeout: 180 * time.Second, // 3 minutos para trilhas educacionais complexas
+			Timeout: DefaultRequestTimeout,
 		},
-		BaseURL: "https://generativelanguage.googleapis.com/v1beta",
+		BaseURL:         "https://generativelanguage.googleapis.com/v1beta",
+		Prompts:         prompts.Default(),
+		ModelsCacheTTL:  5 * time.Minute,
+		QuotaRetryDelay: 30 * time.Second,
+		FallbackModels:  DefaultFallbackModels,
+		Stats:           NewModelStats(15 * time.Minute),
+		Breakers:        NewBreakers(DefaultBreakerSettings),
+		Hedge:           DefaultHedgeSettings,
+		CatalogMode:     catalog.ModePrefer,
+		Links:           linkcheck.New(linkcheck.DefaultSettings, nil),
+	}
+}
+
+// checkConfigured verifica se alguma API key foi configurada e ainda é aceita antes de chamar a API
+func (s *GeminiService) checkConfigured() error {
+	if s.Keys.Len() == 0 {
+		return apperror.New(apperror.KindUpstreamUnavailable, apperror.CodeAPIKeyMissing, "GEMINI_API_KEY não configurada")
+	}
+	if s.Keys.Usable() == 0 {
+		return apperror.New(apperror.KindUnauthorized, apperror.CodeUpstreamRejected, "todas as API keys foram recusadas pela API")
+	}
+	return nil
+}
+
+// listAvailableModels lista os modelos disponíveis na API, usando o cache quando válido
+func (s *GeminiService) listAvailableModels(ctx context.Context) ([]string, error) {
+	ttl := s.current().ModelsCacheTTL
+
+	s.modelsMu.Lock()
+	if s.cachedModels != nil && time.Since(s.modelsCachedAt) < ttl {
+		cached := s.cachedModels
+		s.modelsMu.Unlock()
+		metrics.RecordCache("models", true)
+		return cached, nil
+	}
+	s.modelsMu.Unlock()
+	metrics.RecordCache("models", false)
+
+	models, err := s.fetchAvailableModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cacheModels(models)
+	return models, nil
+}
+
+// cacheModels guarda a lista de modelos. Só guarda listas não vazias, para não mascarar
+// falhas temporárias
+func (s *GeminiService) cacheModels(models []string) {
+	if len(models) == 0 {
+		return
+	}
+	s.modelsMu.Lock()
+	s.cachedModels = models
+	s.modelsCachedAt = time.Now()
+	s.modelsMu.Unlock()
+}
+
+// ModelsCacheInfo descreve o estado do cache da lista de modelos
+type ModelsCacheInfo struct {
+	Entries  int
+	CachedAt time.Time // Zero se a lista nunca foi obtida
+	Fresh    bool      // Dentro do ModelsCacheTTL
+}
+
+// ModelsCache retorna o estado atual do cache da lista de modelos
+func (s *GeminiService) ModelsCache() ModelsCacheInfo {
+	ttl := s.current().ModelsCacheTTL
+
+	s.modelsMu.Lock()
+	defer s.modelsMu.Unlock()
+
+	return ModelsCacheInfo{
+		Entries:  len(s.cachedModels),
+		CachedAt: s.modelsCachedAt,
+		Fresh:    s.cachedModels != nil && time.Since(s.modelsCachedAt) < ttl,
+	}
+}
+
+// ProbeModels consulta a lista de modelos ignorando o cache, para verificar o alcance da
+// API e a validade da API key. Atualiza o cache quando a consulta tem sucesso.
+func (s *GeminiService) ProbeModels(ctx context.Context) ([]string, error) {
+	if err := s.checkConfigured(); err != nil {
+		return nil, err
+	}
+
+	models, err := s.fetchAvailableModels(ctx)
+	if err != nil {
+		return nil, err
 	}
+
+	s.cacheModels(models)
+	return models, nil
 }
 
-// listAvailableModels lista os modelos disponíveis na API
-func (s *GeminiService) listAvailableModels() ([]string, error) {
-	url := fmt.Sprintf("%s/models?key=%s", s.BaseURL, s.APIKey)
+// CircuitBreakers retorna o estado do circuit breaker de cada modelo já tentado
+func (s *GeminiService) CircuitBreakers() []BreakerState {
+	return s.Breakers.Snapshot()
+}
+
+// KeyUsage retorna o estado e o uso de cada API key do pool
+func (s *GeminiService) KeyUsage() []KeyUsage {
+	return s.Keys.Usage()
+}
+
+// ModelStats retorna o resumo das tentativas recentes por modelo
+func (s *GeminiService) ModelStats() []ModelStat {
+	if s.Stats == nil {
+		return nil
+	}
+	return s.Stats.Snapshot()
+}
+
+// CheckModels verifica se o catálogo de modelos da API está acessível e tem modelos utilizáveis.
+// Usa o cache de modelos quando válido, para que verificações frequentes não consumam a API.
+func (s *GeminiService) CheckModels(ctx context.Context) error {
+	if err := s.checkConfigured(); err != nil {
+		return err
+	}
+
+	models, err := s.listAvailableModels(ctx)
+	if err != nil {
+		return err
+	}
+	if len(models) == 0 {
+		return apperror.New(apperror.KindUpstreamUnavailable, apperror.CodeUpstreamUnavailable, "nenhum modelo Gemini disponível")
+	}
+	return nil
+}
+
+// fetchAvailableModels consulta a API para obter a lista de modelos disponíveis. Se a chave
+// usada for recusada, tenta com as próximas do pool.
+func (s *GeminiService) fetchAvailableModels(ctx context.Context) ([]string, error) {
+	models, err := s.fetchModels(ctx)
+	for attempt := 1; errors.Is(err, apperror.ErrUnauthorized) && attempt < s.Keys.Len() && s.Keys.Usable() > 0; attempt++ {
+		models, err = s.fetchModels(ctx)
+	}
+	return models, err
+}
 
-	resp, err := s.HTTPClient.Get(url)
+func (s *GeminiService) fetchModels(ctx context.Context) ([]string, error) {
+	apiKey, err := s.Keys.Acquire()
 	if err != nil {
 		return nil, err
 	}
+	url := fmt.Sprintf("%s/models?key=%s", s.BaseURL, apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, logging.RedactError(err)
+	}
+
+	resp, err := s.httpClient().Do(req)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.KindUpstreamUnavailable, apperror.CodeUpstreamUnavailable, "erro de conexão com a API", logging.RedactError(err))
+	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return []string{}, nil
+		body, _ := io.ReadAll(resp.Body)
+		err := upstreamError(resp, body)
+		s.Keys.Report(apiKey, err, s.current().QuotaRetryDelay)
+		return nil, err
 	}
 
 	var data struct {
@@ -52,7 +259,7 @@ func (s *GeminiService) listAvailableModels() ([]string, error) {
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return []string{}, nil
+		return nil, apperror.Wrap(apperror.KindUpstreamUnavailable, apperror.CodeUpstreamUnavailable, "lista de modelos em formato inesperado", err)
 	}
 
 	models := make([]string, 0)
@@ -69,8 +276,12 @@ func (s *GeminiService) listAvailableModels() ([]string, error) {
 }
 
 // generateContent gera conteúdo usando um modelo específico
-func (s *GeminiService) generateContent(modelName, prompt string) (string, error) {
-	url := fmt.Sprintf("%s/models/%s:generateContent?key=%s", s.BaseURL, modelName, s.APIKey)
+func (s *GeminiService) generateContent(ctx context.Context, modelName, prompt string) (string, error) {
+	apiKey, err := s.Keys.Acquire()
+	if err != nil {
+		return "", err
+	}
+	url := fmt.Sprintf("%s/models/%s:generateContent?key=%s", s.BaseURL, modelName, apiKey)
 
 	payload := map[string]interface{}{
 		"contents": []map[string]interface{}{
@@ -89,31 +300,37 @@ func (s *GeminiService) generateContent(modelName, prompt string) (string, error
 		return "", err
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", err
+		return "", logging.RedactError(err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := s.HTTPClient.Do(req)
+	start := time.Now()
+	resp, err := s.httpClient().Do(req)
 	if err != nil {
-		return "", err
+		// Erros de transporte incluem a URL completa, com a API key na query string
+		err = logging.RedactError(err)
+		if errors.Is(err, context.DeadlineExceeded) {
+			return "", apperror.Wrap(apperror.KindTimeout, apperror.CodeTimeout, "tempo limite excedido", err)
+		}
+		return "", apperror.Wrap(apperror.KindUpstreamUnavailable, apperror.CodeUpstreamUnavailable, "erro de conexão com a API", err)
 	}
 	defer resp.Body.Close()
+	defer func() {
+		metrics.ModelCallDuration.WithLabelValues(modelName).Observe(time.Since(start).Seconds())
+	}()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
-	}
-
-	if resp.StatusCode == http.StatusTooManyRequests {
-		// Erro de quota - retornar erro especial
-		return "", fmt.Errorf("quota excedida (429)")
+		return "", apperror.Wrap(apperror.KindUpstreamUnavailable, apperror.CodeUpstreamUnavailable, "erro ao ler resposta da API", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("erro da API: %d - %s", resp.StatusCode, string(body))
+		err := upstreamError(resp, body)
+		s.Keys.Report(apiKey, err, s.current().QuotaRetryDelay)
+		return "", err
 	}
 
 	var result struct {
@@ -124,769 +341,303 @@ func (s *GeminiService) generateContent(modelName, prompt string) (string, error
 				} `json:"parts"`
 			} `json:"content"`
 		} `json:"candidates"`
+		UsageMetadata struct {
+			PromptTokenCount     int `json:"promptTokenCount"`
+			CandidatesTokenCount int `json:"candidatesTokenCount"`
+		} `json:"usageMetadata"`
 	}
 
 	if err := json.Unmarshal(body, &result); err != nil {
-		return "", err
+		return "", apperror.Wrap(apperror.KindUpstreamUnavailable, apperror.CodeUpstreamUnavailable, "resposta da API em formato inesperado", err)
 	}
 
+	// Registrar o consumo de tokens no span da tentativa
+	trace.SpanFromContext(ctx).SetAttributes(
+		tracing.AttrInputTokens.Int(result.UsageMetadata.PromptTokenCount),
+		tracing.AttrOutputTokens.Int(result.UsageMetadata.CandidatesTokenCount),
+	)
+
 	if len(result.Candidates) == 0 || len(result.Candidates[0].Content.Parts) == 0 {
-		return "", fmt.Errorf("resposta vazia da API")
+		return "", apperror.New(apperror.KindOutputInvalid, apperror.CodeOutputInvalid, "resposta vazia da API")
 	}
 
 	return result.Candidates[0].Content.Parts[0].Text, nil
 }
 
-// cleanJSONText limpa o texto para extrair apenas o JSON
-func cleanJSONText(text string) string {
-	// Remover markdown code blocks
-	text = regexp.MustCompile("(?i)```json\\s*").ReplaceAllString(text, "")
-	text = regexp.MustCompile("(?i)```\\s*").ReplaceAllString(text, "")
-	text = strings.TrimSpace(text)
-
-	// Tentar encontrar o JSON no texto
-	jsonMatch := regexp.MustCompile(`\{[\s\S]*\}`).FindString(text)
-	if jsonMatch != "" {
-		return jsonMatch
-	}
-
-	return text
-}
-
-// GenerateRoadmap gera um roadmap de estudo usando o Gemini
-func (s *GeminiService) GenerateRoadmap(topic string, availableDays *int, exactItemCount *int) (*models.Roadmap, error) {
-	if topic == "" {
-		return nil, fmt.Errorf("tópico não pode ser vazio")
-	}
-
-	// Listar modelos disponíveis
-	availableModels, _ := s.listAvailableModels()
-
-	// Lista de modelos para tentar em ordem (fallback)
-	modelsToTry := make([]string, 0)
-
-	// Adicionar modelos disponíveis primeiro
-	for _, model := range availableModels {
-		modelsToTry = append(modelsToTry, model)
-	}
-
-	// Adicionar fallbacks
-	fallbacks := []string{
-		"gemini-1.5-flash-latest",
-		"gemini-1.5-pro-latest",
-		"gemini-pro",
-		"gemini-1.5-flash",
-		"gemini-1.5-pro",
-	}
-
-	// Remover duplicatas
-	seen := make(map[string]bool)
-	for _, model := range modelsToTry {
-		seen[model] = true
-	}
-	for _, model := range fallbacks {
-		if !seen[model] {
-			modelsToTry = append(modelsToTry, model)
+// upstreamError classifica uma resposta de erro da API. O corpo fica apenas na causa,
+// para aparecer nos logs sem chegar ao cliente.
+func upstreamError(resp *http.Response, body []byte) error {
+	statusCode := resp.StatusCode
+	cause := fmt.Errorf("erro da API: %d - %s", statusCode, string(body))
+	details := parseErrorDetails(body)
+
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		err := apperror.Wrap(apperror.KindUpstreamQuota, apperror.CodeUpstreamQuota, "quota excedida (429)", cause)
+		err.RetryAfter = details.retryDelay
+		if err.RetryAfter == 0 {
+			err.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
 		}
+		return err
+	case statusCode == http.StatusUnauthorized || details.reason == "API_KEY_INVALID":
+		return apperror.Wrap(apperror.KindUnauthorized, apperror.CodeUpstreamRejected, "API key recusada pela API", cause)
+	case statusCode == http.StatusForbidden:
+		// 403 também vem de permissões por modelo ou projeto: a chave continua no pool e o
+		// erro conta como falha do modelo, que passa ao próximo
+		return apperror.Wrap(apperror.KindUpstreamUnavailable, apperror.CodeModelForbidden, "acesso ao modelo negado pela API", cause)
+	case statusCode == http.StatusGatewayTimeout:
+		return apperror.Wrap(apperror.KindTimeout, apperror.CodeTimeout, "tempo limite excedido na API", cause)
+	default:
+		return apperror.Wrap(apperror.KindUpstreamUnavailable, apperror.CodeUpstreamUnavailable, "erro da API", cause)
 	}
+}
 
-	// Determinar número de categorias e itens baseado em availableDays e exactItemCount
-	numCategories := "4-6"
-	itemsPerCategory := "5-10"
-	timeContext := ""
-	targetItemCount := 30      // Número exato de itens a serem gerados
-	daysAvailable := 30        // Para uso no timeContext
-	
-	// Prioridade: usar exactItemCount se disponível, senão calcular baseado em availableDays
-	if exactItemCount != nil && *exactItemCount > 0 {
-		targetItemCount = *exactItemCount
-		if availableDays != nil && *availableDays > 0 {
-			daysAvailable = *availableDays
-		} else {
-			daysAvailable = *exactItemCount
-		}
-	} else if availableDays != nil && *availableDays > 0 {
-		// Calcular proporção de itens baseado no tempo disponível
-		// Aproximadamente 1 item por dia
-		targetItemCount = *availableDays
-		daysAvailable = *availableDays
-	}
-	
-	// Calcular número de categorias baseado no número de itens
-	if targetItemCount < 14 {
-		// Tempo curto: focar em essencial
-		numCategories = "3-4"
-		itemsPerCategory = "3-5"
-		timeContext = fmt.Sprintf("\n\n⏰ PRAZO CRÍTICO: Este roadmap DEVE ter EXATAMENTE %d itens (não mais, não menos).\n\nREGRAS OBRIGATÓRIAS:\n- Crie EXATAMENTE %d itens no total\n- Tempo disponível: %d dias (1 item por dia)\n- Distribua em 3-4 categorias\n- Cada categoria deve ter entre 3-5 itens\n- Se você criar mais ou menos de %d itens, o roadmap será REJEITADO\n- Priorize apenas o ESSENCIAL e mais importante", targetItemCount, targetItemCount, daysAvailable, targetItemCount)
-	} else if targetItemCount <= 30 {
-		// Tempo médio: estrutura balanceada
-		numCategories = "4-6"
-		itemsPerCategory = "5-8"
-		timeContext = fmt.Sprintf("\n\n⏰ PRAZO CRÍTICO: Este roadmap DEVE ter EXATAMENTE %d itens (não mais, não menos).\n\nREGRAS OBRIGATÓRIAS:\n- Crie EXATAMENTE %d itens no total\n- Tempo disponível: %d dias (1 item por dia)\n- Distribua em 4-6 categorias\n- Cada categoria deve ter entre 5-8 itens\n- Se você criar mais ou menos de %d itens, o roadmap será REJEITADO\n- Mantenha uma estrutura balanceada e prática", targetItemCount, targetItemCount, daysAvailable, targetItemCount)
-	} else if targetItemCount <= 60 {
-		// Tempo médio-longo: estrutura mais completa
-		numCategories = "5-7"
-		itemsPerCategory = "6-10"
-		timeContext = fmt.Sprintf("\n\n⏰ PRAZO CRÍTICO: Este roadmap DEVE ter EXATAMENTE %d itens (não mais, não menos).\n\nREGRAS OBRIGATÓRIAS:\n- Crie EXATAMENTE %d itens no total\n- Tempo disponível: %d dias (1 item por dia)\n- Distribua em 5-7 categorias\n- Cada categoria deve ter entre 6-10 itens\n- Se você criar mais ou menos de %d itens, o roadmap será REJEITADO\n- Você tem tempo suficiente para uma estrutura mais completa", targetItemCount, targetItemCount, daysAvailable, targetItemCount)
-	} else {
-		// Tempo longo: estrutura extensa mas organizada
-		// Calcular estimativas proporcionais ao número de itens
-		estimatedCategories := 6 + (targetItemCount-60)/15 // Aproximadamente 1 categoria a cada 15 itens extras
-		itemsPerCat := targetItemCount / estimatedCategories
-		
-		numCategories = fmt.Sprintf("%d-%d", estimatedCategories-1, estimatedCategories+2)
-		itemsPerCategory = fmt.Sprintf("%d-%d", itemsPerCat-2, itemsPerCat+3)
-		timeContext = fmt.Sprintf("\n\n⏰ PRAZO CRÍTICO: Este roadmap DEVE ter EXATAMENTE %d itens (não mais, não menos).\n\nREGRAS OBRIGATÓRIAS:\n- Crie EXATAMENTE %d itens no total\n- Tempo disponível: %d dias (1 item por dia)\n- Distribua em %s categorias\n- Cada categoria deve ter entre %s itens\n- Se você criar mais ou menos de %d itens, o roadmap será REJEITADO\n- A quantidade deve ser proporcional ao tempo disponível", targetItemCount, targetItemCount, daysAvailable, numCategories, itemsPerCategory, targetItemCount)
-	}
-
-	// Prompt para gerar o roadmap
-	prompt := fmt.Sprintf(`Você é um especialista em criar roadmaps de estudo detalhados e estruturados.
-
-Crie um roadmap completo e bem organizado sobre: "%s"%s
-
-O roadmap deve ser retornado APENAS como um JSON válido, sem markdown, sem texto adicional, seguindo EXATAMENTE esta estrutura:
-
-{
-  "topic": "%s",
-  "roadmap": [
-    {
-      "category": "Nome da Categoria",
-      "items": [
-        {"id": "1", "title": "Título do item", "completed": false},
-        {"id": "2", "title": "Título do item", "completed": false}
-      ]
-    }
-  ]
-}
-
-Requisitos OBRIGATÓRIOS:
-- Crie EXATAMENTE %s categorias principais (não mais, não menos)
-- Cada categoria deve ter EXATAMENTE entre %s itens (respeite este intervalo)
-- O TOTAL DE ITENS em todo o roadmap DEVE SER EXATAMENTE %d itens (não mais, não menos)
-- Os itens devem ser progressivos (do básico ao avançado)
-- Seja específico e prático nos títulos
-- Organize de forma lógica e sequencial
-
-VALIDAÇÃO CRÍTICA: Se o roadmap tiver mais ou menos de %d itens totais, ele será REJEITADO e você terá que gerar novamente. O número exato de itens é %d.
-
-Retorne APENAS o JSON válido, sem markdown code blocks, sem texto antes ou depois.`, topic, timeContext, topic, numCategories, itemsPerCategory, targetItemCount, targetItemCount, targetItemCount)
-
-	var lastError error
-
-	// Tentar cada modelo até encontrar um que funcione
-	for _, modelName := range modelsToTry {
-		text, err := s.generateContent(modelName, prompt)
-		if err != nil {
-			// Se for erro de quota, aguardar e tentar novamente
-			if strings.Contains(err.Error(), "429") || strings.Contains(err.Error(), "quota") {
-				time.Sleep(30 * time.Second)
-				// Tentar novamente este modelo
-				text, err = s.generateContent(modelName, prompt)
-				if err != nil {
-					lastError = err
-					continue
-				}
-			} else {
-				lastError = err
-				continue
-			}
-		}
-
-		// Limpar o texto para extrair apenas o JSON
-		jsonText := cleanJSONText(text)
-
-		// Tentar fazer parse do JSON
-		var roadmap models.Roadmap
-		if err := json.Unmarshal([]byte(jsonText), &roadmap); err != nil {
-			lastError = fmt.Errorf("erro ao fazer parse do JSON: %v", err)
-			continue
-		}
-
-		// Validar estrutura básica
-		if roadmap.Topic == "" || len(roadmap.Roadmap) == 0 {
-			lastError = fmt.Errorf("resposta do Gemini não está no formato esperado")
-			continue
-		}
-
-		// Validar quantidade total de itens
-		totalItems := 0
-		for _, category := range roadmap.Roadmap {
-			totalItems += len(category.Items)
-		}
+// errorDetails são os detalhes relevantes de um erro da API (google.rpc.RetryInfo e ErrorInfo)
+type errorDetails struct {
+	retryDelay time.Duration
+	reason     string
+}
 
-		// Validação rigorosa: usar exactItemCount se disponível, senão availableDays
-		if exactItemCount != nil && *exactItemCount > 0 {
-			// Tolerância de apenas ±1 item para exactItemCount
-			if totalItems != *exactItemCount && totalItems != *exactItemCount+1 && totalItems != *exactItemCount-1 {
-				lastError = fmt.Errorf("roadmap gerado com %d itens, mas o esperado é EXATAMENTE %d itens. Rejeitando e tentando novamente...", totalItems, *exactItemCount)
-				continue
-			}
-			// Log para debug
-			fmt.Printf("[DEBUG] Spellbook GenerateRoadmap - ExactItemCount: %d, TotalItemsGenerated: %d\n", 
-				*exactItemCount, totalItems)
-		} else if availableDays != nil && *availableDays > 0 {
-			// Se não tiver exactItemCount, usar availableDays com tolerância de ±2 itens
-			maxExpectedItems := *availableDays + 2
-			minExpectedItems := *availableDays - 2
-			if totalItems > maxExpectedItems || totalItems < minExpectedItems {
-				lastError = fmt.Errorf("roadmap gerado com %d itens, mas o esperado é %d itens (tempo disponível: %d dias). Tentando novamente...", totalItems, *availableDays, *availableDays)
-				continue
+func parseErrorDetails(body []byte) errorDetails {
+	var payload struct {
+		Error struct {
+			Details []struct {
+				Type       string `json:"@type"`
+				RetryDelay string `json:"retryDelay"`
+				Reason     string `json:"reason"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	var details errorDetails
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return details
+	}
+
+	for _, detail := range payload.Error.Details {
+		switch {
+		case strings.HasSuffix(detail.Type, "google.rpc.RetryInfo"):
+			if delay, err := time.ParseDuration(detail.RetryDelay); err == nil && delay > 0 {
+				details.retryDelay = delay
 			}
-			// Log para debug
-			fmt.Printf("[DEBUG] Spellbook GenerateRoadmap - AvailableDays: %d, TotalItemsGenerated: %d, Expected: %d\n", 
-				*availableDays, totalItems, *availableDays)
+		case strings.HasSuffix(detail.Type, "google.rpc.ErrorInfo"):
+			details.reason = detail.Reason
 		}
-
-		return &roadmap, nil
-	}
-
-	if lastError != nil {
-		return nil, fmt.Errorf("erro ao gerar roadmap: %v", lastError)
 	}
-
-	return nil, fmt.Errorf("erro ao gerar roadmap: nenhum modelo disponível funcionou")
+	return details
 }
 
-// GenerateTopics gera uma lista de tópicos sobre um assunto
-func (s *GeminiService) GenerateTopics(subject string, count int) (*models.TopicsResponse, error) {
-	if subject == "" {
-		return nil, fmt.Errorf("assunto não pode ser vazio")
-	}
-
-	if count <= 0 {
-		count = 10 // Default
-	}
-
-	// Listar modelos disponíveis
-	availableModels, _ := s.listAvailableModels()
-
-	modelsToTry := make([]string, 0)
-	seen := make(map[string]bool)
-
-	for _, model := range availableModels {
-		modelsToTry = append(modelsToTry, model)
-		seen[model] = true
+// parseRetryAfter lê o header Retry-After em segundos (o formato de data não é usado pela API)
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds <= 0 {
+		return 0
 	}
-
-	fallbacks := []string{
-		"gemini-1.5-flash-latest",
-		"gemini-1.5-pro-latest",
-		"gemini-pro",
-		"gemini-1.5-flash",
-		"gemini-1.5-pro",
-	}
-
-	for _, model := range fallbacks {
-		if !seen[model] {
-			modelsToTry = append(modelsToTry, model)
-		}
-	}
-
-	// Prompt para gerar tópicos
-	prompt := fmt.Sprintf(`Você é um especialista em organizar conhecimento.
-
-Gere uma lista de %d tópicos importantes e relevantes sobre: "%s"
-
-A resposta deve ser APENAS um JSON válido, sem markdown, sem texto adicional, seguindo EXATAMENTE esta estrutura:
-
-{
-  "subject": "%s",
-  "topics": [
-    "Tópico 1",
-    "Tópico 2",
-    "Tópico 3"
-  ]
+	return time.Duration(seconds) * time.Second
 }
 
-Requisitos:
-- Liste tópicos práticos e específicos
-- Organize de forma lógica
-- Seja conciso nos nomes dos tópicos
-- Retorne APENAS o JSON, sem explicações adicionais
-
-IMPORTANTE: Retorne apenas o JSON válido, sem markdown code blocks, sem texto antes ou depois.`, count, subject, subject)
-
-	var lastError error
-
-	for _, modelName := range modelsToTry {
-		text, err := s.generateContent(modelName, prompt)
-		if err != nil {
-			if strings.Contains(err.Error(), "429") || strings.Contains(err.Error(), "quota") {
-				time.Sleep(30 * time.Second)
-				text, err = s.generateContent(modelName, prompt)
-				if err != nil {
-					lastError = err
-					continue
-				}
-			} else {
-				lastError = err
-				continue
+// generateWithRetry chama o modelo trocando de API key quando a chave usada é recusada ou
+// fica sem quota e há outra disponível. Se todas as chaves estiverem sem quota, aguarda a
+// primeira ser liberada (no máximo QuotaRetryDelay) e tenta novamente uma vez.
+func (s *GeminiService) generateWithRetry(ctx context.Context, modelName, prompt string) (string, error) {
+	text, err := s.generateContent(ctx, modelName, prompt)
+	waited := false
+	for attempt := 1; err != nil; attempt++ {
+		// Cada chave é tentada no máximo uma vez sem espera
+		switchKey := attempt < s.Keys.Len()
+		switch {
+		case errors.Is(err, apperror.ErrUnauthorized) && switchKey && s.Keys.Usable() > 0:
+			slog.WarnContext(ctx, "API key recusada, tentando com a próxima", "model", modelName)
+			metrics.ModelRetriesTotal.WithLabelValues(modelName, "api_key").Inc()
+
+		case isQuotaError(err) && switchKey && s.Keys.Wait() == 0:
+			slog.WarnContext(ctx, "quota excedida, tentando com a próxima API key", "model", modelName)
+			metrics.ModelRetriesTotal.WithLabelValues(modelName, "quota").Inc()
+
+		case isQuotaError(err) && !waited:
+			waited = true
+			wait := min(s.Keys.Wait(), s.current().QuotaRetryDelay)
+			slog.WarnContext(ctx, "quota excedida, aguardando para tentar novamente", "model", modelName, "wait", wait.String())
+			_, waitSpan := tracing.Start(ctx, "gemini.retry_wait", tracing.AttrModel.String(modelName))
+			select {
+			case <-time.After(wait):
+				waitSpan.End()
+			case <-ctx.Done():
+				waitSpan.End()
+				return "", ctx.Err()
 			}
-		}
-
-		jsonText := cleanJSONText(text)
-
-		var topicsResp models.TopicsResponse
-		if err := json.Unmarshal([]byte(jsonText), &topicsResp); err != nil {
-			lastError = fmt.Errorf("erro ao fazer parse do JSON: %v", err)
-			continue
-		}
+			// Tentar novamente este modelo
+			metrics.ModelRetriesTotal.WithLabelValues(modelName, "quota").Inc()
 
-		if topicsResp.Subject == "" || len(topicsResp.Topics) == 0 {
-			lastError = fmt.Errorf("resposta do Gemini não está no formato esperado")
-			continue
+		default:
+			return "", err
 		}
-
-		return &topicsResp, nil
-	}
-
-	if lastError != nil {
-		return nil, fmt.Errorf("erro ao gerar tópicos: %v", lastError)
+		text, err = s.generateContent(ctx, modelName, prompt)
 	}
 
-	return nil, fmt.Errorf("erro ao gerar tópicos: nenhum modelo disponível funcionou")
+	return text, nil
 }
 
-// getTimeDistributionInstructions retorna instruções sobre como distribuir Key Results no tempo
-func getTimeDistributionInstructions(completionDate *string) string {
-	if completionDate == nil || *completionDate == "" {
-		return ""
-	}
+// modelAttempt acompanha uma tentativa de geração em um modelo, para métricas, logs e tracing
+type modelAttempt struct {
+	ctx           context.Context
+	span          trace.Span
+	stats         *ModelStats
+	breakers      *Breakers
+	operation     string
+	model         string
+	promptHash    string
+	promptVersion string
+	language      string
+	start         time.Time
+}
 
-	completionTime, err := time.Parse("2006-01-02", *completionDate)
-	if err != nil {
-		return ""
+// startAttempt inicia o acompanhamento de uma tentativa em um modelo, abrindo um span filho
+func (s *GeminiService) startAttempt(ctx context.Context, operation, model string, prompt prompts.Prompt) *modelAttempt {
+	promptHash := hashPrompt(prompt.Text)
+	ctx, span := tracing.Start(ctx, "gemini.attempt",
+		tracing.AttrOperation.String(operation),
+		tracing.AttrModel.String(model),
+		tracing.AttrPromptHash.String(promptHash),
+		tracing.AttrPromptVersion.String(prompt.String()),
+		tracing.AttrLanguage.String(prompt.Language),
+	)
+
+	return &modelAttempt{
+		ctx:           ctx,
+		span:          span,
+		stats:         s.Stats,
+		breakers:      s.Breakers,
+		operation:     operation,
+		model:         model,
+		promptHash:    promptHash,
+		promptVersion: prompt.String(),
+		language:      prompt.Language,
+		start:         time.Now(),
 	}
+}
 
-	now := time.Now()
-	daysRemaining := int(completionTime.Sub(now).Hours() / 24)
-	monthsRemaining := daysRemaining / 30
+// parse extrai o JSON do texto do modelo e faz o unmarshal em v, dentro de um span próprio
+func (a *modelAttempt) parse(text string, v interface{}) error {
+	_, span := tracing.Start(a.ctx, "gemini.parse")
+	defer span.End()
 
-	if daysRemaining < 0 {
-		return "Distribuição temporal: Todos os Key Results devem ser realizáveis imediatamente, priorizando resultados rápidos."
-	} else if monthsRemaining < 3 {
-		return "Distribuição temporal: Todos os Key Results devem ser realizáveis em curto prazo (semanas). Priorize resultados rápidos e simples."
-	} else if monthsRemaining <= 6 {
-		return fmt.Sprintf("Distribuição temporal: Distribua os Key Results ao longo de %d meses - alguns no primeiro mês (início), outros no meio do período, e alguns no final. Complexidade moderada.", monthsRemaining)
-	} else {
-		return fmt.Sprintf("Distribuição temporal: Distribua os Key Results progressivamente ao longo de %d meses - Key Results iniciais (primeiro mês), intermediários (meio do período), e finais (último mês). Pode incluir Key Results mais complexos e ambiciosos.", monthsRemaining)
+	if err := json.Unmarshal([]byte(cleanJSONText(text)), v); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "JSON inválido")
+		return apperror.Wrap(apperror.KindOutputInvalid, apperror.CodeOutputInvalid, "erro ao fazer parse do JSON", err)
 	}
+	return nil
 }
 
-// GenerateKeyResults gera uma lista de Key Results mensuráveis para um objetivo OKR
-func (s *GeminiService) GenerateKeyResults(objective string, count int, completionDate *string) (*models.KeyResultsResponse, error) {
-	if objective == "" {
-		return nil, fmt.Errorf("objetivo não pode ser vazio")
-	}
+// validate executa a validação da resposta dentro de um span próprio
+func (a *modelAttempt) validate(check func() error) error {
+	_, span := tracing.Start(a.ctx, "gemini.validate")
+	defer span.End()
 
-	if count <= 0 {
-		count = 5 // Default para Key Results
+	if err := check(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "resposta rejeitada")
+		return apperror.Wrap(apperror.KindOutputInvalid, apperror.CodeOutputInvalid, "resposta rejeitada na validação", err)
 	}
+	return nil
+}
 
-	// Listar modelos disponíveis
-	availableModels, _ := s.listAvailableModels()
-
-	modelsToTry := make([]string, 0)
-	seen := make(map[string]bool)
-
-	for _, model := range availableModels {
-		modelsToTry = append(modelsToTry, model)
-		seen[model] = true
+// failed finaliza a tentativa com erro de chamada ao modelo (quota ou erro da API)
+func (a *modelAttempt) failed(err error) {
+	outcome := metrics.OutcomeError
+	switch {
+	case errors.Is(a.ctx.Err(), context.Canceled):
+		outcome = metrics.OutcomeCancelled
+	case isQuotaError(err):
+		outcome = metrics.OutcomeQuota
 	}
+	a.finish(outcome, err)
+}
 
-	fallbacks := []string{
-		"gemini-1.5-flash-latest",
-		"gemini-1.5-pro-latest",
-		"gemini-pro",
-		"gemini-1.5-flash",
-		"gemini-1.5-pro",
+// finish registra o resultado da tentativa nas métricas, no log e no span
+func (a *modelAttempt) finish(outcome string, reason error) {
+	metrics.RecordModelCall(a.model, outcome)
+	if a.stats != nil {
+		a.stats.Record(a.model, outcome)
 	}
-
-	for _, model := range fallbacks {
-		if !seen[model] {
-			modelsToTry = append(modelsToTry, model)
+	if a.breakers != nil {
+		if outcome == metrics.OutcomeCancelled {
+			// O erro da chamada interrompida não diz nada sobre o modelo
+			reason = context.Canceled
 		}
+		a.breakers.Record(a.model, reason)
 	}
 
-	// Calcular informações sobre o prazo
-	var timeContext string
-	if completionDate != nil && *completionDate != "" {
-		completionTime, err := time.Parse("2006-01-02", *completionDate)
-		if err == nil {
-			now := time.Now()
-			daysRemaining := int(completionTime.Sub(now).Hours() / 24)
-			monthsRemaining := daysRemaining / 30
-			
-			if daysRemaining < 0 {
-				timeContext = fmt.Sprintf("\n\n⚠️ ATENÇÃO: A data de conclusão (%s) já passou. Ajuste os Key Results para serem realizáveis no menor tempo possível.", *completionDate)
-			} else if monthsRemaining < 3 {
-				timeContext = fmt.Sprintf("\n\n⏰ PRAZO: Este OKR deve ser concluído em %d dias (menos de 3 meses). Gere Key Results SIMPLES, DIRETOS e REALIZÁVEIS no curto prazo. Priorize resultados rápidos e de baixa complexidade.", daysRemaining)
-			} else if monthsRemaining <= 6 {
-				timeContext = fmt.Sprintf("\n\n⏰ PRAZO: Este OKR deve ser concluído em %d dias (aproximadamente %d meses). Distribua os Key Results ao longo do tempo: alguns no primeiro mês, outros no meio do período, e alguns no final. Complexidade MODERADA.", daysRemaining, monthsRemaining)
-			} else {
-				timeContext = fmt.Sprintf("\n\n⏰ PRAZO: Este OKR deve ser concluído em %d dias (aproximadamente %d meses). Distribua os Key Results progressivamente: Key Results iniciais (primeiro mês), intermediários (meio do período), e finais (último mês). Pode incluir Key Results mais complexos e ambiciosos.", daysRemaining, monthsRemaining)
-			}
-		}
+	a.span.SetAttributes(tracing.AttrOutcome.String(outcome))
+	if reason != nil {
+		a.span.SetStatus(codes.Error, outcome)
 	}
+	a.span.End()
 
-	// Prompt específico para gerar Key Results mensuráveis para OKRs
-	prompt := fmt.Sprintf(`Você é um especialista em OKRs (Objectives and Key Results).
-
-Gere uma lista de %d Key Results mensuráveis e específicos para o seguinte objetivo: "%s"%s
-
-Key Results devem ser:
-- Mensuráveis (com métricas claras)
-- Específicos e acionáveis
-- Alinhados com o objetivo
-- Focados em resultados, não apenas em atividades
-- Realistas e alcançáveis
-
-%s
-
-A resposta deve ser APENAS um JSON válido, sem markdown, sem texto adicional, seguindo EXATAMENTE esta estrutura:
-
-{
-  "objective": "%s",
-  "key_results": [
-    "Key Result 1",
-    "Key Result 2",
-    "Key Result 3"
-  ]
-}
-
-Requisitos:
-- Cada Key Result deve ser uma frase clara e mensurável
-- Use métricas específicas quando possível (números, percentuais, etc.)
-- Foque em resultados que demonstrem progresso em direção ao objetivo
-- Seja conciso mas específico
-- Retorne APENAS o JSON, sem explicações adicionais
-
-IMPORTANTE: Retorne apenas o JSON válido, sem markdown code blocks, sem texto antes ou depois.`, count, objective, timeContext, getTimeDistributionInstructions(completionDate), objective)
-
-	var lastError error
-
-	for _, modelName := range modelsToTry {
-		text, err := s.generateContent(modelName, prompt)
-		if err != nil {
-			if strings.Contains(err.Error(), "429") || strings.Contains(err.Error(), "quota") {
-				time.Sleep(30 * time.Second)
-				text, err = s.generateContent(modelName, prompt)
-				if err != nil {
-					lastError = err
-					continue
-				}
-			} else {
-				lastError = err
-				continue
-			}
-		}
-
-		jsonText := cleanJSONText(text)
-
-		var keyResultsResp models.KeyResultsResponse
-		if err := json.Unmarshal([]byte(jsonText), &keyResultsResp); err != nil {
-			lastError = fmt.Errorf("erro ao fazer parse do JSON: %v", err)
-			continue
-		}
-
-		if keyResultsResp.Objective == "" || len(keyResultsResp.KeyResults) == 0 {
-			lastError = fmt.Errorf("resposta do Gemini não está no formato esperado")
-			continue
-		}
-
-		return &keyResultsResp, nil
+	attrs := []any{
+		"operation", a.operation,
+		"model", a.model,
+		"prompt_hash", a.promptHash,
+		"prompt_version", a.promptVersion,
+		"language", a.language,
+		"latency_ms", time.Since(a.start).Milliseconds(),
+		"outcome", outcome,
 	}
-
-	if lastError != nil {
-		return nil, fmt.Errorf("erro ao gerar Key Results: %v", lastError)
+	if reason != nil {
+		slog.WarnContext(a.ctx, "tentativa rejeitada", append(attrs, "reason", reason.Error())...)
+		return
 	}
-
-	return nil, fmt.Errorf("erro ao gerar Key Results: nenhum modelo disponível funcionou")
+	slog.InfoContext(a.ctx, "tentativa concluída", attrs...)
 }
 
-// GenerateEducationalRoadmap gera um roadmap educacional detalhado com livros, cursos, vídeos, artigos e projetos
-func (s *GeminiService) GenerateEducationalRoadmap(topic string) (*models.EducationalRoadmap, error) {
-	if topic == "" {
-		return nil, fmt.Errorf("tópico não pode ser vazio")
-	}
-
-	// Listar modelos disponíveis
-	availableModels, _ := s.listAvailableModels()
-
-	modelsToTry := make([]string, 0)
-	seen := make(map[string]bool)
+// hashPrompt retorna um hash curto do prompt para correlacionar tentativas nos logs
+func hashPrompt(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])[:16]
+}
 
-	for _, model := range availableModels {
-		modelsToTry = append(modelsToTry, model)
-		seen[model] = true
-	}
+// isQuotaError verifica se o erro indica quota excedida
+func isQuotaError(err error) bool {
+	return errors.Is(err, apperror.ErrUpstreamQuota)
+}
 
-	fallbacks := []string{
-		"gemini-1.5-flash-latest",
-		"gemini-1.5-pro-latest",
-		"gemini-pro",
-		"gemini-1.5-flash",
-		"gemini-1.5-pro",
-	}
+// cleanJSONText limpa o texto para extrair apenas o JSON
+func cleanJSONText(text string) string {
+	// Remover markdown code blocks
+	text = regexp.MustCompile("(?i)```json\\s*").ReplaceAllString(text, "")
+	text = regexp.MustCompile("(?i)```\\s*").ReplaceAllString(text, "")
+	text = strings.TrimSpace(text)
 
-	for _, model := range fallbacks {
-		if !seen[model] {
-			modelsToTry = append(modelsToTry, model)
-		}
+	// Tentar encontrar o JSON no texto
+	jsonMatch := regexp.MustCompile(`\{[\s\S]*\}`).FindString(text)
+	if jsonMatch != "" {
+		return jsonMatch
 	}
 
-	// Prompt para gerar roadmap educacional
-	prompt := fmt.Sprintf(`Você é um especialista em criar roadmaps educacionais detalhados e estruturados.
-
-Crie um roadmap educacional completo e bem organizado sobre: "%s"
-
-O roadmap deve ser retornado APENAS como um JSON válido, sem markdown, sem texto adicional, seguindo EXATAMENTE esta estrutura:
-
-{
-  "topic": "%s",
-  "books": [
-    {
-      "title": "Nome do Livro",
-      "description": "Descrição do livro",
-      "author": "Nome do Autor",
-      "chapters": ["Capítulo 1", "Capítulo 2", "Capítulo 3"],
-      "url": "URL do livro (se disponível)"
-    }
-  ],
-  "courses": [
-    {
-      "title": "Nome do Curso",
-      "description": "Descrição do curso",
-      "duration": "Duração estimada",
-      "url": "URL do curso"
-    }
-  ],
-  "videos": [
-    {
-      "title": "Nome do Vídeo",
-      "description": "Descrição do vídeo",
-      "duration": "Duração do vídeo",
-      "url": "URL do vídeo"
-    }
-  ],
-  "articles": [
-    {
-      "title": "Nome do Artigo",
-      "description": "Descrição do artigo",
-      "url": "URL do artigo"
-    }
-  ],
-  "projects": [
-    {
-      "title": "Nome do Projeto",
-      "description": "Descrição do projeto lúdico para consolidar conhecimento",
-      "url": "URL de referência (se disponível)"
-    }
-  ]
-}
-
-Requisitos:
-- Inclua 3-5 livros relevantes com seus principais capítulos
-- Inclua 3-5 cursos online ou presenciais
-- Inclua 5-10 vídeos educacionais (YouTube, etc)
-- Inclua 5-10 artigos técnicos ou tutoriais
-- Inclua 3-5 projetos práticos e lúdicos para consolidar o conhecimento
-- Seja específico e prático nas descrições
-- Organize de forma progressiva (do básico ao avançado)
-- Retorne APENAS o JSON, sem explicações adicionais
-
-IMPORTANTE: Retorne apenas o JSON válido, sem markdown code blocks, sem texto antes ou depois.`, topic, topic)
-
-	var lastError error
-
-	for _, modelName := range modelsToTry {
-		text, err := s.generateContent(modelName, prompt)
-		if err != nil {
-			if strings.Contains(err.Error(), "429") || strings.Contains(err.Error(), "quota") {
-				time.Sleep(30 * time.Second)
-				text, err = s.generateContent(modelName, prompt)
-				if err != nil {
-					lastError = err
-					continue
-				}
-			} else {
-				lastError = err
-				continue
-			}
-		}
-
-		jsonText := cleanJSONText(text)
-
-		var educationalRoadmap models.EducationalRoadmap
-		if err := json.Unmarshal([]byte(jsonText), &educationalRoadmap); err != nil {
-			lastError = fmt.Errorf("erro ao fazer parse do JSON: %v", err)
-			continue
-		}
+	return text
+}
 
-		// Validar estrutura básica
-		if educationalRoadmap.Topic == "" {
-			lastError = fmt.Errorf("resposta do Gemini não está no formato esperado")
-			continue
-		}
+// GenerateRoadmap gera um roadmap de estudo usando o Gemini
+func (s *GeminiService) GenerateRoadmap(ctx context.Context, topic string, availableDays *int, exactItemCount *int, language string) (*models.Roadmap, error) {
+	return roadmapPipeline.Run(ctx, s, roadmapParams{Topic: topic, AvailableDays: availableDays, ExactItemCount: exactItemCount}, language)
+}
 
-		return &educationalRoadmap, nil
-	}
+// GenerateTopics gera uma lista de tópicos sobre um assunto
+func (s *GeminiService) GenerateTopics(ctx context.Context, subject string, count int, language string) (*models.TopicsResponse, error) {
+	return topicsPipeline.Run(ctx, s, topicsParams{Subject: subject, Count: count}, language)
+}
 
-	if lastError != nil {
-		return nil, fmt.Errorf("erro ao gerar roadmap educacional: %v", lastError)
-	}
+// GenerateKeyResults gera uma lista de Key Results mensuráveis para um objetivo OKR
+func (s *GeminiService) GenerateKeyResults(ctx context.Context, objective string, count int, completionDate *string, language string) (*models.KeyResultsResponse, error) {
+	return keyResultsPipeline.Run(ctx, s, keyResultsParams{Objective: objective, Count: count, CompletionDate: completionDate}, language)
+}
 
-	return nil, fmt.Errorf("erro ao gerar roadmap educacional: nenhum modelo disponível funcionou")
+// GenerateEducationalRoadmap gera um roadmap educacional detalhado com livros, cursos, vídeos, artigos e projetos
+func (s *GeminiService) GenerateEducationalRoadmap(ctx context.Context, topic string, language string) (*models.EducationalRoadmap, error) {
+	return educationalRoadmapPipeline.Run(ctx, s, educationalRoadmapParams{Topic: topic, Grounding: s.grounding(topic)}, language)
 }
 
 // GenerateEducationalTrail gera uma trilha educacional estruturada em dias/etapas
-func (s *GeminiService) GenerateEducationalTrail(topic string, availableDays *int) (*models.EducationalTrail, error) {
-	if topic == "" {
-		return nil, fmt.Errorf("tópico não pode ser vazio")
-	}
-
-	// Listar modelos disponíveis
-	availableModels, _ := s.listAvailableModels()
-
-	modelsToTry := make([]string, 0)
-	seen := make(map[string]bool)
-
-	for _, model := range availableModels {
-		modelsToTry = append(modelsToTry, model)
-		seen[model] = true
-	}
-
-	fallbacks := []string{
-		"gemini-1.5-flash-latest",
-		"gemini-1.5-pro-latest",
-		"gemini-pro",
-		"gemini-1.5-flash",
-		"gemini-1.5-pro",
-	}
-
-	for _, model := range fallbacks {
-		if !seen[model] {
-			modelsToTry = append(modelsToTry, model)
-		}
-	}
-
-	// Determinar dias totais e atividades por dia baseado em availableDays
-	totalDays := 12
-	activitiesPerDay := "2-3"
-	timeContext := ""
-	
-	if availableDays != nil && *availableDays > 0 {
-		totalDays = *availableDays
-		
-		if totalDays < 7 {
-			// Tempo curto: focar em essencial, menos atividades
-			activitiesPerDay = "1-2"
-			timeContext = fmt.Sprintf("\n\n⏰ PRAZO LIMITADO: Esta trilha deve ser concluída em %d dias. Foque em conteúdo ESSENCIAL e DIRETO. Priorize atividades rápidas e práticas. Menos atividades por dia (1-2), mas bem focadas.", totalDays)
-		} else if totalDays <= 14 {
-			// Tempo médio: estrutura balanceada
-			activitiesPerDay = "2-3"
-			timeContext = fmt.Sprintf("\n\n⏰ PRAZO: Esta trilha deve ser concluída em %d dias. Mantenha um ritmo balanceado com 2-3 atividades por dia.", totalDays)
-		} else {
-			// Tempo longo: conteúdo mais aprofundado
-			activitiesPerDay = "3-4"
-			timeContext = fmt.Sprintf("\n\n⏰ PRAZO: Esta trilha deve ser concluída em %d dias. Você tem tempo suficiente para conteúdo mais aprofundado. Pode incluir 3-4 atividades por dia e materiais mais extensos.", totalDays)
-		}
-	}
-
-	// Prompt para gerar trilha educacional estruturada (otimizado para ser mais rápido)
-	prompt := fmt.Sprintf(`Crie uma trilha educacional de %d dias sobre: "%s"%s
-
-Retorne APENAS JSON válido, sem markdown:
-
-{
-  "topic": "%s",
-  "total_days": %d,
-  "description": "Trilha de aprendizado progressiva",
-  "resources": {
-    "recurso_1": {"title": "Nome", "description": "Desc", "author": "Autor", "chapters": ["Cap 1"], "url": ""},
-    "recurso_2": {"title": "Vídeo", "duration": "30 min", "url": ""}
-  },
-  "steps": [
-    {
-      "day": 1,
-      "title": "Dia 1: Título",
-      "description": "O que será aprendido",
-      "activities": [
-        {
-          "type": "read_chapters",
-          "resource_id": "recurso_1",
-          "title": "Ler capítulos 1-3",
-          "description": "Foque em...",
-          "chapters": ["Cap 1", "Cap 2"],
-          "progress": "3 de 10 capítulos"
-        }
-      ]
-    }
-  ]
-}
-
-Regras IMPORTANTES:
-- EXATAMENTE %d dias, %s atividades por dia
-- O campo "total_days" no JSON DEVE ser %d
-- Tipos: read_chapters, watch_video, read_article, take_course, do_project
-- Progressivo: básico → avançado → prática
-- Seja específico: "Ler capítulos 1-3" não "Ler livro"
-- Inclua progresso quando relevante
-- Projetos no final
-- Distribua o conteúdo proporcionalmente ao longo dos %d dias
-
-CRITÉRIOS PARA RECURSOS (LIVROS, CURSOS, VÍDEOS, ARTIGOS):
-- Use APENAS recursos amplamente conhecidos, estabelecidos e reconhecidos na área
-- Priorize recursos clássicos, best-sellers e materiais amplamente utilizados
-- Evite recursos muito recentes, específicos ou obscuros que podem não existir
-- Para livros: use apenas livros famosos, best-sellers ou clássicos da área (ex: "Clean Code", "Design Patterns", "The Pragmatic Programmer")
-- Para cursos: use plataformas conhecidas (Coursera, edX, Udemy) e cursos populares/verificados
-- Para vídeos: use canais conhecidos e vídeos populares (YouTube, com muitos views)
-- Para artigos: use artigos de sites conhecidos e estabelecidos
-- Se não tiver certeza se um recurso existe, prefira recursos genéricos ou bem conhecidos
-- URLs devem ser válidas e acessíveis - evite URLs quebradas ou inexistentes
-- Se não souber uma URL específica, deixe o campo "url" vazio ao invés de inventar uma
-
-APENAS JSON, sem markdown.`, totalDays, topic, timeContext, topic, totalDays, activitiesPerDay, totalDays, totalDays)
-
-	var lastError error
-
-	for _, modelName := range modelsToTry {
-		text, err := s.generateContent(modelName, prompt)
-		if err != nil {
-			if strings.Contains(err.Error(), "429") || strings.Contains(err.Error(), "quota") {
-				time.Sleep(30 * time.Second)
-				text, err = s.generateContent(modelName, prompt)
-				if err != nil {
-					lastError = err
-					continue
-				}
-			} else {
-				lastError = err
-				continue
-			}
-		}
-
-		jsonText := cleanJSONText(text)
-
-		var trail models.EducationalTrail
-		if err := json.Unmarshal([]byte(jsonText), &trail); err != nil {
-			lastError = fmt.Errorf("erro ao fazer parse do JSON: %v", err)
-			continue
-		}
-
-		// Validar estrutura básica
-		if trail.Topic == "" || len(trail.Steps) == 0 {
-			lastError = fmt.Errorf("resposta do Gemini não está no formato esperado")
-			continue
-		}
-
-		return &trail, nil
-	}
-
-	if lastError != nil {
-		return nil, fmt.Errorf("erro ao gerar trilha educacional: %v", lastError)
-	}
-
-	return nil, fmt.Errorf("erro ao gerar trilha educacional: nenhum modelo disponível funcionou")
+func (s *GeminiService) GenerateEducationalTrail(ctx context.Context, topic string, availableDays *int, language string) (*models.EducationalTrail, error) {
+	return educationalTrailPipeline.Run(ctx, s, educationalTrailParams{Topic: topic, AvailableDays: availableDays, Grounding: s.grounding(topic)}, language)
 }
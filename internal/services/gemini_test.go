@@ -1,9 +1,20 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/fakegemini"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestGeminiService_NewGeminiService(t *testing.T) {
@@ -11,7 +22,7 @@ func TestGeminiService_NewGeminiService(t *testing.T) {
 	service := NewGeminiService(apiKey)
 
 	assert.NotNil(t, service)
-	assert.Equal(t, apiKey, service.APIKey)
+	assert.Equal(t, 1, service.Keys.Len())
 	assert.NotNil(t, service.HTTPClient)
 	assert.Equal(t, "https://generativelanguage.googleapis.com/v1beta", service.BaseURL)
 }
@@ -19,7 +30,7 @@ func TestGeminiService_NewGeminiService(t *testing.T) {
 func TestGeminiService_GenerateRoadmap_EmptyTopic(t *testing.T) {
 	service := NewGeminiService("test-key")
 	
-	roadmap, err := service.GenerateRoadmap("")
+	roadmap, err := service.GenerateRoadmap(context.Background(), "", nil, nil, "pt-BR")
 	
 	assert.Nil(t, roadmap)
 	assert.Error(t, err)
@@ -29,7 +40,7 @@ func TestGeminiService_GenerateRoadmap_EmptyTopic(t *testing.T) {
 func TestGeminiService_GenerateTopics_EmptySubject(t *testing.T) {
 	service := NewGeminiService("test-key")
 	
-	topics, err := service.GenerateTopics("", 10)
+	topics, err := service.GenerateTopics(context.Background(), "", 10, "pt-BR")
 	
 	assert.Nil(t, topics)
 	assert.Error(t, err)
@@ -41,7 +52,7 @@ func TestGeminiService_GenerateTopics_DefaultCount(t *testing.T) {
 	
 	// Testa que count 0 ou negativo usa default
 	// Como não temos API key real, vamos apenas testar a validação
-	topics, err := service.GenerateTopics("Python", 0)
+	topics, err := service.GenerateTopics(context.Background(), "Python", 0, "pt-BR")
 	
 	// Deve falhar por falta de API key, mas não por count inválido
 	assert.Nil(t, topics)
@@ -81,3 +92,262 @@ func TestCleanJSONText(t *testing.T) {
 	}
 }
 
+
+func TestGeminiService_ListAvailableModels_UsesCache(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"models":[{"name":"models/gemini-test"},{"name":"models/text-embedding-004"}]}`))
+	}))
+	defer server.Close()
+
+	service := NewGeminiService("test-key")
+	service.BaseURL = server.URL
+
+	first, err := service.listAvailableModels(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gemini-test"}, first)
+
+	second, err := service.listAvailableModels(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, calls)
+}
+
+func TestGeminiService_GenerateTopics_RecordsAttemptSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"models":[{"name":"models/gemini-test"}]}`))
+			return
+		}
+		w.Write([]byte(`{"candidates":[{"content":{"parts":[{"text":"{\"subject\":\"Go\",\"topics\":[\"Goroutines\"]}"}]}}],"usageMetadata":{"promptTokenCount":42,"candidatesTokenCount":7}}`))
+	}))
+	defer server.Close()
+
+	service := NewGeminiService("test-key")
+	service.BaseURL = server.URL
+
+	topics, err := service.GenerateTopics(context.Background(), "Go", 1, "pt-BR")
+	assert.NoError(t, err)
+	assert.Equal(t, "Go", topics.Subject)
+	assert.Equal(t, "v1", topics.PromptVersion)
+
+	names := make([]string, 0)
+	var attempt sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		names = append(names, span.Name())
+		if span.Name() == "gemini.attempt" {
+			attempt = span
+		}
+	}
+	assert.ElementsMatch(t, []string{"gemini.parse", "gemini.validate", "gemini.attempt"}, names)
+
+	attrs := make(map[string]interface{})
+	for _, kv := range attempt.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	assert.Equal(t, "gemini-test", attrs["gen_ai.request.model"])
+	assert.Equal(t, "ok", attrs["spellbook.outcome"])
+	assert.Equal(t, int64(42), attrs["gen_ai.usage.input_tokens"])
+	assert.Equal(t, int64(7), attrs["gen_ai.usage.output_tokens"])
+}
+
+func TestGeminiService_GenerateTopics_TypedErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected error
+	}{
+		{name: "API indisponível", status: http.StatusInternalServerError, body: `{"error":"detalhe interno"}`, expected: apperror.ErrUpstreamUnavailable},
+		{name: "API key recusada", status: http.StatusUnauthorized, body: `{"error":"chave inválida"}`, expected: apperror.ErrUnauthorized},
+		{name: "acesso negado ao modelo", status: http.StatusForbidden, body: `{"error":"sem permissão"}`, expected: &apperror.Error{Kind: apperror.KindUpstreamUnavailable, Code: apperror.CodeModelForbidden}},
+		{name: "JSON inválido", status: http.StatusOK, body: `{"candidates":[{"content":{"parts":[{"text":"não é JSON"}]}}]}`, expected: apperror.ErrOutputInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					w.Write([]byte(`{"models":[]}`))
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			service := NewGeminiService("test-key")
+			service.BaseURL = server.URL
+
+			topics, err := service.GenerateTopics(context.Background(), "Go", 1, "pt-BR")
+
+			assert.Nil(t, topics)
+			assert.True(t, errors.Is(err, tt.expected), "erro inesperado: %v", err)
+		})
+	}
+}
+
+func TestGeminiService_GenerateTopics_MissingAPIKey(t *testing.T) {
+	service := NewGeminiService("")
+
+	_, err := service.GenerateTopics(context.Background(), "Go", 1, "pt-BR")
+
+	var appErr *apperror.Error
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeAPIKeyMissing, appErr.Code)
+}
+
+func TestUpstreamError_Quota(t *testing.T) {
+	err := upstreamError(&http.Response{StatusCode: http.StatusTooManyRequests}, []byte("RESOURCE_EXHAUSTED"))
+
+	assert.True(t, isQuotaError(err))
+	assert.Equal(t, http.StatusTooManyRequests, apperror.From(err).Kind.StatusCode())
+}
+
+func TestUpstreamError_RetryAfter(t *testing.T) {
+	body := []byte(`{"error":{"code":429,"details":[{"@type":"type.googleapis.com/google.rpc.RetryInfo","retryDelay":"17s"}]}}`)
+	err := upstreamError(&http.Response{StatusCode: http.StatusTooManyRequests}, body)
+	assert.Equal(t, 17*time.Second, apperror.From(err).RetryAfter)
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"5"}}}
+	err = upstreamError(resp, []byte("RESOURCE_EXHAUSTED"))
+	assert.Equal(t, 5*time.Second, apperror.From(err).RetryAfter)
+}
+
+func TestUpstreamError_InvalidKey(t *testing.T) {
+	body := []byte(`{"error":{"code":400,"status":"INVALID_ARGUMENT","details":[{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"API_KEY_INVALID"}]}}`)
+	err := upstreamError(&http.Response{StatusCode: http.StatusBadRequest}, body)
+	assert.ErrorIs(t, err, apperror.ErrUnauthorized)
+}
+
+// newFakeService cria um serviço apontando para o Gemini fake, sem espera após quota
+func newFakeService(t *testing.T) (*GeminiService, *fakegemini.Server) {
+	gemini := fakegemini.New()
+	t.Cleanup(func() { gemini.Close() })
+
+	service := NewGeminiService("fake-key")
+	service.BaseURL = gemini.URL
+	service.QuotaRetryDelay = time.Millisecond
+	return service, gemini
+}
+
+func TestGeminiService_Fallback(t *testing.T) {
+	t.Run("429 tenta o mesmo modelo novamente", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		gemini.Enqueue(fakegemini.Quota())
+
+		topics, err := service.GenerateTopics(context.Background(), "Go", 3, "pt-BR")
+
+		assert.NoError(t, err)
+		assert.Len(t, topics.Topics, 3)
+		assert.Equal(t, []string{"gemini-2.5-flash", "gemini-2.5-flash"}, gemini.CalledModels())
+	})
+
+	t.Run("500 passa para o próximo modelo", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		gemini.OnModel("gemini-2.5-flash", fakegemini.ServerError())
+
+		roadmap, err := service.GenerateEducationalRoadmap(context.Background(), "SQL", "en")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "SQL", roadmap.Topic)
+		assert.Equal(t, []string{"gemini-2.5-flash", "gemini-2.5-pro"}, gemini.CalledModels())
+	})
+
+	t.Run("JSON malformado passa para o próximo modelo", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		gemini.Enqueue(fakegemini.Malformed())
+
+		trail, err := service.GenerateEducationalTrail(context.Background(), "SQL", nil, "es")
+
+		assert.NoError(t, err)
+		assert.Len(t, trail.Steps, 12)
+		assert.Equal(t, []string{"gemini-2.5-flash", "gemini-2.5-pro"}, gemini.CalledModels())
+	})
+
+	t.Run("quantidade errada de itens é rejeitada", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		gemini.Enqueue(fakegemini.Roadmap("Rust", 2, 3))
+		days := 10
+
+		roadmap, err := service.GenerateRoadmap(context.Background(), "Rust", &days, nil, "pt-BR")
+
+		assert.NoError(t, err)
+		total := 0
+		for _, category := range roadmap.Roadmap {
+			total += len(category.Items)
+		}
+		assert.Equal(t, 10, total)
+		assert.Equal(t, []string{"gemini-2.5-flash", "gemini-2.5-pro"}, gemini.CalledModels())
+	})
+
+	t.Run("todos os modelos falham", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		gemini.Models = []string{"gemini-2.5-flash"}
+		gemini.OnModel("gemini-2.5-flash", fakegemini.Quota())
+
+		_, err := service.GenerateKeyResults(context.Background(), "Crescer", 3, nil, "pt-BR")
+
+		// Os modelos de fallback não existem no fake (404) e o último erro é o que prevalece
+		assert.True(t, errors.Is(err, apperror.ErrUpstreamUnavailable), "erro inesperado: %v", err)
+		assert.Equal(t, "gemini-2.5-flash", gemini.CalledModels()[0])
+		assert.Equal(t, "gemini-2.5-flash", gemini.CalledModels()[1])
+	})
+}
+
+func TestGeminiService_CheckModels(t *testing.T) {
+	t.Run("catálogo acessível", func(t *testing.T) {
+		service, _ := newFakeService(t)
+		assert.NoError(t, service.CheckModels(context.Background()))
+	})
+
+	t.Run("catálogo vazio", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		gemini.Models = nil
+
+		err := service.CheckModels(context.Background())
+		assert.ErrorIs(t, err, apperror.ErrUpstreamUnavailable)
+	})
+
+	t.Run("API inacessível", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		gemini.Close()
+
+		err := service.CheckModels(context.Background())
+		assert.ErrorIs(t, err, apperror.ErrUpstreamUnavailable)
+	})
+
+	t.Run("sem API key", func(t *testing.T) {
+		service := NewGeminiService("")
+		assert.Error(t, service.CheckModels(context.Background()))
+	})
+}
+
+func TestGeminiService_Apply(t *testing.T) {
+	service, gemini := newFakeService(t)
+	gemini.Enqueue(fakegemini.ServerError())
+
+	service.Apply(Settings{
+		APIKeys:         []string{"outra-chave"},
+		PreferredModels: []string{"gemini-2.5-pro"},
+		FallbackModels:  []string{"gemini-2.5-flash"},
+		QuotaRetryDelay: time.Millisecond,
+	})
+
+	_, err := service.GenerateTopics(context.Background(), "Go", 3, "pt-BR")
+
+	assert.NoError(t, err)
+	// Modelo preferido primeiro, depois os listados pela API, sem repetições
+	assert.Equal(t, []string{"gemini-2.5-pro", "gemini-2.5-flash"}, gemini.CalledModels())
+	assert.Equal(t, "outra-chave", gemini.Requests()[0].APIKey)
+
+	// Trocar a API key descarta o cache de modelos
+	service.Apply(Settings{APIKeys: []string{"terceira-chave"}})
+	assert.Zero(t, service.ModelsCache().Entries)
+}
@@ -0,0 +1,350 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/spellbook/spellbook/internal/prompts"
+)
+
+// errUnexpectedFormat rejeita respostas sem os campos obrigatórios
+var errUnexpectedFormat = fmt.Errorf("resposta do Gemini não está no formato esperado")
+
+type roadmapParams struct {
+	Topic          string
+	AvailableDays  *int
+	ExactItemCount *int
+}
+
+var roadmapPipeline = &Pipeline[roadmapParams, models.Roadmap]{
+	Operation:   "roadmap",
+	Description: "roadmap",
+	Check: func(p roadmapParams) error {
+		if p.Topic == "" {
+			return apperror.New(apperror.KindValidation, apperror.CodeTopicEmpty, "tópico não pode ser vazio")
+		}
+		return nil
+	},
+	Key: func(p roadmapParams) []any { return []any{p.Topic, p.AvailableDays, p.ExactItemCount} },
+	Prompt: func(p roadmapParams) map[string]interface{} {
+		availableDays, exactItemCount := p.AvailableDays, p.ExactItemCount
+
+		// Determinar número de categorias e itens baseado em availableDays e exactItemCount
+		targetItemCount := 30 // Número exato de itens a serem gerados
+		daysAvailable := 30   // Para uso no contexto de prazo
+
+		// Prioridade: usar exactItemCount se disponível, senão calcular baseado em availableDays
+		if exactItemCount != nil && *exactItemCount > 0 {
+			targetItemCount = *exactItemCount
+			if availableDays != nil && *availableDays > 0 {
+				daysAvailable = *availableDays
+			} else {
+				daysAvailable = *exactItemCount
+			}
+		} else if availableDays != nil && *availableDays > 0 {
+			// Calcular proporção de itens baseado no tempo disponível
+			// Aproximadamente 1 item por dia
+			targetItemCount = *availableDays
+			daysAvailable = *availableDays
+		}
+
+		// Calcular número de categorias baseado no número de itens
+		var numCategories, itemsPerCategory, pace string
+		if targetItemCount < 14 {
+			// Tempo curto: focar em essencial
+			numCategories, itemsPerCategory, pace = "3-4", "3-5", "short"
+		} else if targetItemCount <= 30 {
+			// Tempo médio: estrutura balanceada
+			numCategories, itemsPerCategory, pace = "4-6", "5-8", "medium"
+		} else if targetItemCount <= 60 {
+			// Tempo médio-longo: estrutura mais completa
+			numCategories, itemsPerCategory, pace = "5-7", "6-10", "long"
+		} else {
+			// Tempo longo: estrutura extensa mas organizada
+			// Calcular estimativas proporcionais ao número de itens
+			estimatedCategories := 6 + (targetItemCount-60)/15 // Aproximadamente 1 categoria a cada 15 itens extras
+			itemsPerCat := targetItemCount / estimatedCategories
+
+			numCategories = fmt.Sprintf("%d-%d", estimatedCategories-1, estimatedCategories+2)
+			itemsPerCategory = fmt.Sprintf("%d-%d", itemsPerCat-2, itemsPerCat+3)
+			pace = "extended"
+		}
+
+		return map[string]interface{}{
+			"Topic":            p.Topic,
+			"TargetItemCount":  targetItemCount,
+			"DaysAvailable":    daysAvailable,
+			"NumCategories":    numCategories,
+			"ItemsPerCategory": itemsPerCategory,
+			"Pace":             pace,
+		}
+	},
+	Validate: validateRoadmap,
+	PostProcess: func(_ context.Context, _ roadmapParams, roadmap *models.Roadmap, prompt prompts.Prompt) {
+		roadmap.PromptVersion = prompt.Version
+	},
+}
+
+// validateRoadmap verifica a estrutura do roadmap e a quantidade total de itens
+func validateRoadmap(ctx context.Context, params roadmapParams, roadmap *models.Roadmap) error {
+	availableDays, exactItemCount := params.AvailableDays, params.ExactItemCount
+
+	// Validar estrutura básica
+	if roadmap.Topic == "" || len(roadmap.Roadmap) == 0 {
+		return errUnexpectedFormat
+	}
+
+	// Validar quantidade total de itens
+	totalItems := 0
+	for _, category := range roadmap.Roadmap {
+		totalItems += len(category.Items)
+	}
+
+	// Validação rigorosa: usar exactItemCount se disponível, senão availableDays
+	if exactItemCount != nil && *exactItemCount > 0 {
+		// Tolerância de apenas ±1 item para exactItemCount
+		if totalItems != *exactItemCount && totalItems != *exactItemCount+1 && totalItems != *exactItemCount-1 {
+			return fmt.Errorf("roadmap gerado com %d itens, mas o esperado é EXATAMENTE %d itens. Rejeitando e tentando novamente...", totalItems, *exactItemCount)
+		}
+		// Log para debug
+		slog.DebugContext(ctx, "contagem de itens do roadmap validada",
+			"exact_item_count", *exactItemCount, "total_items", totalItems)
+	} else if availableDays != nil && *availableDays > 0 {
+		// Se não tiver exactItemCount, usar availableDays com tolerância de ±2 itens
+		maxExpectedItems := *availableDays + 2
+		minExpectedItems := *availableDays - 2
+		if totalItems > maxExpectedItems || totalItems < minExpectedItems {
+			return fmt.Errorf("roadmap gerado com %d itens, mas o esperado é %d itens (tempo disponível: %d dias). Tentando novamente...", totalItems, *availableDays, *availableDays)
+		}
+		// Log para debug
+		slog.DebugContext(ctx, "contagem de itens do roadmap validada",
+			"available_days", *availableDays, "total_items", totalItems, "expected", *availableDays)
+	}
+
+	return nil
+}
+
+type topicsParams struct {
+	Subject string
+	Count   int
+}
+
+var topicsPipeline = &Pipeline[topicsParams, models.TopicsResponse]{
+	Operation:   "topics",
+	Description: "tópicos",
+	// Tópicos são curtos e sensíveis a latência: com hedging ativo, um segundo modelo é
+	// consultado em paralelo quando o primeiro demora
+	Hedge: true,
+	Check: func(p topicsParams) error {
+		if p.Subject == "" {
+			return apperror.New(apperror.KindValidation, apperror.CodeSubjectEmpty, "assunto não pode ser vazio")
+		}
+		return nil
+	},
+	Key: func(p topicsParams) []any { return []any{p.Subject, p.Count} },
+	Prompt: func(p topicsParams) map[string]interface{} {
+		count := p.Count
+		if count <= 0 {
+			count = 10 // Default
+		}
+		return map[string]interface{}{
+			"Subject": p.Subject,
+			"Count":   count,
+		}
+	},
+	Validate: func(_ context.Context, _ topicsParams, topics *models.TopicsResponse) error {
+		if topics.Subject == "" || len(topics.Topics) == 0 {
+			return errUnexpectedFormat
+		}
+		return nil
+	},
+	PostProcess: func(_ context.Context, _ topicsParams, topics *models.TopicsResponse, prompt prompts.Prompt) {
+		topics.PromptVersion = prompt.Version
+	},
+}
+
+type keyResultsParams struct {
+	Objective      string
+	Count          int
+	CompletionDate *string
+}
+
+var keyResultsPipeline = &Pipeline[keyResultsParams, models.KeyResultsResponse]{
+	Operation:   "key_results",
+	Description: "Key Results",
+	Check: func(p keyResultsParams) error {
+		if p.Objective == "" {
+			return apperror.New(apperror.KindValidation, apperror.CodeObjectiveEmpty, "objetivo não pode ser vazio")
+		}
+		return nil
+	},
+	Key: func(p keyResultsParams) []any { return []any{p.Objective, p.Count, p.CompletionDate} },
+	Prompt: func(p keyResultsParams) map[string]interface{} {
+		count := p.Count
+		if count <= 0 {
+			count = 5 // Default para Key Results
+		}
+
+		// Calcular informações sobre o prazo
+		deadline, daysRemaining, monthsRemaining := completionDeadline(p.CompletionDate)
+		completion := ""
+		if p.CompletionDate != nil {
+			completion = *p.CompletionDate
+		}
+
+		return map[string]interface{}{
+			"Objective":       p.Objective,
+			"Count":           count,
+			"CompletionDate":  completion,
+			"DaysRemaining":   daysRemaining,
+			"MonthsRemaining": monthsRemaining,
+			"Deadline":        deadline,
+		}
+	},
+	Validate: func(_ context.Context, _ keyResultsParams, keyResults *models.KeyResultsResponse) error {
+		if keyResults.Objective == "" || len(keyResults.KeyResults) == 0 {
+			return errUnexpectedFormat
+		}
+		return nil
+	},
+	PostProcess: func(_ context.Context, _ keyResultsParams, keyResults *models.KeyResultsResponse, prompt prompts.Prompt) {
+		keyResults.PromptVersion = prompt.Version
+	},
+}
+
+// completionDeadline classifica o prazo de um OKR a partir da data de conclusão (AAAA-MM-DD).
+// Retorna "none" quando a data não foi informada ou é inválida, ou "past", "short", "medium" e "long".
+func completionDeadline(completionDate *string) (deadline string, daysRemaining int, monthsRemaining int) {
+	if completionDate == nil || *completionDate == "" {
+		return "none", 0, 0
+	}
+
+	completionTime, err := time.Parse("2006-01-02", *completionDate)
+	if err != nil {
+		return "none", 0, 0
+	}
+
+	daysRemaining = int(time.Until(completionTime).Hours() / 24)
+	monthsRemaining = daysRemaining / 30
+
+	if daysRemaining < 0 {
+		return "past", daysRemaining, monthsRemaining
+	} else if monthsRemaining < 3 {
+		return "short", daysRemaining, monthsRemaining
+	} else if monthsRemaining <= 6 {
+		return "medium", daysRemaining, monthsRemaining
+	}
+	return "long", daysRemaining, monthsRemaining
+}
+
+type educationalRoadmapParams struct {
+	Topic     string
+	Grounding grounding
+}
+
+var educationalRoadmapPipeline = &Pipeline[educationalRoadmapParams, models.EducationalRoadmap]{
+	Operation:   "educational_roadmap",
+	Description: "roadmap educacional",
+	Check: func(p educationalRoadmapParams) error {
+		if p.Topic == "" {
+			return apperror.New(apperror.KindValidation, apperror.CodeTopicEmpty, "tópico não pode ser vazio")
+		}
+		return nil
+	},
+	Key: func(p educationalRoadmapParams) []any { return []any{p.Topic} },
+	Prompt: func(p educationalRoadmapParams) map[string]interface{} {
+		return map[string]interface{}{
+			"Topic":   p.Topic,
+			"Catalog": p.Grounding.entries,
+		}
+	},
+	Validate: func(_ context.Context, _ educationalRoadmapParams, roadmap *models.EducationalRoadmap) error {
+		if roadmap.Topic == "" {
+			return errUnexpectedFormat
+		}
+		return nil
+	},
+	PostProcess: func(_ context.Context, p educationalRoadmapParams, roadmap *models.EducationalRoadmap, prompt prompts.Prompt) {
+		roadmap.PromptVersion = prompt.Version
+		p.Grounding.roadmap("educational_roadmap", roadmap)
+	},
+	Links: func(roadmap *models.EducationalRoadmap) []Link {
+		var links []Link
+		for _, resources := range [][]models.EducationalResource{roadmap.Books, roadmap.Courses, roadmap.Videos, roadmap.Articles, roadmap.Projects} {
+			links = append(links, resourceLinks(resources)...)
+		}
+		return links
+	},
+}
+
+type educationalTrailParams struct {
+	Topic         string
+	AvailableDays *int
+	Grounding     grounding
+}
+
+var educationalTrailPipeline = &Pipeline[educationalTrailParams, models.EducationalTrail]{
+	Operation:   "educational_trail",
+	Description: "trilha educacional",
+	Check: func(p educationalTrailParams) error {
+		if p.Topic == "" {
+			return apperror.New(apperror.KindValidation, apperror.CodeTopicEmpty, "tópico não pode ser vazio")
+		}
+		return nil
+	},
+	Key: func(p educationalTrailParams) []any { return []any{p.Topic, p.AvailableDays} },
+	Prompt: func(p educationalTrailParams) map[string]interface{} {
+		// Dias totais e atividades por dia baseados em availableDays
+		plan := newTrailPlan(p.AvailableDays)
+
+		return map[string]interface{}{
+			"Topic":            p.Topic,
+			"TotalDays":        plan.TotalDays,
+			"ActivitiesPerDay": plan.ActivitiesPerDay(),
+			"ProjectsFromDay":  plan.ProjectsFromDay(),
+			"Pace":             plan.Pace,
+			"Catalog":          p.Grounding.entries,
+		}
+	},
+	// O catálogo vem antes da normalização: os IDs saem do título curado e sugestões diferentes
+	// do mesmo recurso do catálogo viram um só. Os dois rodam antes da validação, que confere
+	// os capítulos do recurso que vai na resposta; as métricas de correção contam também as
+	// respostas rejeitadas depois.
+	Normalize: func(ctx context.Context, p educationalTrailParams, trail *models.EducationalTrail) {
+		p.Grounding.trail("educational_trail", trail)
+		normalizeTrail(ctx, trail)
+	},
+	Validate: func(_ context.Context, p educationalTrailParams, trail *models.EducationalTrail) error {
+		if trail.Topic == "" || len(trail.Steps) == 0 {
+			return errUnexpectedFormat
+		}
+		return validateTrail(trail, newTrailPlan(p.AvailableDays))
+	},
+	// Os problemas da trilha rejeitada vão no prompt do próximo modelo
+	Feedback: true,
+	PostProcess: func(_ context.Context, _ educationalTrailParams, trail *models.EducationalTrail, prompt prompts.Prompt) {
+		trail.PromptVersion = prompt.Version
+	},
+	Links: func(trail *models.EducationalTrail) []Link {
+		var links []Link
+		for id, resource := range trail.Resources {
+			links = append(links, Link{URL: resource.URL, Set: func(url, status string) {
+				resource.URL, resource.LinkStatus = url, status
+				trail.Resources[id] = resource
+			}})
+		}
+		for i := range trail.Steps {
+			for j := range trail.Steps[i].Activities {
+				activity := &trail.Steps[i].Activities[j]
+				links = append(links, Link{URL: activity.URL, Set: func(url, status string) {
+					activity.URL, activity.LinkStatus = url, status
+				}})
+			}
+		}
+		return links
+	},
+}
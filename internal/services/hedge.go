@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/metrics"
+)
+
+// HedgeSettings configura o hedging: se o primeiro modelo não responder dentro de Delay, o
+// próximo modelo é consultado em paralelo e vence a primeira resposta válida
+type HedgeSettings struct {
+	// Delay é a espera pelo primeiro modelo antes de consultar o próximo (0 desativa)
+	Delay time.Duration
+	// MaxRatio limita as consultas extras a esta fração das gerações (ex: 0.1 permite no
+	// máximo 10% de chamadas a mais), para o hedging não dobrar o consumo de quota
+	MaxRatio float64
+}
+
+// DefaultHedgeSettings: hedging desativado; ao ativar, até 10% de chamadas extras
+var DefaultHedgeSettings = HedgeSettings{MaxRatio: 0.1}
+
+// hedgeBudgetCap limita quantas consultas extras podem ser acumuladas para uma rajada
+const hedgeBudgetCap = 10
+
+// hedgeBudget controla quantas consultas extras podem ser feitas: cada geração deposita
+// MaxRatio e cada consulta extra retira 1, de modo que elas nunca passam de MaxRatio das gerações
+type hedgeBudget struct {
+	mu     sync.Mutex
+	tokens float64
+}
+
+func (b *hedgeBudget) deposit(ratio float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.tokens+ratio, hedgeBudgetCap)
+}
+
+func (b *hedgeBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// modelResult é o resultado da tentativa em um modelo
+type modelResult[T any] struct {
+	value  T
+	err    error
+	hedged bool
+}
+
+// firstValid tenta os modelos em ordem (pulando os de circuito aberto) até um deles produzir
+// uma resposta válida. Com hedging ativo, se o modelo em andamento não responder dentro de
+// Delay, o próximo é consultado em paralelo (uma vez por geração, se houver saldo); a primeira
+// resposta válida vence e a outra consulta é cancelada. try faz a tentativa completa em um
+// modelo (chamada, parse e validação) e precisa respeitar o cancelamento do contexto.
+func firstValid[T any](ctx context.Context, s *GeminiService, operation string, modelsToTry []string, hedge HedgeSettings, try func(ctx context.Context, model string) (T, error)) (T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Buffer para as tentativas canceladas terminarem sem bloquear
+	results := make(chan modelResult[T], len(modelsToTry))
+	next, inFlight := 0, 0
+	launch := func(hedged bool) bool {
+		for next < len(modelsToTry) {
+			model := modelsToTry[next]
+			next++
+			if !s.Breakers.Allow(model) {
+				continue
+			}
+			inFlight++
+			go func() {
+				value, err := try(ctx, model)
+				results <- modelResult[T]{value: value, err: err, hedged: hedged}
+			}()
+			return true
+		}
+		return false
+	}
+
+	var zero T
+	if hedge.Delay > 0 {
+		s.hedges.deposit(hedge.MaxRatio)
+	}
+	if !launch(false) {
+		return zero, nil
+	}
+
+	var timer <-chan time.Time
+	if hedge.Delay > 0 {
+		timer = time.After(hedge.Delay)
+	}
+
+	var lastError error
+	for inFlight > 0 {
+		select {
+		case <-timer:
+			timer = nil
+			if next >= len(modelsToTry) {
+				continue
+			}
+			if !s.hedges.withdraw() {
+				metrics.HedgesTotal.WithLabelValues(operation, "throttled").Inc()
+				continue
+			}
+			if launch(true) {
+				metrics.HedgesTotal.WithLabelValues(operation, "launched").Inc()
+			}
+
+		case result := <-results:
+			inFlight--
+			if result.err == nil {
+				if result.hedged {
+					metrics.HedgesTotal.WithLabelValues(operation, "won").Inc()
+				}
+				return result.value, nil
+			}
+			lastError = result.err
+			// Sem tentativa em andamento, segue o fallback para o próximo modelo
+			if inFlight == 0 {
+				launch(false)
+			}
+		}
+	}
+
+	return zero, lastError
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/fakegemini"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeminiService_Hedging(t *testing.T) {
+	t.Run("segundo modelo vence quando o primeiro demora", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		service.Hedge = HedgeSettings{Delay: 20 * time.Millisecond, MaxRatio: 1}
+		gemini.OnModel("gemini-2.5-flash", fakegemini.Slow(5*time.Second, fakegemini.Topics("Lento", 3)))
+
+		start := time.Now()
+		topics, err := service.GenerateTopics(context.Background(), "Go", 3, "pt-BR")
+
+		require.NoError(t, err)
+		assert.NotEqual(t, "Lento", topics.Subject)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, []string{"gemini-2.5-flash", "gemini-2.5-pro"}, gemini.CalledModels())
+
+		// A tentativa cancelada não conta como falha do modelo
+		assert.Eventually(t, func() bool {
+			for _, stat := range service.ModelStats() {
+				if stat.Model == "gemini-2.5-flash" {
+					return false
+				}
+			}
+			return len(service.ModelStats()) == 1
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, CircuitClosed, service.CircuitBreakers()[0].State)
+	})
+
+	t.Run("limite de consultas extras", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		// Cada geração libera meia consulta extra: a primeira não faz hedging, a segunda faz
+		service.Hedge = HedgeSettings{Delay: 10 * time.Millisecond, MaxRatio: 0.5}
+		gemini.OnModel("gemini-2.5-flash", fakegemini.Slow(100*time.Millisecond, fakegemini.Topics("Go", 3)))
+
+		_, err := service.GenerateTopics(context.Background(), "Go", 3, "pt-BR")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gemini-2.5-flash"}, gemini.CalledModels())
+
+		_, err = service.GenerateTopics(context.Background(), "Go", 3, "pt-BR")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gemini-2.5-flash", "gemini-2.5-flash", "gemini-2.5-pro"}, gemini.CalledModels())
+	})
+
+	t.Run("desativado por padrão", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		gemini.OnModel("gemini-2.5-flash", fakegemini.Slow(50*time.Millisecond, fakegemini.Topics("Go", 3)))
+
+		_, err := service.GenerateTopics(context.Background(), "Go", 3, "pt-BR")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gemini-2.5-flash"}, gemini.CalledModels())
+	})
+}
+
+func TestHedgeBudget(t *testing.T) {
+	var budget hedgeBudget
+	assert.False(t, budget.withdraw())
+
+	for range 100 {
+		budget.deposit(0.1)
+	}
+	hedges := 0
+	for budget.withdraw() {
+		hedges++
+	}
+	// No máximo 10% das gerações, limitado ao acúmulo máximo
+	assert.InDelta(t, 10, hedges, 1)
+
+	for range 1000 {
+		budget.deposit(1)
+	}
+	hedges = 0
+	for budget.withdraw() {
+		hedges++
+	}
+	assert.Equal(t, hedgeBudgetCap, hedges)
+}
@@ -1,14 +1,19 @@
 package services
 
-import "github.com/spellbook/spellbook/internal/models"
+import (
+	"context"
+
+	"github.com/spellbook/spellbook/internal/models"
+)
 
 // GeminiServiceInterface define a interface para o serviço Gemini
-// Isso permite criar mocks para testes
+// Isso permite criar mocks para testes. O contexto carrega cancelamento e o ID da requisição;
+// language é o código BCP 47 do idioma da resposta (ex: pt-BR, en, es)
 type GeminiServiceInterface interface {
-	GenerateRoadmap(topic string, availableDays *int, exactItemCount *int) (*models.Roadmap, error)
-	GenerateTopics(subject string, count int) (*models.TopicsResponse, error)
-	GenerateKeyResults(objective string, count int, completionDate *string) (*models.KeyResultsResponse, error)
-	GenerateEducationalRoadmap(topic string) (*models.EducationalRoadmap, error)
-	GenerateEducationalTrail(topic string, availableDays *int) (*models.EducationalTrail, error)
+	GenerateRoadmap(ctx context.Context, topic string, availableDays *int, exactItemCount *int, language string) (*models.Roadmap, error)
+	GenerateTopics(ctx context.Context, subject string, count int, language string) (*models.TopicsResponse, error)
+	GenerateKeyResults(ctx context.Context, objective string, count int, completionDate *string, language string) (*models.KeyResultsResponse, error)
+	GenerateEducationalRoadmap(ctx context.Context, topic string, language string) (*models.EducationalRoadmap, error)
+	GenerateEducationalTrail(ctx context.Context, topic string, availableDays *int, language string) (*models.EducationalTrail, error)
 }
 
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+)
+
+// Estados de uma API key no pool
+const (
+	KeyActive      = "active"
+	KeyCoolingDown = "cooling_down"
+	KeyRevoked     = "revoked"
+)
+
+// KeyPool distribui as chamadas entre várias API keys em rodízio. Uma chave que recebe 429
+// fica em espera pelo tempo informado pela API e uma chave recusada (revogada ou inválida)
+// deixa de ser usada.
+type KeyPool struct {
+	mu   sync.Mutex
+	keys []*poolKey
+	next int
+	now  func() time.Time
+}
+
+type poolKey struct {
+	value        string
+	coolingUntil time.Time
+	revoked      bool
+	requests     int
+	quotaErrors  int
+	lastUsed     time.Time
+}
+
+// KeyUsage é o uso de uma API key, sem expor a chave
+type KeyUsage struct {
+	ID           string     `json:"id"` // Últimos caracteres da chave
+	Status       string     `json:"status"`
+	CoolingUntil *time.Time `json:"cooling_until,omitempty"`
+	Requests     int        `json:"requests"`
+	QuotaErrors  int        `json:"quota_errors"`
+	LastUsed     *time.Time `json:"last_used,omitempty"`
+}
+
+// NewKeyPool cria um pool com as chaves informadas (vazias e repetidas são ignoradas)
+func NewKeyPool(keys ...string) *KeyPool {
+	pool := &KeyPool{now: time.Now}
+	pool.SetKeys(keys)
+	return pool
+}
+
+// SetKeys troca as chaves do pool e retorna se a lista mudou. Chaves que continuam no pool
+// mantêm o estado e o uso.
+func (p *KeyPool) SetKeys(keys []string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	previous := make([]string, len(p.keys))
+	for i, key := range p.keys {
+		previous[i] = key.value
+	}
+
+	existing := make(map[string]*poolKey, len(p.keys))
+	for _, key := range p.keys {
+		existing[key.value] = key
+	}
+
+	p.keys = nil
+	seen := make(map[string]bool)
+	for _, value := range keys {
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		if key, ok := existing[value]; ok {
+			p.keys = append(p.keys, key)
+		} else {
+			p.keys = append(p.keys, &poolKey{value: value})
+		}
+	}
+
+	current := make([]string, len(p.keys))
+	for i, key := range p.keys {
+		current[i] = key.value
+	}
+	if slices.Equal(previous, current) {
+		return false
+	}
+	p.next = 0
+	return true
+}
+
+// Len retorna a quantidade de chaves configuradas
+func (p *KeyPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.keys)
+}
+
+// Usable retorna a quantidade de chaves não revogadas, inclusive as em espera
+func (p *KeyPool) Usable() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	usable := 0
+	for _, key := range p.keys {
+		if !key.revoked {
+			usable++
+		}
+	}
+	return usable
+}
+
+// Acquire escolhe a próxima chave em rodízio, pulando as revogadas e as em espera. Se todas
+// estiverem em espera, retorna a que sai da espera primeiro; Wait informa quanto falta.
+func (p *KeyPool) Acquire() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.keys) == 0 {
+		return "", apperror.New(apperror.KindUpstreamUnavailable, apperror.CodeAPIKeyMissing, "GEMINI_API_KEY não configurada")
+	}
+
+	now := p.now()
+	var soonest *poolKey
+	for i := range p.keys {
+		key := p.keys[(p.next+i)%len(p.keys)]
+		if key.revoked {
+			continue
+		}
+		if !now.Before(key.coolingUntil) {
+			p.next = (p.next + i + 1) % len(p.keys)
+			return p.use(key, now), nil
+		}
+		if soonest == nil || key.coolingUntil.Before(soonest.coolingUntil) {
+			soonest = key
+		}
+	}
+
+	if soonest == nil {
+		return "", apperror.New(apperror.KindUnauthorized, apperror.CodeUpstreamRejected, "todas as API keys foram recusadas pela API")
+	}
+	return p.use(soonest, now), nil
+}
+
+func (p *KeyPool) use(key *poolKey, now time.Time) string {
+	key.requests++
+	key.lastUsed = now
+	return key.value
+}
+
+// Wait retorna quanto falta para alguma chave não revogada sair da espera (zero se já houver uma)
+func (p *KeyPool) Wait() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var wait time.Duration = -1
+	for _, key := range p.keys {
+		if key.revoked {
+			continue
+		}
+		remaining := max(key.coolingUntil.Sub(now), 0)
+		if wait < 0 || remaining < wait {
+			wait = remaining
+		}
+	}
+	return max(wait, 0)
+}
+
+// Report registra o erro de uma chamada feita com a chave: 429 coloca a chave em espera pelo
+// RetryAfter do erro (ou por cooldown, se a API não informou) e credencial recusada revoga a chave
+func (p *KeyPool) Report(value string, err error, cooldown time.Duration) {
+	if err == nil {
+		return
+	}
+	appErr := apperror.From(err)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, key := range p.keys {
+		if key.value != value {
+			continue
+		}
+		switch appErr.Kind {
+		case apperror.KindUpstreamQuota:
+			if appErr.RetryAfter > 0 {
+				cooldown = appErr.RetryAfter
+			}
+			key.quotaErrors++
+			if until := p.now().Add(cooldown); until.After(key.coolingUntil) {
+				key.coolingUntil = until
+			}
+		case apperror.KindUnauthorized:
+			key.revoked = true
+		}
+		return
+	}
+}
+
+// Usage retorna o uso de cada chave, na ordem de configuração
+func (p *KeyPool) Usage() []KeyUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	usage := make([]KeyUsage, 0, len(p.keys))
+	for _, key := range p.keys {
+		u := KeyUsage{
+			ID:          keyID(key.value),
+			Status:      KeyActive,
+			Requests:    key.requests,
+			QuotaErrors: key.quotaErrors,
+		}
+		switch {
+		case key.revoked:
+			u.Status = KeyRevoked
+		case now.Before(key.coolingUntil):
+			u.Status = KeyCoolingDown
+			until := key.coolingUntil.UTC()
+			u.CoolingUntil = &until
+		}
+		if !key.lastUsed.IsZero() {
+			lastUsed := key.lastUsed.UTC()
+			u.LastUsed = &lastUsed
+		}
+		usage = append(usage, u)
+	}
+	return usage
+}
+
+// keyID identifica a chave pelos últimos 4 caracteres, para logs e para o endpoint de admin
+func keyID(key string) string {
+	if len(key) <= 4 {
+		return "…"
+	}
+	return "…" + key[len(key)-4:]
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/fakegemini"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPool cria um pool com relógio controlado pelo teste
+func newTestPool(keys ...string) (*KeyPool, *time.Time) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	pool := NewKeyPool(keys...)
+	pool.now = func() time.Time { return now }
+	return pool, &now
+}
+
+func quotaError(retryAfter time.Duration) error {
+	err := apperror.New(apperror.KindUpstreamQuota, apperror.CodeUpstreamQuota, "quota excedida")
+	err.RetryAfter = retryAfter
+	return err
+}
+
+func acquire(t *testing.T, pool *KeyPool) string {
+	t.Helper()
+	key, err := pool.Acquire()
+	require.NoError(t, err)
+	return key
+}
+
+func TestKeyPool_RoundRobin(t *testing.T) {
+	pool, _ := newTestPool("a", "b", "", "c", "a")
+
+	assert.Equal(t, 3, pool.Len())
+	assert.Equal(t, []string{"a", "b", "c", "a"}, []string{acquire(t, pool), acquire(t, pool), acquire(t, pool), acquire(t, pool)})
+}
+
+func TestKeyPool_QuotaCooldown(t *testing.T) {
+	pool, now := newTestPool("a", "b")
+
+	pool.Report(acquire(t, pool), quotaError(10*time.Second), time.Minute)
+	assert.Equal(t, time.Duration(0), pool.Wait())
+	assert.Equal(t, "b", acquire(t, pool))
+	assert.Equal(t, "b", acquire(t, pool))
+
+	// Sem espera informada pela API, usa o cooldown padrão
+	pool.Report("b", quotaError(0), time.Minute)
+	assert.Equal(t, 10*time.Second, pool.Wait())
+	// Todas em espera: a que é liberada primeiro
+	assert.Equal(t, "a", acquire(t, pool))
+
+	*now = now.Add(10 * time.Second)
+	assert.Equal(t, time.Duration(0), pool.Wait())
+	assert.Equal(t, "a", acquire(t, pool))
+
+	usage := pool.Usage()
+	assert.Equal(t, KeyActive, usage[0].Status)
+	assert.Equal(t, KeyCoolingDown, usage[1].Status)
+	assert.Equal(t, 1, usage[1].QuotaErrors)
+	require.NotNil(t, usage[1].CoolingUntil)
+}
+
+func TestKeyPool_Revoked(t *testing.T) {
+	pool, _ := newTestPool("chave-aaaa", "chave-bbbb")
+	rejected := apperror.New(apperror.KindUnauthorized, apperror.CodeUpstreamRejected, "recusada")
+
+	pool.Report(acquire(t, pool), rejected, time.Minute)
+	assert.Equal(t, 1, pool.Usable())
+	assert.Equal(t, "chave-bbbb", acquire(t, pool))
+	assert.Equal(t, "chave-bbbb", acquire(t, pool))
+
+	pool.Report("chave-bbbb", rejected, time.Minute)
+	_, err := pool.Acquire()
+	assert.ErrorIs(t, err, apperror.ErrUnauthorized)
+
+	usage := pool.Usage()
+	assert.Equal(t, "…aaaa", usage[0].ID)
+	assert.Equal(t, KeyRevoked, usage[0].Status)
+	assert.Equal(t, 2, usage[1].Requests)
+}
+
+func TestKeyPool_Empty(t *testing.T) {
+	pool := NewKeyPool()
+	_, err := pool.Acquire()
+	assert.ErrorIs(t, err, &apperror.Error{Kind: apperror.KindUpstreamUnavailable, Code: apperror.CodeAPIKeyMissing})
+}
+
+func TestKeyPool_SetKeysKeepsState(t *testing.T) {
+	pool, _ := newTestPool("a", "b")
+	pool.Report("a", quotaError(time.Minute), time.Minute)
+
+	assert.False(t, pool.SetKeys([]string{"a", "b"}))
+	assert.True(t, pool.SetKeys([]string{"c", "a"}))
+
+	usage := pool.Usage()
+	require.Len(t, usage, 2)
+	assert.Equal(t, KeyActive, usage[0].Status)
+	assert.Equal(t, KeyCoolingDown, usage[1].Status)
+}
+
+func TestGeminiService_KeyRotation(t *testing.T) {
+	t.Run("429 troca para a próxima chave sem esperar", func(t *testing.T) {
+		gemini := fakegemini.New()
+		t.Cleanup(func() { gemini.Close() })
+		service := NewGeminiService("chave-1", "chave-2")
+		service.BaseURL = gemini.URL
+		service.QuotaRetryDelay = time.Hour
+
+		gemini.Enqueue(fakegemini.QuotaRetry(30 * time.Second))
+		_, err := service.GenerateTopics(context.Background(), "Go", 3, "pt-BR")
+		require.NoError(t, err)
+
+		requests := gemini.Requests()
+		require.Len(t, requests, 2)
+		assert.NotEqual(t, requests[0].APIKey, requests[1].APIKey)
+		assert.Equal(t, requests[0].Model, requests[1].Model)
+
+		usage := service.Keys.Usage()
+		cooling := 0
+		for _, u := range usage {
+			if u.Status == KeyCoolingDown {
+				cooling++
+			}
+		}
+		assert.Equal(t, 1, cooling)
+	})
+
+	t.Run("chave revogada deixa de ser usada", func(t *testing.T) {
+		gemini := fakegemini.New()
+		t.Cleanup(func() { gemini.Close() })
+		gemini.OnKey("revogada", fakegemini.InvalidKey())
+		service := NewGeminiService("revogada", "valida")
+		service.BaseURL = gemini.URL
+
+		for range 3 {
+			_, err := service.GenerateTopics(context.Background(), "Go", 3, "pt-BR")
+			require.NoError(t, err)
+		}
+
+		revoked := 0
+		for _, req := range gemini.Requests() {
+			if req.APIKey == "revogada" {
+				revoked++
+			}
+		}
+		assert.LessOrEqual(t, revoked, 1)
+		assert.Equal(t, KeyRevoked, service.Keys.Usage()[0].Status)
+	})
+
+	t.Run("403 de um modelo não revoga a chave", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		gemini.OnModel("gemini-2.5-flash", fakegemini.Forbidden())
+
+		for range 2 {
+			_, err := service.GenerateTopics(context.Background(), "Go", 3, "pt-BR")
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, []string{"gemini-2.5-flash", "gemini-2.5-pro", "gemini-2.5-flash", "gemini-2.5-pro"}, gemini.CalledModels())
+		for _, usage := range service.Keys.Usage() {
+			assert.Equal(t, KeyActive, usage.Status)
+		}
+	})
+
+	t.Run("todas as chaves recusadas", func(t *testing.T) {
+		gemini := fakegemini.New()
+		t.Cleanup(func() { gemini.Close() })
+		gemini.OnKey("a", fakegemini.InvalidKey())
+		gemini.OnKey("b", fakegemini.InvalidKey())
+		service := NewGeminiService("a", "b")
+		service.BaseURL = gemini.URL
+
+		_, err := service.GenerateTopics(context.Background(), "Go", 3, "pt-BR")
+		assert.ErrorIs(t, err, apperror.ErrUnauthorized)
+
+		// Depois disso, as gerações falham sem chamar a API
+		calls := len(gemini.Requests())
+		_, err = service.GenerateTopics(context.Background(), "Go", 3, "pt-BR")
+		assert.ErrorIs(t, err, apperror.ErrUnauthorized)
+		assert.Len(t, gemini.Requests(), calls)
+	})
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/spellbook/spellbook/internal/linkcheck"
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/spellbook/spellbook/internal/tracing"
+)
+
+// Link é a URL de um recurso da resposta. Set grava o resultado da verificação no recurso
+// (no modo strip, url vem vazia para os links quebrados).
+type Link struct {
+	URL string
+	Set func(url, status string)
+}
+
+// resourceLinks aponta as URLs de uma lista de recursos
+func resourceLinks(resources []models.EducationalResource) []Link {
+	links := make([]Link, 0, len(resources))
+	for i := range resources {
+		resource := &resources[i]
+		links = append(links, Link{URL: resource.URL, Set: func(url, status string) {
+			resource.URL, resource.LinkStatus = url, status
+		}})
+	}
+	return links
+}
+
+// verifyLinks verifica as URLs da resposta aceita. No modo flag, o resultado vai para o campo
+// de status; no modo strip, as URLs quebradas também são removidas. URLs vazias são ignoradas.
+func (s *GeminiService) verifyLinks(ctx context.Context, operation string, links []Link) {
+	settings := s.Links.Settings()
+	if settings.Mode == linkcheck.ModeOff || settings.Mode == "" {
+		return
+	}
+
+	var urls []string
+	for i := range links {
+		links[i].URL = strings.TrimSpace(links[i].URL)
+		if links[i].URL != "" {
+			urls = append(urls, links[i].URL)
+		}
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	ctx, span := tracing.Start(ctx, "links.verify", tracing.AttrOperation.String(operation))
+	defer span.End()
+
+	results := s.Links.CheckAll(ctx, urls)
+	counts := make(map[string]int)
+	for _, link := range links {
+		if link.URL == "" {
+			continue
+		}
+		result := results[link.URL]
+		counts[result.Status]++
+		url := link.URL
+		if settings.Mode == linkcheck.ModeStrip && result.Status == linkcheck.StatusBroken {
+			url = ""
+		}
+		link.Set(url, result.Status)
+	}
+
+	slog.InfoContext(ctx, "links verificados", "operation", operation, "mode", settings.Mode,
+		"ok", counts[linkcheck.StatusOK], "broken", counts[linkcheck.StatusBroken], "unverified", counts[linkcheck.StatusUnverified])
+}
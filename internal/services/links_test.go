@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spellbook/spellbook/internal/fakegemini"
+	"github.com/spellbook/spellbook/internal/linkcheck"
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLinkService configura o serviço para verificar os links em um servidor local, onde só
+// /ok existe
+func newLinkService(t *testing.T, mode string) (*GeminiService, *fakegemini.Server, string) {
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ok" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(site.Close)
+
+	service, gemini := newFakeService(t)
+	settings := linkcheck.DefaultSettings
+	settings.Mode = mode
+	service.Links = linkcheck.New(settings, site.Client().Transport)
+	return service, gemini, site.URL
+}
+
+func TestGeminiService_VerifyLinks(t *testing.T) {
+	roadmap := func(siteURL string) models.EducationalRoadmap {
+		return models.EducationalRoadmap{
+			Topic: "Go",
+			Books: []models.EducationalResource{
+				{Title: "Existe", URL: siteURL + "/ok"},
+				{Title: "Não existe", URL: siteURL + "/inventado"},
+				{Title: "Sem URL"},
+			},
+		}
+	}
+
+	t.Run("flag mantém as URLs e informa o resultado", func(t *testing.T) {
+		service, gemini, siteURL := newLinkService(t, linkcheck.ModeFlag)
+		gemini.Enqueue(fakegemini.JSON(roadmap(siteURL)))
+
+		result, err := service.GenerateEducationalRoadmap(context.Background(), "Go", "pt-BR")
+
+		require.NoError(t, err)
+		assert.Equal(t, linkcheck.StatusOK, result.Books[0].LinkStatus)
+		assert.Equal(t, linkcheck.StatusBroken, result.Books[1].LinkStatus)
+		assert.Equal(t, siteURL+"/inventado", result.Books[1].URL)
+		assert.Empty(t, result.Books[2].LinkStatus)
+	})
+
+	t.Run("strip remove as URLs quebradas", func(t *testing.T) {
+		service, gemini, siteURL := newLinkService(t, linkcheck.ModeStrip)
+		gemini.Enqueue(fakegemini.JSON(roadmap(siteURL)))
+
+		result, err := service.GenerateEducationalRoadmap(context.Background(), "Go", "pt-BR")
+
+		require.NoError(t, err)
+		assert.Equal(t, siteURL+"/ok", result.Books[0].URL)
+		assert.Empty(t, result.Books[1].URL)
+		assert.Equal(t, linkcheck.StatusBroken, result.Books[1].LinkStatus)
+	})
+
+	t.Run("off não verifica", func(t *testing.T) {
+		service, gemini, siteURL := newLinkService(t, linkcheck.ModeOff)
+		gemini.Enqueue(fakegemini.JSON(roadmap(siteURL)))
+
+		result, err := service.GenerateEducationalRoadmap(context.Background(), "Go", "pt-BR")
+
+		require.NoError(t, err)
+		assert.Equal(t, siteURL+"/inventado", result.Books[1].URL)
+		assert.Empty(t, result.Books[1].LinkStatus)
+	})
+
+	t.Run("trilha verifica recursos e atividades", func(t *testing.T) {
+		service, gemini, siteURL := newLinkService(t, linkcheck.ModeStrip)
+		days := 1
+		gemini.Enqueue(fakegemini.JSON(models.EducationalTrail{
+			Topic:     "Go",
+			TotalDays: 1,
+			Steps: []models.EducationalTrailStep{{Day: 1, Activities: []models.Activity{
+				{Type: "watch_video", ResourceID: "video", URL: siteURL + "/inventado"},
+			}}},
+			Resources: map[string]models.EducationalResource{
+				"livro": {Title: "Existe", URL: siteURL + "/ok"},
+				"video": {Title: "Não existe", URL: siteURL + "/inventado"},
+			},
+		}))
+
+		result, err := service.GenerateEducationalTrail(context.Background(), "Go", &days, "pt-BR")
+
+		require.NoError(t, err)
+		assert.Equal(t, linkcheck.StatusOK, result.Resources["existe"].LinkStatus)
+		assert.Empty(t, result.Resources["nao-existe"].URL)
+		assert.Equal(t, linkcheck.StatusBroken, result.Resources["nao-existe"].LinkStatus)
+		assert.Empty(t, result.Steps[0].Activities[0].URL)
+		assert.Equal(t, linkcheck.StatusBroken, result.Steps[0].Activities[0].LinkStatus)
+	})
+}
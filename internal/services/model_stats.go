@@ -0,0 +1,109 @@
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/metrics"
+)
+
+// ModelStat resume as tentativas recentes em um modelo
+type ModelStat struct {
+	Model     string    `json:"model"`
+	Calls     int       `json:"calls"`
+	Errors    int       `json:"errors"` // Tentativas sem sucesso, incluindo quota
+	Quota     int       `json:"quota"`  // Tentativas que receberam 429
+	LastError time.Time `json:"last_error,omitempty"`
+	LastQuota time.Time `json:"last_quota,omitempty"`
+}
+
+// ErrorRate retorna a fração de tentativas sem sucesso
+func (m ModelStat) ErrorRate() float64 {
+	if m.Calls == 0 {
+		return 0
+	}
+	return float64(m.Errors) / float64(m.Calls)
+}
+
+// ModelStats guarda os resultados das tentativas por modelo em uma janela deslizante.
+// Complementa as métricas Prometheus (cumulativas) com uma visão recente consultável
+// pelo próprio serviço, usada no health check detalhado.
+type ModelStats struct {
+	Window time.Duration
+
+	mu     sync.Mutex
+	events map[string][]modelEvent
+	now    func() time.Time
+}
+
+type modelEvent struct {
+	at      time.Time
+	outcome string
+}
+
+// NewModelStats cria um registro com a janela informada
+func NewModelStats(window time.Duration) *ModelStats {
+	return &ModelStats{
+		Window: window,
+		events: make(map[string][]modelEvent),
+		now:    time.Now,
+	}
+}
+
+// Record registra o resultado de uma tentativa (um dos metrics.Outcome*). Tentativas
+// canceladas não dizem nada sobre o modelo e são ignoradas.
+func (m *ModelStats) Record(model, outcome string) {
+	if outcome == metrics.OutcomeCancelled {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.events[model] = append(m.prune(m.events[model], now), modelEvent{at: now, outcome: outcome})
+}
+
+// Snapshot retorna o resumo de cada modelo com tentativas na janela, ordenado pelo nome
+func (m *ModelStats) Snapshot() []ModelStat {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	stats := make([]ModelStat, 0, len(m.events))
+	for model, events := range m.events {
+		events = m.prune(events, now)
+		if len(events) == 0 {
+			delete(m.events, model)
+			continue
+		}
+		m.events[model] = events
+
+		stat := ModelStat{Model: model, Calls: len(events)}
+		for _, event := range events {
+			if event.outcome == metrics.OutcomeOK {
+				continue
+			}
+			stat.Errors++
+			stat.LastError = event.at
+			if event.outcome == metrics.OutcomeQuota {
+				stat.Quota++
+				stat.LastQuota = event.at
+			}
+		}
+		stats = append(stats, stat)
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Model < stats[j].Model })
+	return stats
+}
+
+// prune descarta os eventos mais antigos que a janela (os eventos estão em ordem cronológica)
+func (m *ModelStats) prune(events []modelEvent, now time.Time) []modelEvent {
+	cutoff := now.Add(-m.Window)
+	i := 0
+	for i < len(events) && events[i].at.Before(cutoff) {
+		i++
+	}
+	return events[i:]
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/fakegemini"
+	"github.com/spellbook/spellbook/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelStats(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	stats := NewModelStats(10 * time.Minute)
+	stats.now = func() time.Time { return now }
+
+	stats.Record("gemini-2.5-flash", metrics.OutcomeOK)
+	stats.Record("gemini-2.5-flash", metrics.OutcomeQuota)
+	stats.Record("gemini-2.5-pro", metrics.OutcomeParseError)
+
+	now = now.Add(5 * time.Minute)
+	stats.Record("gemini-2.5-flash", metrics.OutcomeOK)
+
+	snapshot := stats.Snapshot()
+	require.Len(t, snapshot, 2)
+	assert.Equal(t, "gemini-2.5-flash", snapshot[0].Model)
+	assert.Equal(t, 3, snapshot[0].Calls)
+	assert.Equal(t, 1, snapshot[0].Errors)
+	assert.Equal(t, 1, snapshot[0].Quota)
+	assert.InDelta(t, 1.0/3, snapshot[0].ErrorRate(), 0.001)
+	assert.Equal(t, 1, snapshot[1].Errors)
+
+	// Eventos fora da janela são descartados
+	now = now.Add(6 * time.Minute)
+	snapshot = stats.Snapshot()
+	require.Len(t, snapshot, 1)
+	assert.Equal(t, "gemini-2.5-flash", snapshot[0].Model)
+	assert.Equal(t, 1, snapshot[0].Calls)
+	assert.Zero(t, snapshot[0].Errors)
+}
+
+func TestGeminiService_RecordsModelStats(t *testing.T) {
+	service, gemini := newFakeService(t)
+	gemini.Enqueue(fakegemini.ServerError())
+
+	_, err := service.GenerateTopics(context.Background(), "Go", 3, "pt-BR")
+	require.NoError(t, err)
+
+	snapshot := service.ModelStats()
+	require.Len(t, snapshot, 2)
+	assert.Equal(t, ModelStat{Model: "gemini-2.5-flash", Calls: 1, Errors: 1, LastError: snapshot[0].LastError}, snapshot[0])
+	assert.Equal(t, 1, snapshot[1].Calls)
+	assert.Zero(t, snapshot[1].Errors)
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/metrics"
+	"github.com/spellbook/spellbook/internal/prompts"
+)
+
+// Pipeline descreve uma geração de forma declarativa: P são os parâmetros da requisição e T
+// o JSON esperado do modelo. As etapas comuns (agrupamento de requisições idênticas, ordem de
+// fallback, circuit breakers, novas tentativas por quota, parse, métricas, logs e tracing)
+// ficam em Run; cada geração informa só o que é específico dela.
+type Pipeline[P, T any] struct {
+	// Operation nomeia a geração nas métricas, logs e traces e também é o template de prompt
+	Operation string
+	// Description aparece nas mensagens de erro (ex: "roadmap educacional")
+	Description string
+	// Hedge ativa as consultas em paralelo entre modelos (ver HedgeSettings)
+	Hedge bool
+
+	// Check valida os parâmetros antes de qualquer chamada ao Gemini (opcional)
+	Check func(params P) error
+	// Key retorna os parâmetros que identificam requisições idênticas
+	Key func(params P) []any
+	// Prompt monta os dados do template de prompt
+	Prompt func(params P) map[string]interface{}
+	// Render monta o prompt sem os templates do registry, no lugar de Prompt (opcional)
+	Render func(params P, language string) (prompts.Prompt, error)
+	// Normalize ajusta a resposta de cada modelo antes da validação, para que ela confira o
+	// que será devolvido (opcional)
+	Normalize func(ctx context.Context, params P, output *T)
+	// Validate rejeita a resposta de um modelo, passando ao próximo (opcional)
+	Validate func(ctx context.Context, params P, output *T) error
+	// Feedback inclui no prompt os problemas que rejeitaram a resposta anterior na validação
+	// (template validation_feedback) e tenta de novo o mesmo modelo antes do próximo
+	// (ver feedbackRetries)
+	Feedback bool
+	// PostProcess ajusta a resposta aceita antes de devolvê-la (opcional)
+	PostProcess func(ctx context.Context, params P, output *T, prompt prompts.Prompt)
+	// Links aponta as URLs de recursos da resposta aceita, verificadas conforme
+	// LINK_CHECK_MODE (opcional)
+	Links func(output *T) []Link
+}
+
+// Run executa a geração com o serviço informado
+func (p *Pipeline[P, T]) Run(ctx context.Context, s *GeminiService, params P, language string) (*T, error) {
+	if p.Check != nil {
+		if err := p.Check(params); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.checkConfigured(); err != nil {
+		return nil, err
+	}
+
+	// Requisições idênticas simultâneas compartilham a mesma geração
+	return coalesce(ctx, &s.inflight, p.Operation, coalesceKey(language, p.Key(params)...), func(ctx context.Context) (*T, error) {
+		return p.generate(ctx, s, params, language)
+	})
+}
+
+func (p *Pipeline[P, T]) generate(ctx context.Context, s *GeminiService, params P, language string) (*T, error) {
+	defer metrics.TrackInFlight(p.Operation)()
+
+	// Modelos em ordem de tentativa (fallback)
+	modelsToTry := s.modelsToTry(ctx)
+
+	settings := s.current()
+	var prompt prompts.Prompt
+	var err error
+	if p.Render != nil {
+		prompt, err = p.Render(params, language)
+	} else {
+		prompt, err = settings.Prompts.Render(language, p.Operation, p.Prompt(params))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Sem hedging, firstValid tenta um modelo por vez
+	var hedge HedgeSettings
+	if p.Hedge {
+		hedge = *settings.Hedge
+	}
+
+	feedback := &validationFeedback{registry: settings.Prompts}
+	output, lastError := firstValid(ctx, s, p.Operation, modelsToTry, hedge, func(ctx context.Context, modelName string) (*T, error) {
+		return p.try(ctx, s, modelName, params, prompt, feedback)
+	})
+	if output != nil {
+		if p.Links != nil {
+			s.verifyLinks(ctx, p.Operation, p.Links(output))
+		}
+		return output, nil
+	}
+
+	if lastError != nil {
+		return nil, fmt.Errorf("erro ao gerar %s: %w", p.Description, lastError)
+	}
+
+	return nil, apperror.New(apperror.KindUpstreamUnavailable, apperror.CodeUpstreamUnavailable,
+		fmt.Sprintf("erro ao gerar %s: nenhum modelo disponível funcionou", p.Description))
+}
+
+// feedbackRetries é quantas vezes o mesmo modelo é tentado de novo, com o feedback da
+// validação, antes de passar ao próximo
+const feedbackRetries = 1
+
+// try tenta a geração em um modelo. Com Feedback, uma resposta rejeitada na validação é pedida
+// de novo ao mesmo modelo com os problemas no prompt, até feedbackRetries vezes. O circuit
+// breaker liberou uma tentativa só (ver firstValid), então só o resultado final é registrado.
+func (p *Pipeline[P, T]) try(ctx context.Context, s *GeminiService, modelName string, params P, prompt prompts.Prompt, feedback *validationFeedback) (*T, error) {
+	for retry := 0; ; retry++ {
+		retryRejected := p.Feedback && retry < feedbackRetries
+		output, rejected, err := p.tryOnce(ctx, s, modelName, params, prompt, feedback, retryRejected)
+		if !rejected || !retryRejected {
+			return output, err
+		}
+	}
+}
+
+// tryOnce faz uma tentativa completa em um modelo: chamada, parse, normalização, validação e
+// pós-processamento. rejected indica que a resposta foi rejeitada na validação; com
+// retryRejected, essa rejeição não vai para o circuit breaker, que recebe o resultado da
+// nova tentativa.
+func (p *Pipeline[P, T]) tryOnce(ctx context.Context, s *GeminiService, modelName string, params P, prompt prompts.Prompt, feedback *validationFeedback, retryRejected bool) (output *T, rejected bool, err error) {
+	request := prompt
+	if p.Feedback {
+		request = feedback.apply(ctx, prompt)
+	}
+
+	attempt := s.startAttempt(ctx, p.Operation, modelName, request)
+	text, err := s.generateWithRetry(attempt.ctx, modelName, request.Text)
+	if err != nil {
+		attempt.failed(err)
+		return nil, false, err
+	}
+
+	output = new(T)
+	if err := attempt.parse(text, output); err != nil {
+		attempt.finish(metrics.OutcomeParseError, err)
+		return nil, false, err
+	}
+
+	if p.Normalize != nil {
+		p.Normalize(attempt.ctx, params, output)
+	}
+
+	if p.Validate != nil {
+		if err := attempt.validate(func() error {
+			err := p.Validate(attempt.ctx, params, output)
+			if err != nil && p.Feedback {
+				feedback.reject(err)
+			}
+			return err
+		}); err != nil {
+			if retryRejected {
+				attempt.breakers = nil
+			}
+			attempt.finish(metrics.OutcomeValidationRejected, err)
+			return nil, true, err
+		}
+	}
+
+	if p.PostProcess != nil {
+		p.PostProcess(attempt.ctx, params, output, prompt)
+	}
+	attempt.finish(metrics.OutcomeOK, nil)
+	return output, false, nil
+}
+
+// validationFeedback guarda os problemas da última resposta rejeitada na validação, repassados
+// às próximas tentativas, no mesmo modelo ou no próximo. É compartilhado pelas tentativas de
+// uma geração, que podem rodar em paralelo com hedging.
+type validationFeedback struct {
+	registry *prompts.Registry
+
+	mu       sync.Mutex
+	rejected error
+}
+
+// localizedError é um erro de validação que sabe montar seus problemas em outro idioma
+type localizedError interface {
+	Localized(lang string) []string
+}
+
+// reject registra o erro de validação da última resposta rejeitada
+func (f *validationFeedback) reject(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rejected = err
+}
+
+// problems retorna os problemas da última rejeição no idioma pedido, um por linha. Erros que
+// não implementam localizedError seguem no idioma em que foram gerados (ver errors.Join).
+func (f *validationFeedback) problems(lang string) []string {
+	f.mu.Lock()
+	rejected := f.rejected
+	f.mu.Unlock()
+	if rejected == nil {
+		return nil
+	}
+
+	var localized localizedError
+	if errors.As(rejected, &localized) {
+		return localized.Localized(lang)
+	}
+	return strings.Split(rejected.Error(), "\n")
+}
+
+// apply acrescenta ao prompt os problemas da última resposta rejeitada, no idioma do prompt.
+// Sem rejeição anterior (ou sem o template) o prompt segue igual.
+func (f *validationFeedback) apply(ctx context.Context, prompt prompts.Prompt) prompts.Prompt {
+	problems := f.problems(prompt.Language)
+	if len(problems) == 0 {
+		return prompt
+	}
+
+	note, err := f.registry.Render(prompt.Language, "validation_feedback", map[string]interface{}{"Problems": problems})
+	if err != nil {
+		slog.WarnContext(ctx, "erro ao montar o feedback de validação", "error", err)
+		return prompt
+	}
+	prompt.Text += "\n\n" + note.Text
+	return prompt
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/fakegemini"
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/spellbook/spellbook/internal/prompts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countedTopics é uma geração declarada só para os testes, sobre o template de tópicos
+var countedTopics = &Pipeline[int, models.TopicsResponse]{
+	Operation:   "topics",
+	Description: "tópicos contados",
+	Check: func(count int) error {
+		if count <= 0 {
+			return apperror.New(apperror.KindValidation, apperror.CodeRequestInvalid, "quantidade inválida")
+		}
+		return nil
+	},
+	Key:    func(count int) []any { return []any{count} },
+	Prompt: func(count int) map[string]interface{} { return map[string]interface{}{"Subject": "Go", "Count": count} },
+	Validate: func(_ context.Context, count int, topics *models.TopicsResponse) error {
+		if len(topics.Topics) != count {
+			return fmt.Errorf("esperados %d tópicos, recebidos %d", count, len(topics.Topics))
+		}
+		return nil
+	},
+	PostProcess: func(_ context.Context, _ int, topics *models.TopicsResponse, prompt prompts.Prompt) {
+		topics.PromptVersion = prompt.Version
+		for i := range topics.Topics {
+			topics.Topics[i] = fmt.Sprintf("%d. %s", i+1, topics.Topics[i])
+		}
+	},
+}
+
+func TestPipeline_Run(t *testing.T) {
+	t.Run("resposta rejeitada passa ao próximo modelo", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		gemini.OnModel("gemini-2.5-flash", fakegemini.Topics("Go", 2))
+
+		topics, err := countedTopics.Run(context.Background(), service, 3, "pt-BR")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gemini-2.5-flash", "gemini-2.5-pro"}, gemini.CalledModels())
+		assert.Len(t, topics.Topics, 3)
+		assert.Equal(t, "1. Go: tópico 1", topics.Topics[0])
+		assert.NotEmpty(t, topics.PromptVersion)
+	})
+
+	t.Run("parâmetros inválidos não chamam o Gemini", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+
+		_, err := countedTopics.Run(context.Background(), service, 0, "pt-BR")
+
+		assert.ErrorIs(t, err, apperror.ErrValidation)
+		assert.Empty(t, gemini.Requests())
+	})
+
+	t.Run("todos os modelos rejeitados", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		service.FallbackModels = nil
+		gemini.Enqueue(fakegemini.Topics("Go", 1), fakegemini.Topics("Go", 1))
+
+		_, err := countedTopics.Run(context.Background(), service, 3, "pt-BR")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "erro ao gerar tópicos contados")
+		var appErr *apperror.Error
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, apperror.KindOutputInvalid, appErr.Kind)
+	})
+}
+
+func TestValidateRoadmap_UnexpectedFormat(t *testing.T) {
+	for name, roadmap := range map[string]*models.Roadmap{
+		"sem tópico":     {Roadmap: []models.RoadmapCategory{{Category: "Básico"}}},
+		"sem categorias": {Topic: "Go"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := validateRoadmap(context.Background(), roadmapParams{Topic: "Go"}, roadmap)
+
+			assert.ErrorIs(t, err, errUnexpectedFormat)
+		})
+	}
+}
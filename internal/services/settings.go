@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/catalog"
+	"github.com/spellbook/spellbook/internal/linkcheck"
+	"github.com/spellbook/spellbook/internal/prompts"
+)
+
+// DefaultFallbackModels são tentados depois dos modelos listados pela API
+var DefaultFallbackModels = []string{
+	"gemini-1.5-flash-latest",
+	"gemini-1.5-pro-latest",
+	"gemini-pro",
+	"gemini-1.5-flash",
+	"gemini-1.5-pro",
+}
+
+// DefaultRequestTimeout cobre as trilhas educacionais mais complexas
+const DefaultRequestTimeout = 180 * time.Second
+
+// Settings reúne as opções do serviço que podem ser trocadas com o servidor em execução
+// (recarga de configuração). Campos vazios mantêm o valor atual, exceto APIKeys e PreferredModels.
+type Settings struct {
+	// APIKeys são usadas em rodízio; chaves mantidas na troca conservam o estado (espera, revogação)
+	APIKeys []string
+	// PreferredModels são tentados antes dos modelos listados pela API, nesta ordem
+	PreferredModels []string
+	FallbackModels  []string
+	RequestTimeout  time.Duration
+	QuotaRetryDelay time.Duration
+	ModelsCacheTTL  time.Duration
+	Prompts         *prompts.Registry
+	// Breaker troca as configurações dos circuit breakers (nil mantém as atuais)
+	Breaker *BreakerSettings
+	// Hedge troca as configurações do hedging (nil mantém as atuais)
+	Hedge *HedgeSettings
+	// Catalog troca o catálogo de recursos (nil mantém o atual); CatalogMode, o modo de uso
+	Catalog     *catalog.Catalog
+	CatalogMode string
+	// Links troca as configurações da verificação de links (nil mantém as atuais)
+	Links *linkcheck.Settings
+}
+
+// Apply troca as opções do serviço. As gerações em andamento terminam com as opções antigas.
+// Trocar as API keys descarta o cache da lista de modelos, que depende da chave.
+func (s *GeminiService) Apply(settings Settings) {
+	keyChanged := s.Keys.SetKeys(settings.APIKeys)
+
+	s.settingsMu.Lock()
+	s.PreferredModels = append([]string(nil), settings.PreferredModels...)
+	if len(settings.FallbackModels) > 0 {
+		s.FallbackModels = append([]string(nil), settings.FallbackModels...)
+	}
+	if settings.RequestTimeout > 0 {
+		// Um cliente novo, para não alterar o timeout de um cliente em uso
+		s.HTTPClient = &http.Client{Timeout: settings.RequestTimeout, Transport: s.HTTPClient.Transport}
+	}
+	if settings.QuotaRetryDelay > 0 {
+		s.QuotaRetryDelay = settings.QuotaRetryDelay
+	}
+	if settings.ModelsCacheTTL > 0 {
+		s.ModelsCacheTTL = settings.ModelsCacheTTL
+	}
+	if settings.Prompts != nil {
+		s.Prompts = settings.Prompts
+	}
+	if settings.Hedge != nil {
+		s.Hedge = *settings.Hedge
+	}
+	if settings.Catalog != nil {
+		s.Catalog = settings.Catalog
+	}
+	if settings.CatalogMode != "" {
+		s.CatalogMode = settings.CatalogMode
+	}
+	s.settingsMu.Unlock()
+
+	if settings.Breaker != nil {
+		s.Breakers.SetSettings(*settings.Breaker)
+	}
+	if settings.Links != nil {
+		s.Links.SetSettings(*settings.Links)
+	}
+
+	if keyChanged {
+		s.modelsMu.Lock()
+		s.cachedModels = nil
+		s.modelsMu.Unlock()
+	}
+}
+
+// current retorna uma cópia das opções em uso
+func (s *GeminiService) current() Settings {
+	s.settingsMu.RLock()
+	defer s.settingsMu.RUnlock()
+
+	hedge := s.Hedge
+	return Settings{
+		PreferredModels: s.PreferredModels,
+		FallbackModels:  s.FallbackModels,
+		QuotaRetryDelay: s.QuotaRetryDelay,
+		ModelsCacheTTL:  s.ModelsCacheTTL,
+		Prompts:         s.Prompts,
+		Hedge:           &hedge,
+		Catalog:         s.Catalog,
+		CatalogMode:     s.CatalogMode,
+	}
+}
+
+// httpClient retorna o cliente HTTP em uso
+func (s *GeminiService) httpClient() *http.Client {
+	s.settingsMu.RLock()
+	defer s.settingsMu.RUnlock()
+	return s.HTTPClient
+}
+
+// modelsToTry monta a ordem de tentativa: modelos preferidos, modelos listados pela API
+// e modelos de fallback, sem repetições
+func (s *GeminiService) modelsToTry(ctx context.Context) []string {
+	settings := s.current()
+	availableModels, _ := s.listAvailableModels(ctx)
+
+	modelsToTry := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, group := range [][]string{settings.PreferredModels, availableModels, settings.FallbackModels} {
+		for _, model := range group {
+			if !seen[model] {
+				modelsToTry = append(modelsToTry, model)
+				seen[model] = true
+			}
+		}
+	}
+	return modelsToTry
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/spellbook/spellbook/internal/prompts"
+	"github.com/spellbook/spellbook/internal/spells"
+)
+
+// GenerateSpell executa um spell (geração definida em arquivo) com o corpo da requisição já
+// validado contra o schema do spell. A resposta do modelo precisa seguir o schema de resposta;
+// caso contrário, o próximo modelo é tentado.
+func (s *GeminiService) GenerateSpell(ctx context.Context, spell *spells.Spell, input map[string]interface{}, language string) (map[string]interface{}, error) {
+	pipeline := &Pipeline[map[string]interface{}, map[string]interface{}]{
+		Operation:   "spell_" + spell.Name,
+		Description: "spell " + spell.Name,
+		Key: func(input map[string]interface{}) []any {
+			// encoding/json ordena as chaves, então a mesma requisição gera sempre o mesmo texto
+			canonical, _ := json.Marshal(input)
+			return []any{string(canonical)}
+		},
+		Render: func(input map[string]interface{}, language string) (prompts.Prompt, error) {
+			return spell.RenderPrompt(input, language)
+		},
+		Validate: func(_ context.Context, _ map[string]interface{}, output *map[string]interface{}) error {
+			return spell.ValidateResponse(*output)
+		},
+	}
+	output, err := pipeline.Run(ctx, s, input, language)
+	if err != nil {
+		return nil, err
+	}
+	return *output, nil
+}
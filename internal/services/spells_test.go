@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spellbook/spellbook/internal/fakegemini"
+	"github.com/spellbook/spellbook/internal/spells"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeminiService_GenerateSpell(t *testing.T) {
+	spell, err := spells.Parse("glossary.yaml", []byte(`
+request:
+  type: object
+  properties:
+    topic: {type: string}
+prompt: Glossário de {{.topic}} ({{.Language}})
+response:
+  type: object
+  required: [terms]
+  properties:
+    terms: {type: array, minItems: 1}
+`))
+	require.NoError(t, err)
+
+	service, gemini := newFakeService(t)
+	gemini.OnModel("gemini-2.5-flash", fakegemini.JSON(map[string]interface{}{"terms": []string{}}))
+	gemini.OnModel("gemini-2.5-pro", fakegemini.JSON(map[string]interface{}{"terms": []string{"goroutine"}}))
+
+	output, err := service.GenerateSpell(context.Background(), spell, map[string]interface{}{"topic": "Go"}, "en")
+
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"goroutine"}, output["terms"])
+
+	// A resposta fora do schema foi rejeitada e o próximo modelo tentado
+	assert.Equal(t, []string{"gemini-2.5-flash", "gemini-2.5-pro"}, gemini.CalledModels())
+	assert.Equal(t, "Glossário de Go (en)", gemini.Requests()[0].Prompt)
+}
@@ -0,0 +1,309 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/spellbook/spellbook/internal/catalog"
+	"github.com/spellbook/spellbook/internal/metrics"
+	"github.com/spellbook/spellbook/internal/models"
+)
+
+// titleSimilarity é a fração mínima de palavras em comum para dois títulos serem o mesmo recurso
+const titleSimilarity = 0.75
+
+// maxSlugLength limita o tamanho dos IDs gerados a partir dos títulos
+const maxSlugLength = 48
+
+// trailFixes resume as correções feitas em uma trilha
+type trailFixes struct {
+	Merged   int
+	Repaired int
+	Dropped  int
+}
+
+// normalizeTrail corrige os recursos da trilha gerada: junta os recursos repetidos (mesma URL
+// ou títulos e autores parecidos), troca os IDs escolhidos pelo modelo por IDs estáveis gerados
+// a partir do título (ex: "the-go-programming-language") e confere cada Activity.ResourceID.
+// Referências a recursos inexistentes são reparadas quando o ID, o título ou a URL identificam
+// o recurso; as demais são removidas (a atividade é mantida, sem resource_id).
+func normalizeTrail(ctx context.Context, trail *models.EducationalTrail) trailFixes {
+	var fixes trailFixes
+
+	ids := trailResourceOrder(trail)
+	groups := groupDuplicates(trail.Resources, ids)
+	fixes.Merged = len(ids) - len(groups)
+
+	// Novo ID de cada ID antigo e os recursos já mesclados
+	renamed := make(map[string]string, len(ids))
+	resources := make(map[string]models.EducationalResource, len(groups))
+	for _, group := range groups {
+		merged := mergeResources(trail.Resources, group)
+		id := uniqueSlug(resources, merged.Title)
+		resources[id] = merged
+		for _, old := range group {
+			renamed[old] = id
+		}
+	}
+
+	for i := range trail.Steps {
+		for j := range trail.Steps[i].Activities {
+			activity := &trail.Steps[i].Activities[j]
+			if activity.ResourceID == "" {
+				continue
+			}
+			if id, ok := renamed[activity.ResourceID]; ok {
+				activity.ResourceID = id
+				continue
+			}
+			if id, ok := repairReference(resources, renamed, *activity); ok {
+				activity.ResourceID = id
+				fixes.Repaired++
+				continue
+			}
+			activity.ResourceID = ""
+			fixes.Dropped++
+		}
+	}
+	trail.Resources = resources
+
+	for fix, count := range map[string]int{"merged": fixes.Merged, "repaired": fixes.Repaired, "dropped": fixes.Dropped} {
+		if count > 0 {
+			metrics.TrailResourceFixesTotal.WithLabelValues(fix).Add(float64(count))
+		}
+	}
+	if fixes != (trailFixes{}) {
+		slog.InfoContext(ctx, "recursos da trilha corrigidos",
+			"merged", fixes.Merged, "repaired", fixes.Repaired, "dropped", fixes.Dropped)
+	}
+	return fixes
+}
+
+// trailResourceOrder retorna os IDs dos recursos na ordem em que as atividades os citam; os
+// não citados vêm depois, em ordem alfabética. A ordem decide o sufixo dos IDs repetidos.
+func trailResourceOrder(trail *models.EducationalTrail) []string {
+	seen := make(map[string]bool, len(trail.Resources))
+	var ids []string
+	for _, step := range trail.Steps {
+		for _, activity := range step.Activities {
+			if _, ok := trail.Resources[activity.ResourceID]; ok && !seen[activity.ResourceID] {
+				seen[activity.ResourceID] = true
+				ids = append(ids, activity.ResourceID)
+			}
+		}
+	}
+
+	var rest []string
+	for id := range trail.Resources {
+		if !seen[id] {
+			rest = append(rest, id)
+		}
+	}
+	sort.Strings(rest)
+	return append(ids, rest...)
+}
+
+// groupDuplicates agrupa os IDs dos recursos que são o mesmo recurso, mantendo a ordem
+func groupDuplicates(resources map[string]models.EducationalResource, ids []string) [][]string {
+	var groups [][]string
+	for _, id := range ids {
+		found := false
+		for g, group := range groups {
+			if sameResource(resources[group[0]], resources[id]) {
+				groups[g] = append(group, id)
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, []string{id})
+		}
+	}
+	return groups
+}
+
+// sameResource compara pela URL ou pelo título (exato ou parecido) com autores compatíveis
+func sameResource(a, b models.EducationalResource) bool {
+	if urlA, urlB := resourceURLKey(a.URL), resourceURLKey(b.URL); urlA != "" && urlA == urlB {
+		return true
+	}
+
+	titleA, titleB := catalog.TitleKey(a.Title), catalog.TitleKey(b.Title)
+	if titleA == "" || titleB == "" || !compatibleAuthors(a.Author, b.Author) {
+		return false
+	}
+	return titleA == titleB || wordSimilarity(titleA, titleB) >= titleSimilarity
+}
+
+// resourceURLKey normaliza a URL para comparação (sem esquema, "www.", fragmento nem barra
+// final). URLs sem caminho, como a página inicial de um site, não identificam um recurso.
+func resourceURLKey(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	path := strings.TrimSuffix(u.EscapedPath(), "/")
+	if path == "" && u.RawQuery == "" {
+		return ""
+	}
+	key := strings.TrimPrefix(strings.ToLower(u.Host), "www.") + path
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+	return key
+}
+
+// compatibleAuthors aceita autores ausentes ou com algum nome em comum (ex: "Robert C. Martin"
+// e "Uncle Bob Martin")
+func compatibleAuthors(a, b string) bool {
+	wordsA, wordsB := strings.Fields(catalog.TitleKey(a)), strings.Fields(catalog.TitleKey(b))
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return true
+	}
+	for _, wordA := range wordsA {
+		for _, wordB := range wordsB {
+			if len(wordA) > 2 && wordA == wordB {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// wordSimilarity é a fração de palavras em comum entre dois textos normalizados (Jaccard)
+func wordSimilarity(a, b string) float64 {
+	setA := make(map[string]bool)
+	for _, word := range strings.Fields(a) {
+		setA[word] = true
+	}
+	setB := make(map[string]bool)
+	for _, word := range strings.Fields(b) {
+		setB[word] = true
+	}
+
+	common := 0
+	for word := range setA {
+		if setB[word] {
+			common++
+		}
+	}
+	total := len(setA) + len(setB) - common
+	if total == 0 {
+		return 0
+	}
+	return float64(common) / float64(total)
+}
+
+// mergeResources junta os recursos repetidos no primeiro, completando os campos vazios com os
+// dos demais
+func mergeResources(resources map[string]models.EducationalResource, ids []string) models.EducationalResource {
+	merged := resources[ids[0]]
+	for _, id := range ids[1:] {
+		other := resources[id]
+		for _, field := range []struct{ target, value *string }{
+			{&merged.Description, &other.Description},
+			{&merged.URL, &other.URL},
+			{&merged.Duration, &other.Duration},
+			{&merged.Author, &other.Author},
+			{&merged.Source, &other.Source},
+		} {
+			if *field.target == "" {
+				*field.target = *field.value
+			}
+		}
+		merged.Chapters = appendMissing(merged.Chapters, other.Chapters)
+	}
+	return merged
+}
+
+func appendMissing(list, items []string) []string {
+	for _, item := range items {
+		found := false
+		for _, existing := range list {
+			if strings.EqualFold(existing, item) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// uniqueSlug gera o ID a partir do título, com sufixo numérico quando o ID já existe
+func uniqueSlug(resources map[string]models.EducationalResource, title string) string {
+	base := slug(title)
+	if base == "" {
+		base = "recurso"
+	}
+	id := base
+	for n := 2; ; n++ {
+		if _, ok := resources[id]; !ok {
+			return id
+		}
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+// slug converte o título em um ID estável: minúsculas sem acento, palavras separadas por "-",
+// sem o subtítulo, que varia entre as gerações (vazio quando o título não tem letras nem números)
+func slug(title string) string {
+	var parts []string
+	length := 0
+	for _, word := range strings.Fields(catalog.TitleKey(title)) {
+		word = strings.Map(func(r rune) rune {
+			if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return -1
+		}, word)
+		if word == "" {
+			continue
+		}
+		if length > 0 && length+1+len(word) > maxSlugLength {
+			break
+		}
+		parts = append(parts, word)
+		length += len(word) + 1
+	}
+	return strings.Join(parts, "-")
+}
+
+// repairReference tenta identificar o recurso de uma referência inexistente: por um ID antigo
+// escrito de outra forma (ex: "Recurso_1"), pelo título usado como ID ou pela URL da atividade
+func repairReference(resources map[string]models.EducationalResource, renamed map[string]string, activity models.Activity) (string, bool) {
+	if reference := slug(activity.ResourceID); reference != "" {
+		for _, old := range sortedKeys(renamed) {
+			if slug(old) == reference {
+				return renamed[old], true
+			}
+		}
+		if _, ok := resources[reference]; ok {
+			return reference, true
+		}
+	}
+
+	if key := resourceURLKey(activity.URL); key != "" {
+		for _, id := range sortedKeys(resources) {
+			if resourceURLKey(resources[id].URL) == key {
+				return id, true
+			}
+		}
+	}
+	return "", false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTrail(t *testing.T) {
+	trail := &models.EducationalTrail{
+		Topic: "Boas práticas",
+		Resources: map[string]models.EducationalResource{
+			"recurso_1": {Title: "Clean Code", Author: "Robert C. Martin", Chapters: []string{"Nomes"}},
+			"recurso_2": {Title: "Clean Code: A Handbook of Agile Software Craftsmanship", Author: "Robert Martin", URL: "https://example.com/clean-code", Chapters: []string{"nomes", "Funções"}},
+			"recurso_3": {Title: "Código Limpo na prática", URL: "https://www.example.com/clean-code/"},
+			"recurso_4": {Title: "Refactoring", Author: "Martin Fowler"},
+			"recurso_5": {Title: "Refactoring", Author: "William Opdyke"},
+			"recurso_6": {Title: "Nomes significativos (vídeo)", URL: "https://videos.example.com/nomes"},
+		},
+		Steps: []models.EducationalTrailStep{
+			{Day: 1, Activities: []models.Activity{
+				{Type: "read_chapters", ResourceID: "recurso_2"},
+				{Type: "read_chapters", ResourceID: "recurso_1"},
+				{Type: "read_book", ResourceID: "recurso_3"},
+			}},
+			{Day: 2, Activities: []models.Activity{
+				{Type: "read_book", ResourceID: "recurso_4"},
+				{Type: "read_book", ResourceID: "Recurso 5"},
+				{Type: "read_book", ResourceID: "Clean Code"},
+				{Type: "watch_video", ResourceID: "video_1", URL: "http://videos.example.com/nomes"},
+				{Type: "read_article", ResourceID: "artigo_inexistente"},
+				{Type: "do_project", ResourceID: ""},
+			}},
+		},
+	}
+
+	fixes := normalizeTrail(context.Background(), trail)
+
+	assert.Equal(t, trailFixes{Merged: 2, Repaired: 3, Dropped: 1}, fixes)
+
+	// Mesmo livro (título parecido e autor compatível) e mesma URL viram um recurso só,
+	// completado com os dados das cópias
+	require.Len(t, trail.Resources, 4)
+	assert.Equal(t, models.EducationalResource{
+		Title:    "Clean Code: A Handbook of Agile Software Craftsmanship",
+		Author:   "Robert Martin",
+		URL:      "https://example.com/clean-code",
+		Chapters: []string{"nomes", "Funções"},
+	}, trail.Resources["clean-code"])
+
+	// Títulos iguais de autores diferentes são recursos diferentes
+	assert.Equal(t, "Martin Fowler", trail.Resources["refactoring"].Author)
+	assert.Equal(t, "William Opdyke", trail.Resources["refactoring-2"].Author)
+	assert.Contains(t, trail.Resources, "nomes-significativos-video")
+
+	var references []string
+	for _, step := range trail.Steps {
+		for _, activity := range step.Activities {
+			references = append(references, activity.ResourceID)
+		}
+	}
+	assert.Equal(t, []string{
+		"clean-code", "clean-code", "clean-code",
+		"refactoring", "refactoring-2", "clean-code", "nomes-significativos-video", "", "",
+	}, references)
+}
+
+func TestSlug(t *testing.T) {
+	tests := map[string]string{
+		"The Go Programming Language":   "the-go-programming-language",
+		"Programação Concorrente em Go": "programacao-concorrente-em-go",
+		"Clean Code: A Handbook":        "clean-code",
+		"C# 10 in a Nutshell":           "c-10-in-a-nutshell",
+		"???":                           "",
+		"Designing Data-Intensive Applications: The Big Ideas Behind Reliable Systems": "designing-data-intensive-applications",
+		"Structure and Interpretation of Computer Programs Second Edition Revisited":   "structure-and-interpretation-of-computer",
+	}
+	for title, expected := range tests {
+		assert.Equal(t, expected, slug(title), title)
+	}
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spellbook/spellbook/internal/catalog"
+	"github.com/spellbook/spellbook/internal/i18n"
+	"github.com/spellbook/spellbook/internal/models"
+)
+
+// defaultTrailDays é a duração da trilha quando available_days não é informado
+const defaultTrailDays = 12
+
+// maxTrailProblems limita os problemas listados na rejeição (e no feedback ao próximo modelo)
+const maxTrailProblems = 10
+
+// trailActivityTypes são os valores aceitos em Activity.Type
+var trailActivityTypes = []string{"read_book", "read_chapters", "watch_video", "read_article", "take_course", "do_project"}
+
+// trailPlan é o formato prometido no prompt da trilha e cobrado na validação da resposta
+type trailPlan struct {
+	TotalDays     int
+	MinActivities int
+	MaxActivities int
+	// Pace é o ritmo usado no template: none, short, medium ou long
+	Pace string
+}
+
+// newTrailPlan determina dias totais e atividades por dia a partir de available_days
+func newTrailPlan(availableDays *int) trailPlan {
+	if availableDays == nil || *availableDays <= 0 {
+		return trailPlan{TotalDays: defaultTrailDays, MinActivities: 2, MaxActivities: 3, Pace: "none"}
+	}
+
+	days := *availableDays
+	switch {
+	case days < 7:
+		// Tempo curto: focar em essencial, menos atividades
+		return trailPlan{TotalDays: days, MinActivities: 1, MaxActivities: 2, Pace: "short"}
+	case days <= 14:
+		// Tempo médio: estrutura balanceada
+		return trailPlan{TotalDays: days, MinActivities: 2, MaxActivities: 3, Pace: "medium"}
+	default:
+		// Tempo longo: conteúdo mais aprofundado
+		return trailPlan{TotalDays: days, MinActivities: 3, MaxActivities: 4, Pace: "long"}
+	}
+}
+
+// ActivitiesPerDay é a faixa de atividades por dia no formato do prompt (ex: "2-3")
+func (p trailPlan) ActivitiesPerDay() string {
+	return fmt.Sprintf("%d-%d", p.MinActivities, p.MaxActivities)
+}
+
+// ProjectsFromDay é o primeiro dia em que a trilha aceita projetos: o último terço dos dias
+func (p trailPlan) ProjectsFromDay() int {
+	return p.TotalDays - (p.TotalDays+2)/3 + 1
+}
+
+// validateTrail confere a estrutura da trilha contra o plano: total_days e dias 1..N sem
+// lacunas, número de atividades por dia, tipos de atividade, projetos só no final e capítulos
+// citados presentes no recurso. Roda depois do catálogo e de normalizeTrail (ver Normalize),
+// então os capítulos conferidos são os do recurso devolvido e referências a recursos
+// inexistentes já foram reparadas ou removidas. Retorna todos os problemas encontrados como
+// trailProblems, para o próximo modelo corrigir.
+func validateTrail(trail *models.EducationalTrail, plan trailPlan) error {
+	var problems trailProblems
+	add := func(key string, args ...any) {
+		problems = append(problems, trailProblem{key: key, args: args})
+	}
+
+	if trail.TotalDays != plan.TotalDays {
+		add(i18n.TrailTotalDays, trail.TotalDays, plan.TotalDays)
+	}
+	if len(trail.Steps) != plan.TotalDays {
+		add(i18n.TrailStepCount, len(trail.Steps), plan.TotalDays)
+	}
+
+	projectsFrom := plan.ProjectsFromDay()
+	for i, step := range trail.Steps {
+		if step.Day != i+1 {
+			add(i18n.TrailDayOrder, i, step.Day, i+1, plan.TotalDays)
+		}
+		if n := len(step.Activities); n < plan.MinActivities || n > plan.MaxActivities {
+			add(i18n.TrailActivityCount, step.Day, n, plan.MinActivities, plan.MaxActivities)
+		}
+
+		for _, activity := range step.Activities {
+			if !contains(trailActivityTypes, activity.Type) {
+				add(i18n.TrailActivityType, step.Day, activity.Type, strings.Join(trailActivityTypes, ", "))
+			}
+			if activity.Type == "do_project" && i+1 < projectsFrom {
+				add(i18n.TrailProjectTooEarly, step.Day, projectsFrom)
+			}
+			if chapter, resource, ok := missingChapter(trail.Resources, activity); !ok {
+				add(i18n.TrailChapterMissing, step.Day, chapter, resource.Title, strings.Join(resource.Chapters, "; "))
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	if len(problems) > maxTrailProblems {
+		problems = append(problems[:maxTrailProblems], trailProblem{key: i18n.TrailMoreProblems, args: []any{len(problems) - maxTrailProblems}})
+	}
+	return problems
+}
+
+// trailProblem é um problema da validação: a chave da mensagem em i18n e seus argumentos
+type trailProblem struct {
+	key  string
+	args []any
+}
+
+// trailProblems é o erro de validação da trilha. Error usa o idioma padrão (logs e resposta
+// de erro); Localized monta as linhas no idioma do prompt para o feedback ao modelo.
+type trailProblems []trailProblem
+
+func (p trailProblems) Error() string {
+	return strings.Join(p.Localized(i18n.DefaultLanguage), "\n")
+}
+
+// Localized retorna os problemas no idioma pedido, um por linha
+func (p trailProblems) Localized(lang string) []string {
+	lines := make([]string, len(p))
+	for i, problem := range p {
+		lines[i] = fmt.Sprintf(i18n.Message(lang, problem.key), problem.args...)
+	}
+	return lines
+}
+
+// missingChapter retorna o primeiro capítulo da atividade ausente da lista do recurso, ignorando
+// maiúsculas, acentos e subtítulo, junto com o recurso. Recursos inexistentes ou sem lista de
+// capítulos não são conferidos.
+func missingChapter(resources map[string]models.EducationalResource, activity models.Activity) (string, models.EducationalResource, bool) {
+	resource, ok := resources[activity.ResourceID]
+	if !ok || len(resource.Chapters) == 0 {
+		return "", resource, true
+	}
+
+	known := make(map[string]bool, len(resource.Chapters))
+	for _, chapter := range resource.Chapters {
+		known[catalog.TitleKey(chapter)] = true
+	}
+	for _, chapter := range activity.Chapters {
+		if !known[catalog.TitleKey(chapter)] {
+			return chapter, resource, false
+		}
+	}
+	return "", resource, true
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
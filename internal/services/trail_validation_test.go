@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/catalog"
+	"github.com/spellbook/spellbook/internal/fakegemini"
+	"github.com/spellbook/spellbook/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validTrail monta uma trilha de 6 dias no formato do plano de 6 dias (1-2 atividades por dia,
+// projetos a partir do dia 5)
+func validTrail() *models.EducationalTrail {
+	trail := &models.EducationalTrail{
+		Topic:     "Go",
+		TotalDays: 6,
+		Resources: map[string]models.EducationalResource{
+			"livro":   {Title: "The Go Programming Language", Chapters: []string{"Tutorial", "Program Structure", "Concurrency"}},
+			"projeto": {Title: "CLI em Go"},
+		},
+	}
+	for day := 1; day <= 6; day++ {
+		trail.Steps = append(trail.Steps, models.EducationalTrailStep{Day: day, Activities: []models.Activity{
+			{Type: "read_chapters", ResourceID: "livro", Chapters: []string{"tutorial"}},
+		}})
+	}
+	trail.Steps[5].Activities = append(trail.Steps[5].Activities, models.Activity{Type: "do_project", ResourceID: "projeto"})
+	return trail
+}
+
+func TestValidateTrail(t *testing.T) {
+	days := 6
+	plan := newTrailPlan(&days)
+
+	tests := []struct {
+		name     string
+		change   func(trail *models.EducationalTrail)
+		problems []string
+	}{
+		{
+			name:   "trilha no formato do plano",
+			change: func(*models.EducationalTrail) {},
+		},
+		{
+			name:     "total_days diferente do pedido",
+			change:   func(trail *models.EducationalTrail) { trail.TotalDays = 7 },
+			problems: []string{"total_days é 7, esperado 6"},
+		},
+		{
+			name:     "dias faltando",
+			change:   func(trail *models.EducationalTrail) { trail.Steps = trail.Steps[:5] },
+			problems: []string{"a trilha tem 5 dias em steps, esperado 6"},
+		},
+		{
+			name:     "dias fora de ordem",
+			change:   func(trail *models.EducationalTrail) { trail.Steps[1].Day, trail.Steps[2].Day = 3, 2 },
+			problems: []string{"steps[1] é o dia 3, esperado 2", "steps[2] é o dia 2, esperado 3"},
+		},
+		{
+			name: "atividades fora da faixa",
+			change: func(trail *models.EducationalTrail) {
+				trail.Steps[0].Activities = nil
+				trail.Steps[1].Activities = append(trail.Steps[1].Activities, trail.Steps[1].Activities[0], trail.Steps[1].Activities[0])
+			},
+			problems: []string{"dia 1 tem 0 atividades, esperado de 1 a 2", "dia 2 tem 3 atividades, esperado de 1 a 2"},
+		},
+		{
+			name:     "tipo desconhecido",
+			change:   func(trail *models.EducationalTrail) { trail.Steps[0].Activities[0].Type = "listen_podcast" },
+			problems: []string{`dia 1: tipo de atividade "listen_podcast" inválido`},
+		},
+		{
+			name: "projeto no início",
+			change: func(trail *models.EducationalTrail) {
+				trail.Steps[3].Activities = append(trail.Steps[3].Activities, models.Activity{Type: "do_project", ResourceID: "projeto"})
+			},
+			problems: []string{"dia 4: projeto antes do dia 5"},
+		},
+		{
+			name: "capítulo que o recurso não tem",
+			change: func(trail *models.EducationalTrail) {
+				trail.Steps[2].Activities[0].Chapters = []string{"Tutorial", "Generics"}
+			},
+			problems: []string{`dia 3: capítulo "Generics" não existe em "The Go Programming Language" (capítulos: Tutorial; Program Structure; Concurrency)`},
+		},
+		{
+			name: "recursos sem capítulos ou inexistentes não são conferidos",
+			change: func(trail *models.EducationalTrail) {
+				trail.Steps[0].Activities[0] = models.Activity{Type: "read_chapters", ResourceID: "projeto", Chapters: []string{"Cap 1"}}
+				trail.Steps[1].Activities[0] = models.Activity{Type: "read_chapters", ResourceID: "inexistente", Chapters: []string{"Cap 1"}}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trail := validTrail()
+			tt.change(trail)
+
+			err := validateTrail(trail, plan)
+
+			if len(tt.problems) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, problem := range tt.problems {
+				assert.Contains(t, err.Error(), problem)
+			}
+		})
+	}
+}
+
+func TestValidateTrail_LimitsProblems(t *testing.T) {
+	trail := validTrail()
+	for i := range trail.Steps {
+		trail.Steps[i].Day = 0
+		trail.Steps[i].Activities = nil
+	}
+	days := 6
+
+	err := validateTrail(trail, newTrailPlan(&days))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "e mais 2 problemas")
+	var problems trailProblems
+	require.ErrorAs(t, err, &problems)
+	assert.Equal(t, "y 2 problemas más", problems.Localized("es")[maxTrailProblems])
+}
+
+func TestNewTrailPlan(t *testing.T) {
+	days := func(n int) *int { return &n }
+
+	tests := []struct {
+		availableDays *int
+		expected      trailPlan
+		activities    string
+		projectsFrom  int
+	}{
+		{nil, trailPlan{TotalDays: 12, MinActivities: 2, MaxActivities: 3, Pace: "none"}, "2-3", 9},
+		{days(1), trailPlan{TotalDays: 1, MinActivities: 1, MaxActivities: 2, Pace: "short"}, "1-2", 1},
+		{days(5), trailPlan{TotalDays: 5, MinActivities: 1, MaxActivities: 2, Pace: "short"}, "1-2", 4},
+		{days(14), trailPlan{TotalDays: 14, MinActivities: 2, MaxActivities: 3, Pace: "medium"}, "2-3", 10},
+		{days(30), trailPlan{TotalDays: 30, MinActivities: 3, MaxActivities: 4, Pace: "long"}, "3-4", 21},
+	}
+	for _, tt := range tests {
+		plan := newTrailPlan(tt.availableDays)
+		assert.Equal(t, tt.expected, plan)
+		assert.Equal(t, tt.activities, plan.ActivitiesPerDay())
+		assert.Equal(t, tt.projectsFrom, plan.ProjectsFromDay())
+	}
+}
+
+func TestGeminiService_GenerateEducationalTrail_ValidationFeedback(t *testing.T) {
+	invalid := validTrail()
+	invalid.TotalDays = 3
+	invalid.Steps[0].Activities[0].Type = "listen_podcast"
+
+	t.Run("mesmo modelo tenta de novo com os problemas no prompt", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		gemini.Enqueue(fakegemini.JSON(invalid), fakegemini.JSON(validTrail()))
+		days := 6
+
+		trail, err := service.GenerateEducationalTrail(context.Background(), "Go", &days, "pt-BR")
+
+		require.NoError(t, err)
+		assert.Len(t, trail.Steps, 6)
+		assert.Equal(t, []string{"gemini-2.5-flash", "gemini-2.5-flash"}, gemini.CalledModels())
+		requests := gemini.Requests()
+		require.Len(t, requests, 2)
+		assert.NotContains(t, requests[0].Prompt, "foi rejeitada")
+		assert.Contains(t, requests[1].Prompt, "uma resposta anterior para este pedido foi rejeitada")
+		assert.Contains(t, requests[1].Prompt, "- total_days é 3, esperado 6\n")
+		assert.Contains(t, requests[1].Prompt, `- dia 1: tipo de atividade "listen_podcast" inválido`)
+	})
+
+	t.Run("passa ao próximo modelo depois da nova tentativa", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		gemini.Enqueue(fakegemini.JSON(invalid), fakegemini.JSON(invalid), fakegemini.JSON(validTrail()))
+		days := 6
+
+		_, err := service.GenerateEducationalTrail(context.Background(), "Go", &days, "pt-BR")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gemini-2.5-flash", "gemini-2.5-flash", "gemini-2.5-pro"}, gemini.CalledModels())
+		assert.Contains(t, gemini.Requests()[2].Prompt, "- total_days é 3, esperado 6\n")
+	})
+
+	t.Run("feedback no idioma do prompt", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		gemini.Enqueue(fakegemini.JSON(invalid), fakegemini.JSON(validTrail()))
+		days := 6
+
+		_, err := service.GenerateEducationalTrail(context.Background(), "Go", &days, "en")
+
+		require.NoError(t, err)
+		prompt := gemini.Requests()[1].Prompt
+		assert.Contains(t, prompt, "a previous response to this request was rejected")
+		assert.Contains(t, prompt, "- total_days is 3, expected 6\n")
+		assert.Contains(t, prompt, `- day 1: invalid activity type "listen_podcast"`)
+		assert.NotContains(t, prompt, "esperado")
+	})
+
+	t.Run("todos os modelos rejeitados", func(t *testing.T) {
+		service, gemini := newFakeService(t)
+		service.FallbackModels = nil
+		gemini.Enqueue(fakegemini.JSON(invalid), fakegemini.JSON(invalid), fakegemini.JSON(invalid), fakegemini.JSON(invalid))
+		days := 6
+
+		_, err := service.GenerateEducationalTrail(context.Background(), "Go", &days, "pt-BR")
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, apperror.ErrOutputInvalid)
+		assert.Contains(t, err.Error(), "total_days é 3, esperado 6")
+		assert.Len(t, gemini.CalledModels(), 4)
+	})
+}
+
+func TestGeminiService_GenerateEducationalTrail_FollowsPlan(t *testing.T) {
+	for _, days := range []int{3, 10, 20} {
+		service, gemini := newFakeService(t)
+
+		trail, err := service.GenerateEducationalTrail(context.Background(), "Go", &days, "pt-BR")
+
+		require.NoError(t, err, "%d dias", days)
+		assert.Len(t, trail.Steps, days)
+		assert.Equal(t, []string{"gemini-2.5-flash"}, gemini.CalledModels(), "%d dias", days)
+	}
+}
+
+func TestGeminiService_GenerateEducationalTrail_CatalogChapters(t *testing.T) {
+	resources, err := catalog.New(catalog.Entry{
+		Type: catalog.TypeBook, Title: "The Go Programming Language", Tags: []string{"go"},
+		Chapters: []string{"Tutorial", "Program Structure"},
+	})
+	require.NoError(t, err)
+	service, gemini := newFakeService(t)
+	service.Apply(Settings{APIKeys: []string{"fake-key"}, Catalog: resources, CatalogMode: catalog.ModePrefer})
+
+	// O modelo cita um capítulo que a lista curada não tem e outro com grafia diferente
+	cited := validTrail()
+	cited.Steps[1].Activities[0].Chapters = []string{"Concurrency", "program structure"}
+	cited.Steps[2].Activities[0].Chapters = []string{"Concurrency"}
+	gemini.Enqueue(fakegemini.JSON(cited))
+	days := 6
+
+	trail, err := service.GenerateEducationalTrail(context.Background(), "Go", &days, "pt-BR")
+
+	require.NoError(t, err)
+	requests := gemini.Requests()
+	require.Len(t, requests, 1)
+	assert.Contains(t, requests[0].Prompt, "[book] The Go Programming Language - capítulos: Tutorial; Program Structure")
+	resource := trail.Resources[trail.Steps[0].Activities[0].ResourceID]
+	assert.Equal(t, []string{"Tutorial", "Program Structure"}, resource.Chapters)
+	assert.Equal(t, []string{"Program Structure"}, trail.Steps[1].Activities[0].Chapters)
+	assert.Equal(t, []string{"Tutorial"}, trail.Steps[0].Activities[0].Chapters)
+	assert.Equal(t, "read_book", trail.Steps[2].Activities[0].Type)
+	assert.Empty(t, trail.Steps[2].Activities[0].Chapters)
+}
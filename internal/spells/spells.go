@@ -0,0 +1,224 @@
+// Package spells carrega as gerações definidas em arquivos de configuração ("spells"). Cada
+// arquivo declara o schema da requisição, o template de prompt e o schema da resposta, e a
+// geração é servida em /api/v1/spells/{nome} com o mesmo fallback dos endpoints embutidos.
+package spells
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/spellbook/spellbook/internal/openapi"
+	"github.com/spellbook/spellbook/internal/prompts"
+	"gopkg.in/yaml.v3"
+)
+
+// validName restringe os nomes ao que pode aparecer na rota e nas métricas
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Spell é uma geração definida em arquivo
+type Spell struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Version identifica o prompt nos logs e traces (padrão v1)
+	Version  string          `json:"version"`
+	Request  *openapi.Schema `json:"request"`
+	Response *openapi.Schema `json:"response"`
+
+	prompt *template.Template
+}
+
+// definition é o formato do arquivo
+type definition struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Version     string          `json:"version"`
+	Request     *openapi.Schema `json:"request"`
+	Prompt      string          `json:"prompt"`
+	Response    *openapi.Schema `json:"response"`
+}
+
+// Parse lê a definição de um spell em YAML ou JSON. O nome vem do arquivo quando não é
+// declarado nele.
+func Parse(path string, data []byte) (*Spell, error) {
+	// O YAML é convertido para JSON para reaproveitar as tags dos schemas do OpenAPI
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("spell %s inválido: %w", path, err)
+	}
+	converted, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("spell %s inválido: %w", path, err)
+	}
+
+	var def definition
+	decoder := json.NewDecoder(bytes.NewReader(converted))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&def); err != nil {
+		return nil, fmt.Errorf("spell %s inválido: %w", path, err)
+	}
+
+	if def.Name == "" {
+		def.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if def.Version == "" {
+		def.Version = "v1"
+	}
+
+	var problems []string
+	if !validName.MatchString(def.Name) {
+		problems = append(problems, fmt.Sprintf("nome %q inválido (use letras minúsculas, números, - e _)", def.Name))
+	}
+	if !isObject(def.Request) {
+		problems = append(problems, "request deve ser um schema do tipo object")
+	}
+	if !isObject(def.Response) {
+		problems = append(problems, "response deve ser um schema do tipo object")
+	}
+	if strings.TrimSpace(def.Prompt) == "" {
+		problems = append(problems, "prompt não pode ser vazio")
+	}
+	tmpl, err := template.New(def.Name).Parse(def.Prompt)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("prompt inválido: %v", err))
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("spell %s inválido: %s", path, strings.Join(problems, "; "))
+	}
+
+	return &Spell{
+		Name:        def.Name,
+		Description: def.Description,
+		Version:     def.Version,
+		Request:     def.Request,
+		Response:    def.Response,
+		prompt:      tmpl,
+	}, nil
+}
+
+func isObject(schema *openapi.Schema) bool {
+	return schema != nil && len(schema.Type) == 1 && schema.Type[0] == "object"
+}
+
+// ValidateRequest valida o corpo da requisição contra o schema do spell
+func (s *Spell) ValidateRequest(input map[string]interface{}) []apperror.FieldError {
+	return s.Request.Validate(input)
+}
+
+// ValidateResponse valida a resposta do modelo contra o schema do spell
+func (s *Spell) ValidateResponse(output map[string]interface{}) error {
+	fields := s.Response.Validate(output)
+	if len(fields) == 0 {
+		return nil
+	}
+	problems := make([]string, len(fields))
+	for i, field := range fields {
+		problems[i] = fmt.Sprintf("%s: %s", field.Field, field.Message)
+	}
+	return fmt.Errorf("resposta não segue o schema do spell: %s", strings.Join(problems, "; "))
+}
+
+// RenderPrompt renderiza o prompt com os campos da requisição, completados com os valores
+// padrão (default) do schema. O idioma da resposta fica disponível em {{.Language}}.
+func (s *Spell) RenderPrompt(input map[string]interface{}, language string) (prompts.Prompt, error) {
+	data := map[string]interface{}{"Language": language}
+	for name, property := range s.Request.Properties {
+		if property.Default != nil {
+			data[name] = property.Default
+		}
+	}
+	for name, value := range input {
+		data[name] = value
+	}
+
+	var buf bytes.Buffer
+	if err := s.prompt.Execute(&buf, data); err != nil {
+		return prompts.Prompt{}, fmt.Errorf("erro ao renderizar prompt do spell %s: %w", s.Name, err)
+	}
+	return prompts.Prompt{Name: "spell_" + s.Name, Version: s.Version, Language: language, Text: buf.String()}, nil
+}
+
+// Registry guarda os spells carregados, por nome
+type Registry struct {
+	spells map[string]*Spell
+}
+
+// NewRegistry cria um registry com os spells informados
+func NewRegistry(spells ...*Spell) (*Registry, error) {
+	registry := &Registry{spells: make(map[string]*Spell)}
+	for _, spell := range spells {
+		if _, ok := registry.spells[spell.Name]; ok {
+			return nil, fmt.Errorf("spell %q definido mais de uma vez", spell.Name)
+		}
+		registry.spells[spell.Name] = spell
+	}
+	return registry, nil
+}
+
+// Load carrega os arquivos .yaml, .yml e .json do diretório (vazio não carrega nenhum).
+// Retorna erro com todos os arquivos inválidos.
+func Load(dir string) (*Registry, error) {
+	if dir == "" {
+		return NewRegistry()
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler diretório de spells: %w", err)
+	}
+
+	var spells []*Spell
+	var errs []error
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		if entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		spell, err := Parse(path, data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		spells = append(spells, spell)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return NewRegistry(spells...)
+}
+
+// Get retorna o spell pelo nome
+func (r *Registry) Get(name string) (*Spell, bool) {
+	spell, ok := r.spells[name]
+	return spell, ok
+}
+
+// List retorna os spells em ordem alfabética
+func (r *Registry) List() []*Spell {
+	spells := make([]*Spell, 0, len(r.spells))
+	for _, spell := range r.spells {
+		spells = append(spells, spell)
+	}
+	sort.Slice(spells, func(i, j int) bool { return spells[i].Name < spells[j].Name })
+	return spells
+}
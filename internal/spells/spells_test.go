@@ -0,0 +1,142 @@
+package spells
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const glossary = `
+description: Glossário de termos de um tema
+request:
+  type: object
+  required: [topic]
+  properties:
+    topic: {type: string, minLength: 1}
+    count: {type: integer, minimum: 1, maximum: 50, default: 10}
+prompt: |
+  Gere {{.count}} termos sobre {{.topic}} em {{.Language}}.
+response:
+  type: object
+  required: [terms]
+  properties:
+    terms:
+      type: array
+      minItems: 1
+      items:
+        type: object
+        required: [term, definition]
+`
+
+func writeSpell(t *testing.T, dir, name, content string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+}
+
+func TestParse(t *testing.T) {
+	spell, err := Parse("spells/glossary.yaml", []byte(glossary))
+	require.NoError(t, err)
+
+	assert.Equal(t, "glossary", spell.Name)
+	assert.Equal(t, "v1", spell.Version)
+
+	t.Run("prompt com os valores padrão do schema", func(t *testing.T) {
+		prompt, err := spell.RenderPrompt(map[string]interface{}{"topic": "Go"}, "en")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Gere 10 termos sobre Go em en.\n", prompt.Text)
+		assert.Equal(t, "spell_glossary@v1", prompt.String())
+	})
+
+	t.Run("validação da requisição", func(t *testing.T) {
+		assert.Empty(t, spell.ValidateRequest(map[string]interface{}{"topic": "Go", "count": 5.0}))
+
+		fields := spell.ValidateRequest(map[string]interface{}{"count": 100.0})
+		require.Len(t, fields, 2)
+		assert.Equal(t, "/topic", fields[0].Field)
+		assert.Equal(t, "/count", fields[1].Field)
+	})
+
+	t.Run("validação da resposta", func(t *testing.T) {
+		assert.NoError(t, spell.ValidateResponse(map[string]interface{}{
+			"terms": []interface{}{map[string]interface{}{"term": "goroutine", "definition": "..."}},
+		}))
+
+		err := spell.ValidateResponse(map[string]interface{}{"terms": []interface{}{map[string]interface{}{"term": "goroutine"}}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "/terms/0/definition: campo obrigatório")
+	})
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		message string
+	}{
+		{"sem prompt", "request: {type: object}\nresponse: {type: object}\n", "prompt não pode ser vazio"},
+		{"resposta que não é objeto", "request: {type: object}\nprompt: x\nresponse: {type: array}\n", "response deve ser um schema do tipo object"},
+		{"template inválido", "request: {type: object}\nprompt: '{{.topic'\nresponse: {type: object}\n", "prompt inválido"},
+		{"nome inválido", "name: Meu Spell\nrequest: {type: object}\nprompt: x\nresponse: {type: object}\n", "nome \"Meu Spell\" inválido"},
+		{"campo desconhecido", "request: {type: object}\nprompt: x\nresponse: {type: object}\nroute: /x\n", "unknown field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("spell.yaml", []byte(tt.content))
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("carrega YAML e JSON do diretório", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSpell(t, dir, "glossary.yaml", glossary)
+		writeSpell(t, dir, "interview.json", `{"request": {"type": "object"}, "prompt": "Perguntas", "response": {"type": "object"}}`)
+		writeSpell(t, dir, "README.md", "ignorado")
+
+		registry, err := Load(dir)
+
+		require.NoError(t, err)
+		names := []string{}
+		for _, spell := range registry.List() {
+			names = append(names, spell.Name)
+		}
+		assert.Equal(t, []string{"glossary", "interview"}, names)
+		_, ok := registry.Get("interview")
+		assert.True(t, ok)
+	})
+
+	t.Run("lista todos os arquivos inválidos", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSpell(t, dir, "a.yaml", "prompt: x\n")
+		writeSpell(t, dir, "b.yaml", "request: [\n")
+
+		_, err := Load(dir)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "a.yaml")
+		assert.Contains(t, err.Error(), "b.yaml")
+	})
+
+	t.Run("nome repetido", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSpell(t, dir, "glossary.yaml", glossary)
+		writeSpell(t, dir, "other.yaml", "name: glossary\n"+glossary)
+
+		_, err := Load(dir)
+
+		assert.ErrorContains(t, err, "definido mais de uma vez")
+	})
+
+	t.Run("sem diretório", func(t *testing.T) {
+		registry, err := Load("")
+
+		require.NoError(t, err)
+		assert.Empty(t, registry.List())
+	})
+}
@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifica os spans criados pelo Spellbook
+const instrumentationName = "github.com/spellbook/spellbook"
+
+// Atributos usados nos spans de geração
+const (
+	AttrOperation     = attribute.Key("spellbook.operation")
+	AttrOutcome       = attribute.Key("spellbook.outcome")
+	AttrPromptHash    = attribute.Key("spellbook.prompt_hash")
+	AttrPromptVersion = attribute.Key("spellbook.prompt_version")
+	AttrLanguage      = attribute.Key("spellbook.language")
+	AttrModel         = attribute.Key("gen_ai.request.model")
+	AttrInputTokens   = attribute.Key("gen_ai.usage.input_tokens")
+	AttrOutputTokens  = attribute.Key("gen_ai.usage.output_tokens")
+)
+
+// Setup configura a propagação W3C (traceparent/baggage) e, quando endpoint não é vazio,
+// o exportador OTLP/HTTP. Retorna a função que descarrega e encerra o provider.
+func Setup(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if endpoint == "" {
+		// Sem coletor configurado: mantém o provider noop, mas ainda propaga o contexto
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(tracesURL(endpoint)))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar exportador OTLP: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", "spellbook"),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar resource do tracing: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// tracesURL monta a URL de envio de traces a partir da URL base do coletor,
+// seguindo a convenção de OTEL_EXPORTER_OTLP_ENDPOINT (acrescenta /v1/traces)
+func tracesURL(endpoint string) string {
+	endpoint = strings.TrimRight(endpoint, "/")
+	if strings.HasSuffix(endpoint, "/v1/traces") {
+		return endpoint
+	}
+	return endpoint + "/v1/traces"
+}
+
+// Tracer retorna o tracer usado pelos handlers e serviços
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start inicia um span filho do span presente no contexto
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,91 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeCollector simula um coletor OTLP/HTTP e guarda os nomes dos spans recebidos
+type fakeCollector struct {
+	mu    sync.Mutex
+	spans []string
+}
+
+func (f *fakeCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/traces" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	var req coltracepb.ExportTraceServiceRequest
+	if err := proto.Unmarshal(body, &req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				f.spans = append(f.spans, span.Name)
+			}
+		}
+	}
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestSetup_ExportsSpansToCollector(t *testing.T) {
+	collector := &fakeCollector{}
+	server := httptest.NewServer(collector)
+	defer server.Close()
+
+	shutdown, err := Setup(context.Background(), server.URL)
+	require.NoError(t, err)
+
+	ctx, parent := Start(context.Background(), "POST /api/v1/topics")
+	_, child := Start(ctx, "gemini.attempt", AttrModel.String("gemini-test"))
+	child.End()
+	parent.End()
+
+	require.NoError(t, shutdown(context.Background()))
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	assert.ElementsMatch(t, []string{"POST /api/v1/topics", "gemini.attempt"}, collector.spans)
+}
+
+func TestSetup_WithoutEndpointPropagatesTraceContext(t *testing.T) {
+	shutdown, err := Setup(context.Background(), "")
+	require.NoError(t, err)
+	defer shutdown(context.Background())
+
+	header := http.Header{}
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(header))
+
+	out := http.Header{}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(out))
+	assert.Contains(t, out.Get("traceparent"), "4bf92f3577b34da6a3ce929d0e0e4736")
+}
+
+func TestTracesURL(t *testing.T) {
+	assert.Equal(t, "http://localhost:4318/v1/traces", tracesURL("http://localhost:4318"))
+	assert.Equal(t, "http://localhost:4318/v1/traces", tracesURL("http://localhost:4318/"))
+	assert.Equal(t, "http://collector/v1/traces", tracesURL("http://collector/v1/traces"))
+}
@@ -0,0 +1,334 @@
+// Package client é o SDK Go oficial da API do Spellbook.
+//
+// Os tipos de requisição e resposta das gerações são os mesmos usados pelo servidor
+// (internal/models, que não depende de outros pacotes), expostos aqui como aliases. O corpo
+// dos erros e os lotes têm tipos próprios, para o SDK não importar o servidor:
+//
+//	c := client.NewClient("http://localhost:8080")
+//	topics, err := c.GenerateTopics(ctx, client.TopicsRequest{Subject: "Go", Count: 5})
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/models"
+)
+
+// Tipos de requisição e resposta da API
+type (
+	RoadmapRequest            = models.RoadmapRequest
+	Roadmap                   = models.Roadmap
+	RoadmapCategory           = models.RoadmapCategory
+	RoadmapItem               = models.RoadmapItem
+	TopicsRequest             = models.TopicsRequest
+	TopicsResponse            = models.TopicsResponse
+	KeyResultsRequest         = models.KeyResultsRequest
+	KeyResultsResponse        = models.KeyResultsResponse
+	EducationalRoadmapRequest = models.EducationalRoadmapRequest
+	EducationalRoadmap        = models.EducationalRoadmap
+	EducationalResource       = models.EducationalResource
+	EducationalTrailRequest   = models.EducationalTrailRequest
+	EducationalTrail          = models.EducationalTrail
+	EducationalTrailStep      = models.EducationalTrailStep
+	Activity                  = models.Activity
+)
+
+// Problem é o corpo das respostas de erro (RFC 7807)
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError descreve um campo inválido da requisição
+type FieldError struct {
+	Field   string `json:"field"` // Caminho do campo como JSON Pointer (ex: /count)
+	Message string `json:"message"`
+}
+
+// BatchItem é um item do lote: o tipo de geração (roadmap, topics, key-results,
+// educational-roadmap ou educational-trail) e o corpo aceito pelo endpoint equivalente
+type BatchItem struct {
+	Type    string          `json:"type"`
+	Request json.RawMessage `json:"request"`
+}
+
+// BatchRequest é o corpo de POST /api/v1/batch
+type BatchRequest struct {
+	Items    []BatchItem `json:"items"`
+	Async    bool        `json:"async,omitempty"`    // Executa como job e responde 202 com o ID
+	Language string      `json:"language,omitempty"` // Idioma padrão dos itens que não informam language
+}
+
+// BatchResult é o resultado de um item do lote. Result guarda o JSON da resposta
+// do endpoint equivalente ao tipo do item; use Decode para convertê-lo.
+type BatchResult struct {
+	Index  int             `json:"index"`
+	Type   string          `json:"type"`
+	Status int             `json:"status"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *Problem        `json:"error,omitempty"`
+}
+
+// Decode decodifica o resultado do item em out (ex: *TopicsResponse)
+func (r BatchResult) Decode(out interface{}) error {
+	if r.Error != nil {
+		return &APIError{StatusCode: r.Status, Problem: *r.Error}
+	}
+	return json.Unmarshal(r.Result, out)
+}
+
+// BatchResponse é a resposta de um lote síncrono
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+// Estados de um job
+const (
+	JobPending   = "pending"
+	JobRunning   = "running"
+	JobCompleted = "completed"
+)
+
+// Job é um lote assíncrono
+type Job struct {
+	ID          string        `json:"id"`
+	Status      string        `json:"status"`
+	Total       int           `json:"total"`
+	CreatedAt   time.Time     `json:"created_at"`
+	CompletedAt *time.Time    `json:"completed_at,omitempty"`
+	Results     []BatchResult `json:"results,omitempty"`
+}
+
+// Done indica se o job terminou
+func (j *Job) Done() bool {
+	return j.Status == JobCompleted
+}
+
+// Client chama a API do Spellbook
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	// MaxRetries é o número de novas tentativas em respostas 429 e 503 transitórias
+	// (com Retry-After ou código upstream_unavailable)
+	MaxRetries int
+	// RetryBackoff é a espera antes da primeira nova tentativa; dobra a cada tentativa.
+	// O header Retry-After da resposta tem prioridade.
+	RetryBackoff time.Duration
+}
+
+// NewClient cria um cliente para a API no endereço informado (ex: http://localhost:8080)
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		HTTPClient: &http.Client{
+			Timeout: 5 * time.Minute, // As gerações podem levar minutos
+		},
+		MaxRetries:   3,
+		RetryBackoff: time.Second,
+	}
+}
+
+// APIError é devolvido quando a API responde com status de erro
+type APIError struct {
+	StatusCode int
+	Problem    Problem
+}
+
+func (e *APIError) Error() string {
+	if e.Problem.Code != "" {
+		return fmt.Sprintf("spellbook: %d %s: %s", e.StatusCode, e.Problem.Code, e.Problem.Detail)
+	}
+	return fmt.Sprintf("spellbook: status %d", e.StatusCode)
+}
+
+// GenerateRoadmap gera um roadmap de estudo
+func (c *Client) GenerateRoadmap(ctx context.Context, req RoadmapRequest) (*Roadmap, error) {
+	var resp Roadmap
+	if err := c.post(ctx, "/api/v1/roadmap", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GenerateTopics gera uma lista de tópicos sobre um assunto
+func (c *Client) GenerateTopics(ctx context.Context, req TopicsRequest) (*TopicsResponse, error) {
+	var resp TopicsResponse
+	if err := c.post(ctx, "/api/v1/topics", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GenerateKeyResults gera Key Results para um objetivo OKR
+func (c *Client) GenerateKeyResults(ctx context.Context, req KeyResultsRequest) (*KeyResultsResponse, error) {
+	var resp KeyResultsResponse
+	if err := c.post(ctx, "/api/v1/key-results", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GenerateEducationalRoadmap gera um roadmap educacional
+func (c *Client) GenerateEducationalRoadmap(ctx context.Context, req EducationalRoadmapRequest) (*EducationalRoadmap, error) {
+	var resp EducationalRoadmap
+	if err := c.post(ctx, "/api/v1/educational-roadmap", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GenerateEducationalTrail gera uma trilha educacional
+func (c *Client) GenerateEducationalTrail(ctx context.Context, req EducationalTrailRequest) (*EducationalTrail, error) {
+	var resp EducationalTrail
+	if err := c.post(ctx, "/api/v1/educational-trail", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RunBatch executa um lote e aguarda os resultados na mesma resposta
+func (c *Client) RunBatch(ctx context.Context, items []BatchItem, language string) (*BatchResponse, error) {
+	var resp BatchResponse
+	req := BatchRequest{Items: items, Language: language}
+	if err := c.post(ctx, "/api/v1/batch", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SubmitBatch envia um lote assíncrono e retorna o job criado
+func (c *Client) SubmitBatch(ctx context.Context, items []BatchItem, language string) (*Job, error) {
+	var job Job
+	req := BatchRequest{Items: items, Async: true, Language: language}
+	if err := c.post(ctx, "/api/v1/batch", req, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetJob consulta o estado de um job
+func (c *Client) GetJob(ctx context.Context, id string) (*Job, error) {
+	var job Job
+	if err := c.do(ctx, http.MethodGet, "/api/v1/jobs/"+url.PathEscape(id), nil, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// WaitJob consulta o job a cada interval até que ele termine ou o contexto seja cancelado
+func (c *Client) WaitJob(ctx context.Context, id string, interval time.Duration) (*Job, error) {
+	for {
+		job, err := c.GetJob(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if job.Done() {
+			return job, nil
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// post envia a requisição JSON e decodifica a resposta, repetindo em 429 e nos 503 transitórios
+func (c *Client) post(ctx context.Context, path string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodPost, path, payload, out)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, payload []byte, out interface{}) error {
+	for attempt := 0; ; attempt++ {
+		var reader io.Reader
+		if payload != nil {
+			reader = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+		if err != nil {
+			return err
+		}
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return err
+		}
+
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			if out == nil {
+				return nil
+			}
+			return json.Unmarshal(data, out)
+		}
+
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		_ = json.Unmarshal(data, &apiErr.Problem)
+
+		retryAfter := resp.Header.Get("Retry-After")
+		if !retryable(resp.StatusCode, retryAfter, apiErr.Problem.Code) || attempt >= c.MaxRetries {
+			return apiErr
+		}
+
+		select {
+		case <-time.After(c.retryDelay(attempt, retryAfter)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// retryDelay usa o Retry-After (em segundos) ou o backoff exponencial
+func (c *Client) retryDelay(attempt int, retryAfter string) time.Duration {
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return c.RetryBackoff << attempt
+}
+
+// transientCodes são os códigos de erro 503 que passam sozinhos. Outros 503, como
+// api_key_missing (servidor sem API key do Gemini), só mudam com ação do operador.
+var transientCodes = map[string]bool{
+	"upstream_unavailable": true,
+}
+
+// retryable repete todo 429 e os 503 com Retry-After ou código transitório
+func retryable(statusCode int, retryAfter, code string) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		return retryAfter != "" || transientCodes[code]
+	default:
+		return false
+	}
+}
@@ -0,0 +1,263 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spellbook/spellbook/internal/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(server *httptest.Server) *Client {
+	c := NewClient(server.URL)
+	c.RetryBackoff = time.Millisecond
+	return c
+}
+
+func TestClient_GenerateTopics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/topics", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var req TopicsRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "Go", req.Subject)
+		assert.Equal(t, 3, req.Count)
+		assert.Equal(t, "en", req.Language)
+
+		json.NewEncoder(w).Encode(TopicsResponse{Subject: "Go", Topics: []string{"Goroutines", "Channels", "Interfaces"}})
+	}))
+	defer server.Close()
+
+	topics, err := newTestClient(server).GenerateTopics(context.Background(), TopicsRequest{Subject: "Go", Count: 3, Language: "en"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Goroutines", "Channels", "Interfaces"}, topics.Topics)
+}
+
+func TestClient_AllEndpoints(t *testing.T) {
+	paths := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{"topic": "Go"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	ctx := context.Background()
+	days := 7
+
+	roadmap, err := c.GenerateRoadmap(ctx, RoadmapRequest{Topic: "Go", AvailableDays: &days})
+	require.NoError(t, err)
+	assert.Equal(t, "Go", roadmap.Topic)
+
+	_, err = c.GenerateKeyResults(ctx, KeyResultsRequest{Objective: "Crescer"})
+	require.NoError(t, err)
+
+	educational, err := c.GenerateEducationalRoadmap(ctx, EducationalRoadmapRequest{Topic: "Go"})
+	require.NoError(t, err)
+	assert.Equal(t, "Go", educational.Topic)
+
+	trail, err := c.GenerateEducationalTrail(ctx, EducationalTrailRequest{Topic: "Go"})
+	require.NoError(t, err)
+	assert.Equal(t, "Go", trail.Topic)
+
+	assert.Equal(t, []string{
+		"/api/v1/roadmap",
+		"/api/v1/key-results",
+		"/api/v1/educational-roadmap",
+		"/api/v1/educational-trail",
+	}, paths)
+}
+
+func TestClient_RetriesOnQuotaAndUnavailable(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"status": 503, "code": "upstream_unavailable"}`))
+		case 3:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"subject": "Go", "topics": ["Goroutines"]}`))
+		}
+	}))
+	defer server.Close()
+
+	topics, err := newTestClient(server).GenerateTopics(context.Background(), TopicsRequest{Subject: "Go"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Go", topics.Subject)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+}
+
+func TestClient_DoesNotRetryPermanentUnavailable(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"status": 503, "code": "api_key_missing", "detail": "API key do Gemini não configurada"}`))
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).GenerateTopics(context.Background(), TopicsRequest{Subject: "Go"})
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "api_key_missing", apiErr.Problem.Code)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestClient_ReturnsAPIErrorWithProblem(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"type": "urn:spellbook:error:request_invalid", "title": "Requisição inválida", "status": 400, "code": "request_invalid", "detail": "a requisição não segue o esquema da API", "errors": [{"field": "/count", "message": "deve ser maior ou igual a 0"}]}`))
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).GenerateTopics(context.Background(), TopicsRequest{Subject: "Go", Count: -1})
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, "request_invalid", apiErr.Problem.Code)
+	assert.Equal(t, "/count", apiErr.Problem.Errors[0].Field)
+	// Erros de validação não são repetidos
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestProblem_MatchesServer(t *testing.T) {
+	server := apperror.Problem{
+		Type: "urn:spellbook:error:request_invalid", Title: "Requisição inválida", Status: 400,
+		Detail: "detalhe", Instance: "/api/v1/topics", Code: "request_invalid", RequestID: "abc",
+		Errors: []apperror.FieldError{{Field: "/count", Message: "inválido"}},
+	}
+	data, err := json.Marshal(server)
+	require.NoError(t, err)
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(data, &problem))
+	roundTrip, err := json.Marshal(problem)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), string(roundTrip))
+}
+
+func TestClient_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	c.MaxRetries = 2
+
+	_, err := c.GenerateTopics(context.Background(), TopicsRequest{Subject: "Go"})
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestClient_RetryRespectsContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := newTestClient(server).GenerateTopics(ctx, TopicsRequest{Subject: "Go"})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestClient_RunBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/batch", r.URL.Path)
+
+		var req BatchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Len(t, req.Items, 2)
+		assert.False(t, req.Async)
+		assert.Equal(t, "en", req.Language)
+
+		w.Write([]byte(`{"results": [
+			{"index": 0, "type": "topics", "status": 200, "result": {"subject": "Go", "topics": ["Goroutines"]}},
+			{"index": 1, "type": "topics", "status": 429, "error": {"status": 429, "code": "upstream_quota"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	resp, err := newTestClient(server).RunBatch(context.Background(), []BatchItem{
+		{Type: "topics", Request: json.RawMessage(`{"subject": "Go"}`)},
+		{Type: "topics", Request: json.RawMessage(`{"subject": "Rust"}`)},
+	}, "en")
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+
+	var topics TopicsResponse
+	require.NoError(t, resp.Results[0].Decode(&topics))
+	assert.Equal(t, []string{"Goroutines"}, topics.Topics)
+
+	err = resp.Results[1].Decode(&topics)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "upstream_quota", apiErr.Problem.Code)
+}
+
+func TestClient_SubmitBatchAndWaitJob(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var req BatchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.True(t, req.Async)
+
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "abc", "status": "pending", "total": 1}`))
+			return
+		}
+
+		assert.Equal(t, "/api/v1/jobs/abc", r.URL.Path)
+		if atomic.AddInt32(&polls, 1) < 3 {
+			w.Write([]byte(`{"id": "abc", "status": "running", "total": 1}`))
+			return
+		}
+		w.Write([]byte(`{"id": "abc", "status": "completed", "total": 1, "results": [{"index": 0, "type": "topics", "status": 200, "result": {}}]}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	job, err := c.SubmitBatch(context.Background(), []BatchItem{{Type: "topics", Request: json.RawMessage(`{"subject": "Go"}`)}}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", job.ID)
+	assert.False(t, job.Done())
+
+	job, err = c.WaitJob(context.Background(), job.ID, time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, job.Done())
+	assert.Len(t, job.Results, 1)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&polls))
+}
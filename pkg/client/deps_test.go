@@ -0,0 +1,51 @@
+package client
+
+import (
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/spellbook/spellbook/internal/batch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// O SDK é importado por outros módulos: além da biblioteca padrão, só pode depender dos
+// modelos da API, nunca do servidor (gin, serviços, métricas, tracing)
+func TestClient_Dependencies(t *testing.T) {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go não encontrado no PATH")
+	}
+
+	out, err := exec.Command(goBin, "list", "-deps", "-f", "{{if not .Standard}}{{.ImportPath}}{{end}}", ".").Output()
+	require.NoError(t, err)
+
+	for _, pkg := range strings.Fields(string(out)) {
+		assert.Contains(t, []string{
+			"github.com/spellbook/spellbook/internal/models",
+			"github.com/spellbook/spellbook/pkg/client",
+		}, pkg, "dependência inesperada no SDK")
+	}
+}
+
+// Os tipos de lote do SDK seguem o formato aceito pelo servidor
+func TestBatchRequest_MatchesServer(t *testing.T) {
+	data, err := json.Marshal(batch.Request{
+		Items:    []batch.Item{{Type: "topics", Request: json.RawMessage(`{"subject":"Go"}`)}},
+		Async:    true,
+		Language: "en",
+	})
+	require.NoError(t, err)
+
+	var req BatchRequest
+	require.NoError(t, json.Unmarshal(data, &req))
+	roundTrip, err := json.Marshal(req)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), string(roundTrip))
+
+	assert.Equal(t, batch.JobCompleted, JobCompleted)
+	assert.Equal(t, batch.JobPending, JobPending)
+	assert.Equal(t, batch.JobRunning, JobRunning)
+}
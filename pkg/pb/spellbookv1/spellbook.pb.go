@@ -0,0 +1,1581 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: spellbook/v1/spellbook.proto
+
+package spellbookv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RoadmapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topic         string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	AvailableDays *int32                 `protobuf:"varint,2,opt,name=available_days,json=availableDays,proto3,oneof" json:"available_days,omitempty"`
+	// Número exato de itens a serem gerados
+	ExactItemCount *int32 `protobuf:"varint,3,opt,name=exact_item_count,json=exactItemCount,proto3,oneof" json:"exact_item_count,omitempty"`
+	// Idioma da resposta em BCP 47 (ex: pt-BR, en, es)
+	Language      string `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoadmapRequest) Reset() {
+	*x = RoadmapRequest{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoadmapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoadmapRequest) ProtoMessage() {}
+
+func (x *RoadmapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoadmapRequest.ProtoReflect.Descriptor instead.
+func (*RoadmapRequest) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RoadmapRequest) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *RoadmapRequest) GetAvailableDays() int32 {
+	if x != nil && x.AvailableDays != nil {
+		return *x.AvailableDays
+	}
+	return 0
+}
+
+func (x *RoadmapRequest) GetExactItemCount() int32 {
+	if x != nil && x.ExactItemCount != nil {
+		return *x.ExactItemCount
+	}
+	return 0
+}
+
+func (x *RoadmapRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type RoadmapItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Completed     bool                   `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoadmapItem) Reset() {
+	*x = RoadmapItem{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoadmapItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoadmapItem) ProtoMessage() {}
+
+func (x *RoadmapItem) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoadmapItem.ProtoReflect.Descriptor instead.
+func (*RoadmapItem) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RoadmapItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RoadmapItem) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *RoadmapItem) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+type RoadmapCategory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Items         []*RoadmapItem         `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoadmapCategory) Reset() {
+	*x = RoadmapCategory{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoadmapCategory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoadmapCategory) ProtoMessage() {}
+
+func (x *RoadmapCategory) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoadmapCategory.ProtoReflect.Descriptor instead.
+func (*RoadmapCategory) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RoadmapCategory) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *RoadmapCategory) GetItems() []*RoadmapItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type Roadmap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topic         string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Roadmap       []*RoadmapCategory     `protobuf:"bytes,2,rep,name=roadmap,proto3" json:"roadmap,omitempty"`
+	PromptVersion string                 `protobuf:"bytes,3,opt,name=prompt_version,json=promptVersion,proto3" json:"prompt_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Roadmap) Reset() {
+	*x = Roadmap{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Roadmap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Roadmap) ProtoMessage() {}
+
+func (x *Roadmap) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Roadmap.ProtoReflect.Descriptor instead.
+func (*Roadmap) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Roadmap) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Roadmap) GetRoadmap() []*RoadmapCategory {
+	if x != nil {
+		return x.Roadmap
+	}
+	return nil
+}
+
+func (x *Roadmap) GetPromptVersion() string {
+	if x != nil {
+		return x.PromptVersion
+	}
+	return ""
+}
+
+type TopicsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Subject string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Quantidade de tópicos (0 usa o padrão de 10)
+	Count         int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Language      string `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopicsRequest) Reset() {
+	*x = TopicsRequest{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopicsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicsRequest) ProtoMessage() {}
+
+func (x *TopicsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicsRequest.ProtoReflect.Descriptor instead.
+func (*TopicsRequest) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TopicsRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *TopicsRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *TopicsRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type TopicsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Topics        []string               `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+	PromptVersion string                 `protobuf:"bytes,3,opt,name=prompt_version,json=promptVersion,proto3" json:"prompt_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopicsResponse) Reset() {
+	*x = TopicsResponse{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopicsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicsResponse) ProtoMessage() {}
+
+func (x *TopicsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicsResponse.ProtoReflect.Descriptor instead.
+func (*TopicsResponse) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TopicsResponse) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *TopicsResponse) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *TopicsResponse) GetPromptVersion() string {
+	if x != nil {
+		return x.PromptVersion
+	}
+	return ""
+}
+
+type KeyResultsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Objective string                 `protobuf:"bytes,1,opt,name=objective,proto3" json:"objective,omitempty"`
+	// Quantidade de Key Results (0 usa o padrão de 5)
+	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Data de conclusão no formato YYYY-MM-DD
+	CompletionDate *string `protobuf:"bytes,3,opt,name=completion_date,json=completionDate,proto3,oneof" json:"completion_date,omitempty"`
+	Language       string  `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *KeyResultsRequest) Reset() {
+	*x = KeyResultsRequest{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyResultsRequest) ProtoMessage() {}
+
+func (x *KeyResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyResultsRequest.ProtoReflect.Descriptor instead.
+func (*KeyResultsRequest) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *KeyResultsRequest) GetObjective() string {
+	if x != nil {
+		return x.Objective
+	}
+	return ""
+}
+
+func (x *KeyResultsRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *KeyResultsRequest) GetCompletionDate() string {
+	if x != nil && x.CompletionDate != nil {
+		return *x.CompletionDate
+	}
+	return ""
+}
+
+func (x *KeyResultsRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type KeyResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Objective     string                 `protobuf:"bytes,1,opt,name=objective,proto3" json:"objective,omitempty"`
+	KeyResults    []string               `protobuf:"bytes,2,rep,name=key_results,json=keyResults,proto3" json:"key_results,omitempty"`
+	PromptVersion string                 `protobuf:"bytes,3,opt,name=prompt_version,json=promptVersion,proto3" json:"prompt_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyResultsResponse) Reset() {
+	*x = KeyResultsResponse{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyResultsResponse) ProtoMessage() {}
+
+func (x *KeyResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyResultsResponse.ProtoReflect.Descriptor instead.
+func (*KeyResultsResponse) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *KeyResultsResponse) GetObjective() string {
+	if x != nil {
+		return x.Objective
+	}
+	return ""
+}
+
+func (x *KeyResultsResponse) GetKeyResults() []string {
+	if x != nil {
+		return x.KeyResults
+	}
+	return nil
+}
+
+func (x *KeyResultsResponse) GetPromptVersion() string {
+	if x != nil {
+		return x.PromptVersion
+	}
+	return ""
+}
+
+type EducationalResource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Chapters      []string               `protobuf:"bytes,4,rep,name=chapters,proto3" json:"chapters,omitempty"`
+	Duration      string                 `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	Author        string                 `protobuf:"bytes,6,opt,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EducationalResource) Reset() {
+	*x = EducationalResource{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EducationalResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EducationalResource) ProtoMessage() {}
+
+func (x *EducationalResource) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EducationalResource.ProtoReflect.Descriptor instead.
+func (*EducationalResource) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EducationalResource) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *EducationalResource) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *EducationalResource) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *EducationalResource) GetChapters() []string {
+	if x != nil {
+		return x.Chapters
+	}
+	return nil
+}
+
+func (x *EducationalResource) GetDuration() string {
+	if x != nil {
+		return x.Duration
+	}
+	return ""
+}
+
+func (x *EducationalResource) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+type EducationalRoadmapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topic         string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EducationalRoadmapRequest) Reset() {
+	*x = EducationalRoadmapRequest{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EducationalRoadmapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EducationalRoadmapRequest) ProtoMessage() {}
+
+func (x *EducationalRoadmapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EducationalRoadmapRequest.ProtoReflect.Descriptor instead.
+func (*EducationalRoadmapRequest) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EducationalRoadmapRequest) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *EducationalRoadmapRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type EducationalRoadmap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topic         string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Books         []*EducationalResource `protobuf:"bytes,2,rep,name=books,proto3" json:"books,omitempty"`
+	Courses       []*EducationalResource `protobuf:"bytes,3,rep,name=courses,proto3" json:"courses,omitempty"`
+	Videos        []*EducationalResource `protobuf:"bytes,4,rep,name=videos,proto3" json:"videos,omitempty"`
+	Articles      []*EducationalResource `protobuf:"bytes,5,rep,name=articles,proto3" json:"articles,omitempty"`
+	Projects      []*EducationalResource `protobuf:"bytes,6,rep,name=projects,proto3" json:"projects,omitempty"`
+	PromptVersion string                 `protobuf:"bytes,7,opt,name=prompt_version,json=promptVersion,proto3" json:"prompt_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EducationalRoadmap) Reset() {
+	*x = EducationalRoadmap{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EducationalRoadmap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EducationalRoadmap) ProtoMessage() {}
+
+func (x *EducationalRoadmap) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EducationalRoadmap.ProtoReflect.Descriptor instead.
+func (*EducationalRoadmap) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EducationalRoadmap) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *EducationalRoadmap) GetBooks() []*EducationalResource {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+func (x *EducationalRoadmap) GetCourses() []*EducationalResource {
+	if x != nil {
+		return x.Courses
+	}
+	return nil
+}
+
+func (x *EducationalRoadmap) GetVideos() []*EducationalResource {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+func (x *EducationalRoadmap) GetArticles() []*EducationalResource {
+	if x != nil {
+		return x.Articles
+	}
+	return nil
+}
+
+func (x *EducationalRoadmap) GetProjects() []*EducationalResource {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+func (x *EducationalRoadmap) GetPromptVersion() string {
+	if x != nil {
+		return x.PromptVersion
+	}
+	return ""
+}
+
+type EducationalTrailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topic         string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	AvailableDays *int32                 `protobuf:"varint,2,opt,name=available_days,json=availableDays,proto3,oneof" json:"available_days,omitempty"`
+	Language      string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EducationalTrailRequest) Reset() {
+	*x = EducationalTrailRequest{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EducationalTrailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EducationalTrailRequest) ProtoMessage() {}
+
+func (x *EducationalTrailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EducationalTrailRequest.ProtoReflect.Descriptor instead.
+func (*EducationalTrailRequest) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EducationalTrailRequest) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *EducationalTrailRequest) GetAvailableDays() int32 {
+	if x != nil && x.AvailableDays != nil {
+		return *x.AvailableDays
+	}
+	return 0
+}
+
+func (x *EducationalTrailRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type Activity struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// read_book, read_chapters, watch_video, read_article, do_project ou take_course
+	Type          string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	ResourceId    string   `protobuf:"bytes,2,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	Title         string   `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string   `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Chapters      []string `protobuf:"bytes,5,rep,name=chapters,proto3" json:"chapters,omitempty"`
+	Duration      string   `protobuf:"bytes,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	Url           string   `protobuf:"bytes,7,opt,name=url,proto3" json:"url,omitempty"`
+	Progress      string   `protobuf:"bytes,8,opt,name=progress,proto3" json:"progress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Activity) Reset() {
+	*x = Activity{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Activity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Activity) ProtoMessage() {}
+
+func (x *Activity) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Activity.ProtoReflect.Descriptor instead.
+func (*Activity) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Activity) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Activity) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *Activity) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Activity) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Activity) GetChapters() []string {
+	if x != nil {
+		return x.Chapters
+	}
+	return nil
+}
+
+func (x *Activity) GetDuration() string {
+	if x != nil {
+		return x.Duration
+	}
+	return ""
+}
+
+func (x *Activity) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Activity) GetProgress() string {
+	if x != nil {
+		return x.Progress
+	}
+	return ""
+}
+
+type EducationalTrailStep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           int32                  `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Activities    []*Activity            `protobuf:"bytes,4,rep,name=activities,proto3" json:"activities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EducationalTrailStep) Reset() {
+	*x = EducationalTrailStep{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EducationalTrailStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EducationalTrailStep) ProtoMessage() {}
+
+func (x *EducationalTrailStep) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EducationalTrailStep.ProtoReflect.Descriptor instead.
+func (*EducationalTrailStep) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EducationalTrailStep) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *EducationalTrailStep) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *EducationalTrailStep) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *EducationalTrailStep) GetActivities() []*Activity {
+	if x != nil {
+		return x.Activities
+	}
+	return nil
+}
+
+type EducationalTrail struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	Topic         string                          `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	TotalDays     int32                           `protobuf:"varint,2,opt,name=total_days,json=totalDays,proto3" json:"total_days,omitempty"`
+	Description   string                          `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Steps         []*EducationalTrailStep         `protobuf:"bytes,4,rep,name=steps,proto3" json:"steps,omitempty"`
+	Resources     map[string]*EducationalResource `protobuf:"bytes,5,rep,name=resources,proto3" json:"resources,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	PromptVersion string                          `protobuf:"bytes,6,opt,name=prompt_version,json=promptVersion,proto3" json:"prompt_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EducationalTrail) Reset() {
+	*x = EducationalTrail{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EducationalTrail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EducationalTrail) ProtoMessage() {}
+
+func (x *EducationalTrail) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EducationalTrail.ProtoReflect.Descriptor instead.
+func (*EducationalTrail) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EducationalTrail) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *EducationalTrail) GetTotalDays() int32 {
+	if x != nil {
+		return x.TotalDays
+	}
+	return 0
+}
+
+func (x *EducationalTrail) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *EducationalTrail) GetSteps() []*EducationalTrailStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *EducationalTrail) GetResources() map[string]*EducationalResource {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *EducationalTrail) GetPromptVersion() string {
+	if x != nil {
+		return x.PromptVersion
+	}
+	return ""
+}
+
+// Progress é enviado periodicamente enquanto a geração está em andamento
+type Progress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tempo desde o início da geração, em milissegundos
+	ElapsedMs     int64 `protobuf:"varint,1,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Progress) Reset() {
+	*x = Progress{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Progress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Progress) ProtoMessage() {}
+
+func (x *Progress) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Progress.ProtoReflect.Descriptor instead.
+func (*Progress) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Progress) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+type RoadmapEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*RoadmapEvent_Progress
+	//	*RoadmapEvent_Result
+	Event         isRoadmapEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoadmapEvent) Reset() {
+	*x = RoadmapEvent{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoadmapEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoadmapEvent) ProtoMessage() {}
+
+func (x *RoadmapEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoadmapEvent.ProtoReflect.Descriptor instead.
+func (*RoadmapEvent) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RoadmapEvent) GetEvent() isRoadmapEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *RoadmapEvent) GetProgress() *Progress {
+	if x != nil {
+		if x, ok := x.Event.(*RoadmapEvent_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
+}
+
+func (x *RoadmapEvent) GetResult() *Roadmap {
+	if x != nil {
+		if x, ok := x.Event.(*RoadmapEvent_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isRoadmapEvent_Event interface {
+	isRoadmapEvent_Event()
+}
+
+type RoadmapEvent_Progress struct {
+	Progress *Progress `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type RoadmapEvent_Result struct {
+	Result *Roadmap `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+func (*RoadmapEvent_Progress) isRoadmapEvent_Event() {}
+
+func (*RoadmapEvent_Result) isRoadmapEvent_Event() {}
+
+type EducationalRoadmapEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*EducationalRoadmapEvent_Progress
+	//	*EducationalRoadmapEvent_Result
+	Event         isEducationalRoadmapEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EducationalRoadmapEvent) Reset() {
+	*x = EducationalRoadmapEvent{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EducationalRoadmapEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EducationalRoadmapEvent) ProtoMessage() {}
+
+func (x *EducationalRoadmapEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EducationalRoadmapEvent.ProtoReflect.Descriptor instead.
+func (*EducationalRoadmapEvent) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EducationalRoadmapEvent) GetEvent() isEducationalRoadmapEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *EducationalRoadmapEvent) GetProgress() *Progress {
+	if x != nil {
+		if x, ok := x.Event.(*EducationalRoadmapEvent_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
+}
+
+func (x *EducationalRoadmapEvent) GetResult() *EducationalRoadmap {
+	if x != nil {
+		if x, ok := x.Event.(*EducationalRoadmapEvent_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isEducationalRoadmapEvent_Event interface {
+	isEducationalRoadmapEvent_Event()
+}
+
+type EducationalRoadmapEvent_Progress struct {
+	Progress *Progress `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type EducationalRoadmapEvent_Result struct {
+	Result *EducationalRoadmap `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+func (*EducationalRoadmapEvent_Progress) isEducationalRoadmapEvent_Event() {}
+
+func (*EducationalRoadmapEvent_Result) isEducationalRoadmapEvent_Event() {}
+
+type EducationalTrailEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*EducationalTrailEvent_Progress
+	//	*EducationalTrailEvent_Result
+	Event         isEducationalTrailEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EducationalTrailEvent) Reset() {
+	*x = EducationalTrailEvent{}
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EducationalTrailEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EducationalTrailEvent) ProtoMessage() {}
+
+func (x *EducationalTrailEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_spellbook_v1_spellbook_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EducationalTrailEvent.ProtoReflect.Descriptor instead.
+func (*EducationalTrailEvent) Descriptor() ([]byte, []int) {
+	return file_spellbook_v1_spellbook_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *EducationalTrailEvent) GetEvent() isEducationalTrailEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *EducationalTrailEvent) GetProgress() *Progress {
+	if x != nil {
+		if x, ok := x.Event.(*EducationalTrailEvent_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
+}
+
+func (x *EducationalTrailEvent) GetResult() *EducationalTrail {
+	if x != nil {
+		if x, ok := x.Event.(*EducationalTrailEvent_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isEducationalTrailEvent_Event interface {
+	isEducationalTrailEvent_Event()
+}
+
+type EducationalTrailEvent_Progress struct {
+	Progress *Progress `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type EducationalTrailEvent_Result struct {
+	Result *EducationalTrail `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+func (*EducationalTrailEvent_Progress) isEducationalTrailEvent_Event() {}
+
+func (*EducationalTrailEvent_Result) isEducationalTrailEvent_Event() {}
+
+var File_spellbook_v1_spellbook_proto protoreflect.FileDescriptor
+
+const file_spellbook_v1_spellbook_proto_rawDesc = "" +
+	"\n" +
+	"\x1cspellbook/v1/spellbook.proto\x12\fspellbook.v1\"\xc5\x01\n" +
+	"\x0eRoadmapRequest\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x12*\n" +
+	"\x0eavailable_days\x18\x02 \x01(\x05H\x00R\ravailableDays\x88\x01\x01\x12-\n" +
+	"\x10exact_item_count\x18\x03 \x01(\x05H\x01R\x0eexactItemCount\x88\x01\x01\x12\x1a\n" +
+	"\blanguage\x18\x04 \x01(\tR\blanguageB\x11\n" +
+	"\x0f_available_daysB\x13\n" +
+	"\x11_exact_item_count\"Q\n" +
+	"\vRoadmapItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1c\n" +
+	"\tcompleted\x18\x03 \x01(\bR\tcompleted\"^\n" +
+	"\x0fRoadmapCategory\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12/\n" +
+	"\x05items\x18\x02 \x03(\v2\x19.spellbook.v1.RoadmapItemR\x05items\"\x7f\n" +
+	"\aRoadmap\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x127\n" +
+	"\aroadmap\x18\x02 \x03(\v2\x1d.spellbook.v1.RoadmapCategoryR\aroadmap\x12%\n" +
+	"\x0eprompt_version\x18\x03 \x01(\tR\rpromptVersion\"[\n" +
+	"\rTopicsRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\"i\n" +
+	"\x0eTopicsResponse\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x16\n" +
+	"\x06topics\x18\x02 \x03(\tR\x06topics\x12%\n" +
+	"\x0eprompt_version\x18\x03 \x01(\tR\rpromptVersion\"\xa5\x01\n" +
+	"\x11KeyResultsRequest\x12\x1c\n" +
+	"\tobjective\x18\x01 \x01(\tR\tobjective\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12,\n" +
+	"\x0fcompletion_date\x18\x03 \x01(\tH\x00R\x0ecompletionDate\x88\x01\x01\x12\x1a\n" +
+	"\blanguage\x18\x04 \x01(\tR\blanguageB\x12\n" +
+	"\x10_completion_date\"z\n" +
+	"\x12KeyResultsResponse\x12\x1c\n" +
+	"\tobjective\x18\x01 \x01(\tR\tobjective\x12\x1f\n" +
+	"\vkey_results\x18\x02 \x03(\tR\n" +
+	"keyResults\x12%\n" +
+	"\x0eprompt_version\x18\x03 \x01(\tR\rpromptVersion\"\xaf\x01\n" +
+	"\x13EducationalResource\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x1a\n" +
+	"\bchapters\x18\x04 \x03(\tR\bchapters\x12\x1a\n" +
+	"\bduration\x18\x05 \x01(\tR\bduration\x12\x16\n" +
+	"\x06author\x18\x06 \x01(\tR\x06author\"M\n" +
+	"\x19EducationalRoadmapRequest\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\"\x80\x03\n" +
+	"\x12EducationalRoadmap\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x127\n" +
+	"\x05books\x18\x02 \x03(\v2!.spellbook.v1.EducationalResourceR\x05books\x12;\n" +
+	"\acourses\x18\x03 \x03(\v2!.spellbook.v1.EducationalResourceR\acourses\x129\n" +
+	"\x06videos\x18\x04 \x03(\v2!.spellbook.v1.EducationalResourceR\x06videos\x12=\n" +
+	"\barticles\x18\x05 \x03(\v2!.spellbook.v1.EducationalResourceR\barticles\x12=\n" +
+	"\bprojects\x18\x06 \x03(\v2!.spellbook.v1.EducationalResourceR\bprojects\x12%\n" +
+	"\x0eprompt_version\x18\a \x01(\tR\rpromptVersion\"\x8a\x01\n" +
+	"\x17EducationalTrailRequest\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x12*\n" +
+	"\x0eavailable_days\x18\x02 \x01(\x05H\x00R\ravailableDays\x88\x01\x01\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguageB\x11\n" +
+	"\x0f_available_days\"\xdd\x01\n" +
+	"\bActivity\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1f\n" +
+	"\vresource_id\x18\x02 \x01(\tR\n" +
+	"resourceId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bchapters\x18\x05 \x03(\tR\bchapters\x12\x1a\n" +
+	"\bduration\x18\x06 \x01(\tR\bduration\x12\x10\n" +
+	"\x03url\x18\a \x01(\tR\x03url\x12\x1a\n" +
+	"\bprogress\x18\b \x01(\tR\bprogress\"\x98\x01\n" +
+	"\x14EducationalTrailStep\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\x05R\x03day\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x126\n" +
+	"\n" +
+	"activities\x18\x04 \x03(\v2\x16.spellbook.v1.ActivityR\n" +
+	"activities\"\xf8\x02\n" +
+	"\x10EducationalTrail\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x12\x1d\n" +
+	"\n" +
+	"total_days\x18\x02 \x01(\x05R\ttotalDays\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x128\n" +
+	"\x05steps\x18\x04 \x03(\v2\".spellbook.v1.EducationalTrailStepR\x05steps\x12K\n" +
+	"\tresources\x18\x05 \x03(\v2-.spellbook.v1.EducationalTrail.ResourcesEntryR\tresources\x12%\n" +
+	"\x0eprompt_version\x18\x06 \x01(\tR\rpromptVersion\x1a_\n" +
+	"\x0eResourcesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x127\n" +
+	"\x05value\x18\x02 \x01(\v2!.spellbook.v1.EducationalResourceR\x05value:\x028\x01\")\n" +
+	"\bProgress\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\x01 \x01(\x03R\telapsedMs\"~\n" +
+	"\fRoadmapEvent\x124\n" +
+	"\bprogress\x18\x01 \x01(\v2\x16.spellbook.v1.ProgressH\x00R\bprogress\x12/\n" +
+	"\x06result\x18\x02 \x01(\v2\x15.spellbook.v1.RoadmapH\x00R\x06resultB\a\n" +
+	"\x05event\"\x94\x01\n" +
+	"\x17EducationalRoadmapEvent\x124\n" +
+	"\bprogress\x18\x01 \x01(\v2\x16.spellbook.v1.ProgressH\x00R\bprogress\x12:\n" +
+	"\x06result\x18\x02 \x01(\v2 .spellbook.v1.EducationalRoadmapH\x00R\x06resultB\a\n" +
+	"\x05event\"\x90\x01\n" +
+	"\x15EducationalTrailEvent\x124\n" +
+	"\bprogress\x18\x01 \x01(\v2\x16.spellbook.v1.ProgressH\x00R\bprogress\x128\n" +
+	"\x06result\x18\x02 \x01(\v2\x1e.spellbook.v1.EducationalTrailH\x00R\x06resultB\a\n" +
+	"\x05event2\xef\x05\n" +
+	"\x10SpellbookService\x12F\n" +
+	"\x0fGenerateRoadmap\x12\x1c.spellbook.v1.RoadmapRequest\x1a\x15.spellbook.v1.Roadmap\x12K\n" +
+	"\x0eGenerateTopics\x12\x1b.spellbook.v1.TopicsRequest\x1a\x1c.spellbook.v1.TopicsResponse\x12W\n" +
+	"\x12GenerateKeyResults\x12\x1f.spellbook.v1.KeyResultsRequest\x1a .spellbook.v1.KeyResultsResponse\x12g\n" +
+	"\x1aGenerateEducationalRoadmap\x12'.spellbook.v1.EducationalRoadmapRequest\x1a .spellbook.v1.EducationalRoadmap\x12a\n" +
+	"\x18GenerateEducationalTrail\x12%.spellbook.v1.EducationalTrailRequest\x1a\x1e.spellbook.v1.EducationalTrail\x12K\n" +
+	"\rStreamRoadmap\x12\x1c.spellbook.v1.RoadmapRequest\x1a\x1a.spellbook.v1.RoadmapEvent0\x01\x12l\n" +
+	"\x18StreamEducationalRoadmap\x12'.spellbook.v1.EducationalRoadmapRequest\x1a%.spellbook.v1.EducationalRoadmapEvent0\x01\x12f\n" +
+	"\x16StreamEducationalTrail\x12%.spellbook.v1.EducationalTrailRequest\x1a#.spellbook.v1.EducationalTrailEvent0\x01B?Z=github.com/spellbook/spellbook/pkg/pb/spellbookv1;spellbookv1b\x06proto3"
+
+var (
+	file_spellbook_v1_spellbook_proto_rawDescOnce sync.Once
+	file_spellbook_v1_spellbook_proto_rawDescData []byte
+)
+
+func file_spellbook_v1_spellbook_proto_rawDescGZIP() []byte {
+	file_spellbook_v1_spellbook_proto_rawDescOnce.Do(func() {
+		file_spellbook_v1_spellbook_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_spellbook_v1_spellbook_proto_rawDesc), len(file_spellbook_v1_spellbook_proto_rawDesc)))
+	})
+	return file_spellbook_v1_spellbook_proto_rawDescData
+}
+
+var file_spellbook_v1_spellbook_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_spellbook_v1_spellbook_proto_goTypes = []any{
+	(*RoadmapRequest)(nil),            // 0: spellbook.v1.RoadmapRequest
+	(*RoadmapItem)(nil),               // 1: spellbook.v1.RoadmapItem
+	(*RoadmapCategory)(nil),           // 2: spellbook.v1.RoadmapCategory
+	(*Roadmap)(nil),                   // 3: spellbook.v1.Roadmap
+	(*TopicsRequest)(nil),             // 4: spellbook.v1.TopicsRequest
+	(*TopicsResponse)(nil),            // 5: spellbook.v1.TopicsResponse
+	(*KeyResultsRequest)(nil),         // 6: spellbook.v1.KeyResultsRequest
+	(*KeyResultsResponse)(nil),        // 7: spellbook.v1.KeyResultsResponse
+	(*EducationalResource)(nil),       // 8: spellbook.v1.EducationalResource
+	(*EducationalRoadmapRequest)(nil), // 9: spellbook.v1.EducationalRoadmapRequest
+	(*EducationalRoadmap)(nil),        // 10: spellbook.v1.EducationalRoadmap
+	(*EducationalTrailRequest)(nil),   // 11: spellbook.v1.EducationalTrailRequest
+	(*Activity)(nil),                  // 12: spellbook.v1.Activity
+	(*EducationalTrailStep)(nil),      // 13: spellbook.v1.EducationalTrailStep
+	(*EducationalTrail)(nil),          // 14: spellbook.v1.EducationalTrail
+	(*Progress)(nil),                  // 15: spellbook.v1.Progress
+	(*RoadmapEvent)(nil),              // 16: spellbook.v1.RoadmapEvent
+	(*EducationalRoadmapEvent)(nil),   // 17: spellbook.v1.EducationalRoadmapEvent
+	(*EducationalTrailEvent)(nil),     // 18: spellbook.v1.EducationalTrailEvent
+	nil,                               // 19: spellbook.v1.EducationalTrail.ResourcesEntry
+}
+var file_spellbook_v1_spellbook_proto_depIdxs = []int32{
+	1,  // 0: spellbook.v1.RoadmapCategory.items:type_name -> spellbook.v1.RoadmapItem
+	2,  // 1: spellbook.v1.Roadmap.roadmap:type_name -> spellbook.v1.RoadmapCategory
+	8,  // 2: spellbook.v1.EducationalRoadmap.books:type_name -> spellbook.v1.EducationalResource
+	8,  // 3: spellbook.v1.EducationalRoadmap.courses:type_name -> spellbook.v1.EducationalResource
+	8,  // 4: spellbook.v1.EducationalRoadmap.videos:type_name -> spellbook.v1.EducationalResource
+	8,  // 5: spellbook.v1.EducationalRoadmap.articles:type_name -> spellbook.v1.EducationalResource
+	8,  // 6: spellbook.v1.EducationalRoadmap.projects:type_name -> spellbook.v1.EducationalResource
+	12, // 7: spellbook.v1.EducationalTrailStep.activities:type_name -> spellbook.v1.Activity
+	13, // 8: spellbook.v1.EducationalTrail.steps:type_name -> spellbook.v1.EducationalTrailStep
+	19, // 9: spellbook.v1.EducationalTrail.resources:type_name -> spellbook.v1.EducationalTrail.ResourcesEntry
+	15, // 10: spellbook.v1.RoadmapEvent.progress:type_name -> spellbook.v1.Progress
+	3,  // 11: spellbook.v1.RoadmapEvent.result:type_name -> spellbook.v1.Roadmap
+	15, // 12: spellbook.v1.EducationalRoadmapEvent.progress:type_name -> spellbook.v1.Progress
+	10, // 13: spellbook.v1.EducationalRoadmapEvent.result:type_name -> spellbook.v1.EducationalRoadmap
+	15, // 14: spellbook.v1.EducationalTrailEvent.progress:type_name -> spellbook.v1.Progress
+	14, // 15: spellbook.v1.EducationalTrailEvent.result:type_name -> spellbook.v1.EducationalTrail
+	8,  // 16: spellbook.v1.EducationalTrail.ResourcesEntry.value:type_name -> spellbook.v1.EducationalResource
+	0,  // 17: spellbook.v1.SpellbookService.GenerateRoadmap:input_type -> spellbook.v1.RoadmapRequest
+	4,  // 18: spellbook.v1.SpellbookService.GenerateTopics:input_type -> spellbook.v1.TopicsRequest
+	6,  // 19: spellbook.v1.SpellbookService.GenerateKeyResults:input_type -> spellbook.v1.KeyResultsRequest
+	9,  // 20: spellbook.v1.SpellbookService.GenerateEducationalRoadmap:input_type -> spellbook.v1.EducationalRoadmapRequest
+	11, // 21: spellbook.v1.SpellbookService.GenerateEducationalTrail:input_type -> spellbook.v1.EducationalTrailRequest
+	0,  // 22: spellbook.v1.SpellbookService.StreamRoadmap:input_type -> spellbook.v1.RoadmapRequest
+	9,  // 23: spellbook.v1.SpellbookService.StreamEducationalRoadmap:input_type -> spellbook.v1.EducationalRoadmapRequest
+	11, // 24: spellbook.v1.SpellbookService.StreamEducationalTrail:input_type -> spellbook.v1.EducationalTrailRequest
+	3,  // 25: spellbook.v1.SpellbookService.GenerateRoadmap:output_type -> spellbook.v1.Roadmap
+	5,  // 26: spellbook.v1.SpellbookService.GenerateTopics:output_type -> spellbook.v1.TopicsResponse
+	7,  // 27: spellbook.v1.SpellbookService.GenerateKeyResults:output_type -> spellbook.v1.KeyResultsResponse
+	10, // 28: spellbook.v1.SpellbookService.GenerateEducationalRoadmap:output_type -> spellbook.v1.EducationalRoadmap
+	14, // 29: spellbook.v1.SpellbookService.GenerateEducationalTrail:output_type -> spellbook.v1.EducationalTrail
+	16, // 30: spellbook.v1.SpellbookService.StreamRoadmap:output_type -> spellbook.v1.RoadmapEvent
+	17, // 31: spellbook.v1.SpellbookService.StreamEducationalRoadmap:output_type -> spellbook.v1.EducationalRoadmapEvent
+	18, // 32: spellbook.v1.SpellbookService.StreamEducationalTrail:output_type -> spellbook.v1.EducationalTrailEvent
+	25, // [25:33] is the sub-list for method output_type
+	17, // [17:25] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_spellbook_v1_spellbook_proto_init() }
+func file_spellbook_v1_spellbook_proto_init() {
+	if File_spellbook_v1_spellbook_proto != nil {
+		return
+	}
+	file_spellbook_v1_spellbook_proto_msgTypes[0].OneofWrappers = []any{}
+	file_spellbook_v1_spellbook_proto_msgTypes[6].OneofWrappers = []any{}
+	file_spellbook_v1_spellbook_proto_msgTypes[11].OneofWrappers = []any{}
+	file_spellbook_v1_spellbook_proto_msgTypes[16].OneofWrappers = []any{
+		(*RoadmapEvent_Progress)(nil),
+		(*RoadmapEvent_Result)(nil),
+	}
+	file_spellbook_v1_spellbook_proto_msgTypes[17].OneofWrappers = []any{
+		(*EducationalRoadmapEvent_Progress)(nil),
+		(*EducationalRoadmapEvent_Result)(nil),
+	}
+	file_spellbook_v1_spellbook_proto_msgTypes[18].OneofWrappers = []any{
+		(*EducationalTrailEvent_Progress)(nil),
+		(*EducationalTrailEvent_Result)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spellbook_v1_spellbook_proto_rawDesc), len(file_spellbook_v1_spellbook_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spellbook_v1_spellbook_proto_goTypes,
+		DependencyIndexes: file_spellbook_v1_spellbook_proto_depIdxs,
+		MessageInfos:      file_spellbook_v1_spellbook_proto_msgTypes,
+	}.Build()
+	File_spellbook_v1_spellbook_proto = out.File
+	file_spellbook_v1_spellbook_proto_goTypes = nil
+	file_spellbook_v1_spellbook_proto_depIdxs = nil
+}
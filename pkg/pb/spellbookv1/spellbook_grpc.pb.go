@@ -0,0 +1,413 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: spellbook/v1/spellbook.proto
+
+package spellbookv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SpellbookService_GenerateRoadmap_FullMethodName            = "/spellbook.v1.SpellbookService/GenerateRoadmap"
+	SpellbookService_GenerateTopics_FullMethodName             = "/spellbook.v1.SpellbookService/GenerateTopics"
+	SpellbookService_GenerateKeyResults_FullMethodName         = "/spellbook.v1.SpellbookService/GenerateKeyResults"
+	SpellbookService_GenerateEducationalRoadmap_FullMethodName = "/spellbook.v1.SpellbookService/GenerateEducationalRoadmap"
+	SpellbookService_GenerateEducationalTrail_FullMethodName   = "/spellbook.v1.SpellbookService/GenerateEducationalTrail"
+	SpellbookService_StreamRoadmap_FullMethodName              = "/spellbook.v1.SpellbookService/StreamRoadmap"
+	SpellbookService_StreamEducationalRoadmap_FullMethodName   = "/spellbook.v1.SpellbookService/StreamEducationalRoadmap"
+	SpellbookService_StreamEducationalTrail_FullMethodName     = "/spellbook.v1.SpellbookService/StreamEducationalTrail"
+)
+
+// SpellbookServiceClient is the client API for SpellbookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SpellbookService espelha os endpoints REST de /api/v1. Os campos seguem os mesmos
+// nomes e regras do JSON; campos opcionais usam presença explícita (optional).
+//
+// Erros usam os códigos gRPC equivalentes aos status HTTP e trazem um
+// google.rpc.ErrorInfo com o código estável da API (ex: upstream_quota) em reason.
+type SpellbookServiceClient interface {
+	GenerateRoadmap(ctx context.Context, in *RoadmapRequest, opts ...grpc.CallOption) (*Roadmap, error)
+	GenerateTopics(ctx context.Context, in *TopicsRequest, opts ...grpc.CallOption) (*TopicsResponse, error)
+	GenerateKeyResults(ctx context.Context, in *KeyResultsRequest, opts ...grpc.CallOption) (*KeyResultsResponse, error)
+	GenerateEducationalRoadmap(ctx context.Context, in *EducationalRoadmapRequest, opts ...grpc.CallOption) (*EducationalRoadmap, error)
+	GenerateEducationalTrail(ctx context.Context, in *EducationalTrailRequest, opts ...grpc.CallOption) (*EducationalTrail, error)
+	// Variantes com streaming para as gerações longas: enviam eventos de progresso
+	// periódicos enquanto o modelo gera e, ao final, o resultado.
+	StreamRoadmap(ctx context.Context, in *RoadmapRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoadmapEvent], error)
+	StreamEducationalRoadmap(ctx context.Context, in *EducationalRoadmapRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EducationalRoadmapEvent], error)
+	StreamEducationalTrail(ctx context.Context, in *EducationalTrailRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EducationalTrailEvent], error)
+}
+
+type spellbookServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSpellbookServiceClient(cc grpc.ClientConnInterface) SpellbookServiceClient {
+	return &spellbookServiceClient{cc}
+}
+
+func (c *spellbookServiceClient) GenerateRoadmap(ctx context.Context, in *RoadmapRequest, opts ...grpc.CallOption) (*Roadmap, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Roadmap)
+	err := c.cc.Invoke(ctx, SpellbookService_GenerateRoadmap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *spellbookServiceClient) GenerateTopics(ctx context.Context, in *TopicsRequest, opts ...grpc.CallOption) (*TopicsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopicsResponse)
+	err := c.cc.Invoke(ctx, SpellbookService_GenerateTopics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *spellbookServiceClient) GenerateKeyResults(ctx context.Context, in *KeyResultsRequest, opts ...grpc.CallOption) (*KeyResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyResultsResponse)
+	err := c.cc.Invoke(ctx, SpellbookService_GenerateKeyResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *spellbookServiceClient) GenerateEducationalRoadmap(ctx context.Context, in *EducationalRoadmapRequest, opts ...grpc.CallOption) (*EducationalRoadmap, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EducationalRoadmap)
+	err := c.cc.Invoke(ctx, SpellbookService_GenerateEducationalRoadmap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *spellbookServiceClient) GenerateEducationalTrail(ctx context.Context, in *EducationalTrailRequest, opts ...grpc.CallOption) (*EducationalTrail, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EducationalTrail)
+	err := c.cc.Invoke(ctx, SpellbookService_GenerateEducationalTrail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *spellbookServiceClient) StreamRoadmap(ctx context.Context, in *RoadmapRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoadmapEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SpellbookService_ServiceDesc.Streams[0], SpellbookService_StreamRoadmap_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RoadmapRequest, RoadmapEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SpellbookService_StreamRoadmapClient = grpc.ServerStreamingClient[RoadmapEvent]
+
+func (c *spellbookServiceClient) StreamEducationalRoadmap(ctx context.Context, in *EducationalRoadmapRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EducationalRoadmapEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SpellbookService_ServiceDesc.Streams[1], SpellbookService_StreamEducationalRoadmap_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EducationalRoadmapRequest, EducationalRoadmapEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SpellbookService_StreamEducationalRoadmapClient = grpc.ServerStreamingClient[EducationalRoadmapEvent]
+
+func (c *spellbookServiceClient) StreamEducationalTrail(ctx context.Context, in *EducationalTrailRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EducationalTrailEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SpellbookService_ServiceDesc.Streams[2], SpellbookService_StreamEducationalTrail_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EducationalTrailRequest, EducationalTrailEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SpellbookService_StreamEducationalTrailClient = grpc.ServerStreamingClient[EducationalTrailEvent]
+
+// SpellbookServiceServer is the server API for SpellbookService service.
+// All implementations must embed UnimplementedSpellbookServiceServer
+// for forward compatibility.
+//
+// SpellbookService espelha os endpoints REST de /api/v1. Os campos seguem os mesmos
+// nomes e regras do JSON; campos opcionais usam presença explícita (optional).
+//
+// Erros usam os códigos gRPC equivalentes aos status HTTP e trazem um
+// google.rpc.ErrorInfo com o código estável da API (ex: upstream_quota) em reason.
+type SpellbookServiceServer interface {
+	GenerateRoadmap(context.Context, *RoadmapRequest) (*Roadmap, error)
+	GenerateTopics(context.Context, *TopicsRequest) (*TopicsResponse, error)
+	GenerateKeyResults(context.Context, *KeyResultsRequest) (*KeyResultsResponse, error)
+	GenerateEducationalRoadmap(context.Context, *EducationalRoadmapRequest) (*EducationalRoadmap, error)
+	GenerateEducationalTrail(context.Context, *EducationalTrailRequest) (*EducationalTrail, error)
+	// Variantes com streaming para as gerações longas: enviam eventos de progresso
+	// periódicos enquanto o modelo gera e, ao final, o resultado.
+	StreamRoadmap(*RoadmapRequest, grpc.ServerStreamingServer[RoadmapEvent]) error
+	StreamEducationalRoadmap(*EducationalRoadmapRequest, grpc.ServerStreamingServer[EducationalRoadmapEvent]) error
+	StreamEducationalTrail(*EducationalTrailRequest, grpc.ServerStreamingServer[EducationalTrailEvent]) error
+	mustEmbedUnimplementedSpellbookServiceServer()
+}
+
+// UnimplementedSpellbookServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSpellbookServiceServer struct{}
+
+func (UnimplementedSpellbookServiceServer) GenerateRoadmap(context.Context, *RoadmapRequest) (*Roadmap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateRoadmap not implemented")
+}
+func (UnimplementedSpellbookServiceServer) GenerateTopics(context.Context, *TopicsRequest) (*TopicsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateTopics not implemented")
+}
+func (UnimplementedSpellbookServiceServer) GenerateKeyResults(context.Context, *KeyResultsRequest) (*KeyResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateKeyResults not implemented")
+}
+func (UnimplementedSpellbookServiceServer) GenerateEducationalRoadmap(context.Context, *EducationalRoadmapRequest) (*EducationalRoadmap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateEducationalRoadmap not implemented")
+}
+func (UnimplementedSpellbookServiceServer) GenerateEducationalTrail(context.Context, *EducationalTrailRequest) (*EducationalTrail, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateEducationalTrail not implemented")
+}
+func (UnimplementedSpellbookServiceServer) StreamRoadmap(*RoadmapRequest, grpc.ServerStreamingServer[RoadmapEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRoadmap not implemented")
+}
+func (UnimplementedSpellbookServiceServer) StreamEducationalRoadmap(*EducationalRoadmapRequest, grpc.ServerStreamingServer[EducationalRoadmapEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEducationalRoadmap not implemented")
+}
+func (UnimplementedSpellbookServiceServer) StreamEducationalTrail(*EducationalTrailRequest, grpc.ServerStreamingServer[EducationalTrailEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEducationalTrail not implemented")
+}
+func (UnimplementedSpellbookServiceServer) mustEmbedUnimplementedSpellbookServiceServer() {}
+func (UnimplementedSpellbookServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeSpellbookServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SpellbookServiceServer will
+// result in compilation errors.
+type UnsafeSpellbookServiceServer interface {
+	mustEmbedUnimplementedSpellbookServiceServer()
+}
+
+func RegisterSpellbookServiceServer(s grpc.ServiceRegistrar, srv SpellbookServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSpellbookServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SpellbookService_ServiceDesc, srv)
+}
+
+func _SpellbookService_GenerateRoadmap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoadmapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpellbookServiceServer).GenerateRoadmap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpellbookService_GenerateRoadmap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpellbookServiceServer).GenerateRoadmap(ctx, req.(*RoadmapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SpellbookService_GenerateTopics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopicsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpellbookServiceServer).GenerateTopics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpellbookService_GenerateTopics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpellbookServiceServer).GenerateTopics(ctx, req.(*TopicsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SpellbookService_GenerateKeyResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpellbookServiceServer).GenerateKeyResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpellbookService_GenerateKeyResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpellbookServiceServer).GenerateKeyResults(ctx, req.(*KeyResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SpellbookService_GenerateEducationalRoadmap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EducationalRoadmapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpellbookServiceServer).GenerateEducationalRoadmap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpellbookService_GenerateEducationalRoadmap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpellbookServiceServer).GenerateEducationalRoadmap(ctx, req.(*EducationalRoadmapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SpellbookService_GenerateEducationalTrail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EducationalTrailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpellbookServiceServer).GenerateEducationalTrail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpellbookService_GenerateEducationalTrail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpellbookServiceServer).GenerateEducationalTrail(ctx, req.(*EducationalTrailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SpellbookService_StreamRoadmap_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RoadmapRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SpellbookServiceServer).StreamRoadmap(m, &grpc.GenericServerStream[RoadmapRequest, RoadmapEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SpellbookService_StreamRoadmapServer = grpc.ServerStreamingServer[RoadmapEvent]
+
+func _SpellbookService_StreamEducationalRoadmap_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EducationalRoadmapRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SpellbookServiceServer).StreamEducationalRoadmap(m, &grpc.GenericServerStream[EducationalRoadmapRequest, EducationalRoadmapEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SpellbookService_StreamEducationalRoadmapServer = grpc.ServerStreamingServer[EducationalRoadmapEvent]
+
+func _SpellbookService_StreamEducationalTrail_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EducationalTrailRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SpellbookServiceServer).StreamEducationalTrail(m, &grpc.GenericServerStream[EducationalTrailRequest, EducationalTrailEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SpellbookService_StreamEducationalTrailServer = grpc.ServerStreamingServer[EducationalTrailEvent]
+
+// SpellbookService_ServiceDesc is the grpc.ServiceDesc for SpellbookService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SpellbookService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spellbook.v1.SpellbookService",
+	HandlerType: (*SpellbookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GenerateRoadmap",
+			Handler:    _SpellbookService_GenerateRoadmap_Handler,
+		},
+		{
+			MethodName: "GenerateTopics",
+			Handler:    _SpellbookService_GenerateTopics_Handler,
+		},
+		{
+			MethodName: "GenerateKeyResults",
+			Handler:    _SpellbookService_GenerateKeyResults_Handler,
+		},
+		{
+			MethodName: "GenerateEducationalRoadmap",
+			Handler:    _SpellbookService_GenerateEducationalRoadmap_Handler,
+		},
+		{
+			MethodName: "GenerateEducationalTrail",
+			Handler:    _SpellbookService_GenerateEducationalTrail_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamRoadmap",
+			Handler:       _SpellbookService_StreamRoadmap_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamEducationalRoadmap",
+			Handler:       _SpellbookService_StreamEducationalRoadmap_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamEducationalTrail",
+			Handler:       _SpellbookService_StreamEducationalTrail_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "spellbook/v1/spellbook.proto",
+}